@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/hbk619/gh-peruse/cmd/discussion/internal"
+	"github.com/hbk619/gh-peruse/internal/cli"
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/github"
+	"github.com/hbk619/gh-peruse/internal/history"
+	internal_os "github.com/hbk619/gh-peruse/internal/os"
+	"github.com/spf13/cobra"
+)
+
+var DiscussionCmd = &cobra.Command{
+	Use:   "discussion <number>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Browse Github discussions",
+	Long:  `View a discussion, its comments and their replies one by one, reply and mark the answer`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Browse(cmd, func(settings cli.Settings) error {
+			return browse(cmd, args, settings)
+		})
+	},
+}
+
+// browse reads the discussion with the settings from its flags
+func browse(cmd *cobra.Command, args []string, settings cli.Settings) error {
+	historyService, err := history.NewHistoryService(os.Getenv("HOME"), filesystem.NewFS())
+	if err != nil {
+		return err
+	}
+	repo, err := cli.RepoFlag(cmd)
+	if err != nil {
+		return err
+	}
+	graphQlClient, _, err := cli.NewClients(repo.Host)
+	if err != nil {
+		return err
+	}
+
+	discussion := internal.NewDiscussionAction(github.NewDiscussionClient(graphQlClient), historyService, settings.Output, internal_os.NewClipboard(), settings.Prompt, internal_os.NewEditor())
+	discussion.Repo = repo
+	discussion.Confirm = settings.Confirm
+	discussion.Raw = settings.Raw
+	discussion.Renderer.ReadURLs = settings.ReadURLs
+	discussion.Verbosity = settings.Verbosity
+	err = discussion.Init(args)
+	if err != nil {
+		return err
+	}
+	return discussion.Run()
+}
+
+func init() {
+	cli.AddBrowseFlags(DiscussionCmd)
+	cli.AddVerbosityFlag(DiscussionCmd, "author,time,body,reactions")
+}
@@ -0,0 +1,329 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hbk619/gh-peruse/internal"
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github"
+	"github.com/hbk619/gh-peruse/internal/history"
+	"github.com/hbk619/gh-peruse/internal/markdown"
+	internal_os "github.com/hbk619/gh-peruse/internal/os"
+)
+
+// commands are what can be typed while browsing, single key input needs them to know which keys act alone
+var commands = []string{"n", "p", "tn", "tp", "r", "c", "thread", "cm", "ce", "cq", "emoji", "answer", "h", "x", "q"}
+
+type DiscussionAction struct {
+	Id         string
+	Viewer     string
+	Number     int
+	Title      string
+	Category   string
+	Answerable bool
+	Repo       *git.Repo
+	Results    []git.Comment
+	HelpText   string
+	Confirm    bool
+	Raw        bool
+	Renderer   *markdown.Renderer
+	Verbosity  internal.Verbosity
+	client     github.DiscussionsClient
+	history    history.Storage
+	output     filesystem.Output
+	clipboard  internal_os.Clippy
+	prompt     internal.Prompt
+	composer   *internal.Composer
+	now        func() time.Time
+	internal.Interactive
+	internal.CommandLoop
+}
+
+func NewDiscussionAction(client github.DiscussionsClient, history history.Storage, output filesystem.Output, clipboard internal_os.Clippy, prompt internal.Prompt, editor internal_os.TextEditor) *DiscussionAction {
+	return &DiscussionAction{
+		Repo:        &git.Repo{},
+		Confirm:     true,
+		Renderer:    markdown.NewRenderer(),
+		Verbosity:   internal.Normal,
+		now:         time.Now,
+		HelpText:    "Type tn to go to the next thread, tp for the previous thread, c to reply in this thread, cm to write a reply over several lines, ce to write a reply in your editor, cq to quote the comment in your reply, thread to start a new thread, emoji to add or remove a reaction, answer to mark or unmark the comment as the answer or h to hear this again",
+		client:      client,
+		history:     history,
+		output:      output,
+		clipboard:   clipboard,
+		prompt:      prompt,
+		composer:    internal.NewComposer(prompt, editor, output),
+		Interactive: internal.Interactive{Output: output},
+	}
+}
+
+// Init gets the discussion with its comments and replies, reads the title and then the discussion itself
+func (discussion *DiscussionAction) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("please provide a discussion number")
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+	if err != nil {
+		return errors.New("please provide a valid discussion number")
+	}
+
+	if discussion.Repo.Owner == "" {
+		repoDetails, err := discussion.client.GetRepoDetails()
+		if err != nil {
+			return err
+		}
+		discussion.Repo.Host = repoDetails.Host
+		discussion.Repo.Owner = repoDetails.Owner
+		discussion.Repo.Name = repoDetails.Name
+	}
+	discussion.Number = number
+
+	details, err := discussion.client.GetDiscussion(discussion.Repo, number)
+	if err != nil {
+		return err
+	}
+	discussion.Results = details.Comments
+	discussion.Id = details.Id
+	discussion.Title = details.Title
+	discussion.Category = details.Category
+	discussion.Answerable = details.Answerable
+	discussion.Viewer = details.Viewer
+	discussion.composer.SetParticipants(internal.Participants(discussion.Results, discussion.Viewer))
+
+	_ = discussion.output.Println(discussion.Title)
+	_ = discussion.output.Println(fmt.Sprintf("%s, %d threads", discussion.Category, len(discussion.threadStarts())))
+	discussion.Interactive.MaxIndex = len(discussion.Results) - 1
+	discussion.Print()
+	return nil
+}
+
+// threadStarts lists the index of the discussion itself and each top level comment
+func (discussion *DiscussionAction) threadStarts() []int {
+	var starts []int
+	for i, comment := range discussion.Results {
+		if i > 0 && comment.Thread.ID == comment.Id {
+			starts = append(starts, i)
+		}
+	}
+	return starts
+}
+
+// NextThread moves to the next top level comment, skipping the replies in the current thread
+func (discussion *DiscussionAction) NextThread() {
+	for _, start := range discussion.threadStarts() {
+		if start > discussion.Interactive.Index {
+			discussion.Interactive.Index = start
+			discussion.Print()
+			return
+		}
+	}
+	_ = discussion.output.Println("No more threads")
+}
+
+// PreviousThread moves to the start of the current thread, or to the thread before when already at its start
+func (discussion *DiscussionAction) PreviousThread() {
+	starts := discussion.threadStarts()
+	for i := len(starts) - 1; i >= 0; i-- {
+		if starts[i] < discussion.Interactive.Index {
+			discussion.Interactive.Index = starts[i]
+			discussion.Print()
+			return
+		}
+	}
+	if discussion.Interactive.Index > 0 {
+		discussion.Interactive.Index = 0
+		discussion.Print()
+		return
+	}
+	_ = discussion.output.Println("No more threads")
+}
+
+// Comment replies in the thread of the current comment, or starts a new thread when newThread is set or
+// the current comment is the discussion itself. The reply is kept as a draft of the thread until it is posted.
+func (discussion *DiscussionAction) Comment(write func() string, newThread bool) error {
+	target := discussion.Results[discussion.Interactive.Index]
+	if newThread {
+		target = discussion.Results[0]
+	}
+	draft := internal.Draft{
+		Storage: discussion.history,
+		Item:    history.Key(discussion.Repo, discussion.Number),
+		Key:     target.Thread.ID,
+	}
+	return discussion.composer.Compose(write, discussion.Confirm, draft, func(body string) error {
+		return discussion.reply(body, &target)
+	})
+}
+
+func (discussion *DiscussionAction) reply(body string, target *git.Comment) error {
+	if strings.TrimSpace(body) == "" {
+		_ = discussion.output.Println("Comment is empty, nothing posted")
+		return errors.New("comment is empty")
+	}
+	err := discussion.client.Reply(body, target, discussion.Id)
+	if err != nil {
+		_ = discussion.output.Println(fmt.Sprintf("Warning failed to comment: %s", err.Error()))
+		return err
+	}
+	if target.Thread.ID == "" {
+		_ = discussion.output.Println("Started a new thread")
+	} else {
+		_ = discussion.output.Println("Posted reply")
+	}
+	return nil
+}
+
+// React adds the reaction to the current comment, or removes it if already added
+func (discussion *DiscussionAction) React(input string) error {
+	return internal.ToggleReaction(discussion.client, &discussion.Results[discussion.Interactive.Index], input, discussion.prompt, discussion.output)
+}
+
+// Answer marks the current comment as the answer, or unmarks it if it already is, only in categories that take answers
+func (discussion *DiscussionAction) Answer() error {
+	if !discussion.Answerable {
+		_ = discussion.output.Println(fmt.Sprintf("%s discussions do not have answers", discussion.Category))
+		return errors.New("category is not answerable")
+	}
+	current := &discussion.Results[discussion.Interactive.Index]
+	if current.Kind != git.DiscussionCommentKind {
+		_ = discussion.output.Println("Only a comment can be the answer")
+		return errors.New("not a comment")
+	}
+
+	if current.IsAnswer {
+		err := discussion.client.UnmarkAnswer(current)
+		if err != nil {
+			_ = discussion.output.Println(fmt.Sprintf("Warning failed to unmark answer: %s", err.Error()))
+			return err
+		}
+		current.IsAnswer = false
+		_ = discussion.output.Println("No longer the answer")
+		return nil
+	}
+
+	err := discussion.client.MarkAnswer(current)
+	if err != nil {
+		_ = discussion.output.Println(fmt.Sprintf("Warning failed to mark answer: %s", err.Error()))
+		return err
+	}
+	for i := range discussion.Results {
+		discussion.Results[i].IsAnswer = false
+	}
+	current.IsAnswer = true
+	_ = discussion.output.Println("Marked as the answer")
+	return nil
+}
+
+// Run reads commands until q is entered or there is nothing left to read, it returns an error if any command failed
+func (discussion *DiscussionAction) Run() error {
+	return discussion.RunLoop(discussion.doPrompt)
+}
+
+func (discussion *DiscussionAction) doPrompt() error {
+	prompt := "n to go to the next comment, p for previous, tn for the next thread, tp for the previous thread, r to repeat, c to reply, x to copy, h for more or q to quit"
+	currentComment := discussion.Results[discussion.Interactive.Index]
+	result := internal.ReadCommand(discussion.prompt, prompt, commands)
+	command, argument, _ := strings.Cut(result, " ")
+	argument = strings.TrimSpace(argument)
+	var err error
+	switch command {
+	case "n":
+		discussion.Interactive.Next(discussion.Print)
+	case "p":
+		discussion.Interactive.Previous(discussion.Print)
+	case "tn":
+		discussion.NextThread()
+	case "tp":
+		discussion.PreviousThread()
+	case "r":
+		discussion.Interactive.Repeat(discussion.Print)
+	case "c", "cm", "ce", "cq":
+		write, _ := discussion.composer.Writer(command, argument, currentComment, discussion.Viewer)
+		err = discussion.Comment(write, false)
+	case "thread":
+		write, _ := discussion.composer.Writer("c", argument, currentComment, discussion.Viewer)
+		err = discussion.Comment(write, true)
+	case "emoji":
+		err = discussion.React(argument)
+	case "answer":
+		err = discussion.Answer()
+	case "h":
+		_ = discussion.output.Println(discussion.HelpText)
+	case "x":
+		err = discussion.clipboard.Write(currentComment.Body)
+		if err != nil {
+			_ = discussion.output.Println(err.Error())
+		}
+	case "q":
+		discussion.Stop()
+	default:
+		_ = discussion.output.Println("Invalid choice")
+		err = fmt.Errorf("invalid choice %s", result)
+	}
+	return err
+}
+
+// Print reads where the comment sits in its thread and then the fields chosen by the verbosity
+func (discussion *DiscussionAction) Print() {
+	current := discussion.Results[discussion.Interactive.Index]
+	if position := discussion.position(); position != "" {
+		_ = discussion.output.Println(position)
+	}
+	if current.IsAnswer {
+		_ = discussion.output.Println("Answer")
+	}
+	for _, field := range discussion.Verbosity.Fields {
+		switch field {
+		case internal.AuthorField:
+			_ = discussion.output.Println(current.Author.Login)
+		case internal.TimeField:
+			if !current.CreatedAt.IsZero() {
+				_ = discussion.output.Println(internal.RelativeTime(current.CreatedAt, discussion.now()))
+			}
+		case internal.BodyField:
+			body := current.Body
+			if strings.TrimSpace(body) == "" {
+				body = "No description provided"
+			} else if !discussion.Raw {
+				body = discussion.Renderer.Render(body)
+			}
+			_ = discussion.output.Println(body)
+		case internal.ReactionsField:
+			if reactions := current.ReactionSummary(); reactions != "" {
+				_ = discussion.output.Println(reactions)
+			}
+		}
+	}
+}
+
+// position says which thread a top level comment starts and how many replies it has, or which reply this is
+func (discussion *DiscussionAction) position() string {
+	current := discussion.Results[discussion.Interactive.Index]
+	if current.Thread.ID == "" {
+		return ""
+	}
+	var replies []string
+	for _, comment := range discussion.Results {
+		if comment.Thread.ID == current.Thread.ID && comment.Id != current.Thread.ID {
+			replies = append(replies, comment.Id)
+		}
+	}
+	if current.Id == current.Thread.ID {
+		thread := slices.Index(discussion.threadStarts(), discussion.Interactive.Index) + 1
+		switch len(replies) {
+		case 0:
+			return fmt.Sprintf("Thread %d", thread)
+		case 1:
+			return fmt.Sprintf("Thread %d, 1 reply", thread)
+		default:
+			return fmt.Sprintf("Thread %d, %d replies", thread, len(replies))
+		}
+	}
+	return fmt.Sprintf("Reply %d of %d", slices.Index(replies, current.Id)+1, len(replies))
+}
@@ -0,0 +1,290 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/hbk619/gh-peruse/internal"
+	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
+	"github.com/hbk619/gh-peruse/internal/git"
+	mock_github "github.com/hbk619/gh-peruse/internal/github/mocks"
+	"github.com/hbk619/gh-peruse/internal/history"
+	mock_history "github.com/hbk619/gh-peruse/internal/history/mocks"
+	mock_internal "github.com/hbk619/gh-peruse/internal/mocks"
+	mock_os "github.com/hbk619/gh-peruse/internal/os/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type DiscussionActionTestSuite struct {
+	suite.Suite
+	ctrl             *gomock.Controller
+	discussionAction *DiscussionAction
+	mockOutput       *mock_filesystem.MockOutput
+	mockClipboard    *mock_os.MockClippy
+	mockClient       *mock_github.MockDiscussionsClient
+	mockHistory      *mock_history.MockStorage
+	mockPrompt       *mock_internal.MockPrompt
+	mockEditor       *mock_os.MockTextEditor
+}
+
+func (suite *DiscussionActionTestSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOutput = mock_filesystem.NewMockOutput(suite.ctrl)
+	suite.mockClient = mock_github.NewMockDiscussionsClient(suite.ctrl)
+	suite.mockClipboard = mock_os.NewMockClippy(suite.ctrl)
+	suite.mockPrompt = mock_internal.NewMockPrompt(suite.ctrl)
+	suite.mockEditor = mock_os.NewMockTextEditor(suite.ctrl)
+	suite.mockHistory = mock_history.NewMockStorage(suite.ctrl)
+	suite.discussionAction = NewDiscussionAction(suite.mockClient, suite.mockHistory, suite.mockOutput, suite.mockClipboard, suite.mockPrompt, suite.mockEditor)
+	suite.discussionAction.Id = "D_kwDOA"
+	suite.discussionAction.Number = 3
+	suite.discussionAction.Category = "Q&A"
+	suite.discussionAction.Answerable = true
+	suite.discussionAction.Verbosity = internal.Verbosity{Fields: []internal.Field{internal.AuthorField, internal.BodyField}}
+	suite.discussionAction.Results = []git.Comment{
+		{Id: "D_kwDOA", Body: "What if pipes were faster?", Author: git.Author{Login: "toad"}, Kind: git.DiscussionKind},
+		{Id: "DC_1", Body: "Use a star", Author: git.Author{Login: "mario"}, Kind: git.DiscussionCommentKind, Thread: git.Thread{ID: "DC_1"}},
+		{Id: "DC_2", Body: "Too expensive", Author: git.Author{Login: "toad"}, Kind: git.DiscussionCommentKind, Thread: git.Thread{ID: "DC_1"}},
+		{Id: "DC_3", Body: "Warp zones", Author: git.Author{Login: "luigi"}, Kind: git.DiscussionCommentKind, Thread: git.Thread{ID: "DC_3"}, IsAnswer: true},
+	}
+	suite.discussionAction.Interactive.MaxIndex = 3
+}
+
+func (suite *DiscussionActionTestSuite) TestInit_reads_title_category_and_discussion() {
+	results := suite.discussionAction.Results
+	suite.mockClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	suite.mockClient.EXPECT().GetDiscussion(&git.Repo{Owner: "luigi", Name: "castle"}, 3).Return(&git.DiscussionDetails{
+		Comments:   results,
+		Title:      "RFC: faster pipes",
+		Id:         "D_kwDOA",
+		Viewer:     "mario",
+		Category:   "Ideas",
+		Answerable: false,
+	}, nil)
+	suite.mockOutput.EXPECT().Println("RFC: faster pipes")
+	suite.mockOutput.EXPECT().Println("Ideas, 2 threads")
+	suite.mockOutput.EXPECT().Println("toad")
+	suite.mockOutput.EXPECT().Println("What if pipes were faster?")
+
+	err := suite.discussionAction.Init([]string{"3"})
+	suite.NoError(err)
+	suite.False(suite.discussionAction.Answerable)
+	suite.Equal(3, suite.discussionAction.Interactive.MaxIndex)
+}
+
+func (suite *DiscussionActionTestSuite) TestInit_uses_repo_from_flag() {
+	suite.discussionAction.Repo = &git.Repo{Owner: "peach", Name: "palace"}
+	suite.mockClient.EXPECT().GetDiscussion(&git.Repo{Owner: "peach", Name: "palace"}, 3).Return(nil, errors.New("discussion 3 not found"))
+
+	err := suite.discussionAction.Init([]string{"3"})
+	suite.EqualError(err, "discussion 3 not found")
+}
+
+func (suite *DiscussionActionTestSuite) TestInit_has_error() {
+	suite.mockClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	suite.mockClient.EXPECT().GetDiscussion(gomock.Any(), 3).Return(nil, errors.New("discussion 3 not found"))
+
+	err := suite.discussionAction.Init([]string{"3"})
+	suite.EqualError(err, "discussion 3 not found")
+}
+
+func (suite *DiscussionActionTestSuite) TestPrint_thread_with_replies() {
+	suite.discussionAction.Interactive.Index = 1
+	suite.mockOutput.EXPECT().Println("Thread 1, 1 reply")
+	suite.mockOutput.EXPECT().Println("mario")
+	suite.mockOutput.EXPECT().Println("Use a star")
+
+	suite.discussionAction.Print()
+}
+
+func (suite *DiscussionActionTestSuite) TestPrint_reply() {
+	suite.discussionAction.Interactive.Index = 2
+	suite.mockOutput.EXPECT().Println("Reply 1 of 1")
+	suite.mockOutput.EXPECT().Println("toad")
+	suite.mockOutput.EXPECT().Println("Too expensive")
+
+	suite.discussionAction.Print()
+}
+
+func (suite *DiscussionActionTestSuite) TestPrint_answer() {
+	suite.discussionAction.Interactive.Index = 3
+	suite.mockOutput.EXPECT().Println("Thread 2")
+	suite.mockOutput.EXPECT().Println("Answer")
+	suite.mockOutput.EXPECT().Println("luigi")
+	suite.mockOutput.EXPECT().Println("Warp zones")
+
+	suite.discussionAction.Print()
+}
+
+func (suite *DiscussionActionTestSuite) TestNextThread_skips_replies() {
+	suite.discussionAction.Interactive.Index = 1
+	suite.mockOutput.EXPECT().Println("Thread 2")
+	suite.mockOutput.EXPECT().Println("Answer")
+	suite.mockOutput.EXPECT().Println("luigi")
+	suite.mockOutput.EXPECT().Println("Warp zones")
+
+	suite.discussionAction.NextThread()
+	suite.Equal(3, suite.discussionAction.Interactive.Index)
+}
+
+func (suite *DiscussionActionTestSuite) TestNextThread_at_last_thread() {
+	suite.discussionAction.Interactive.Index = 3
+	suite.mockOutput.EXPECT().Println("No more threads")
+
+	suite.discussionAction.NextThread()
+	suite.Equal(3, suite.discussionAction.Interactive.Index)
+}
+
+func (suite *DiscussionActionTestSuite) TestPreviousThread_from_reply_goes_to_thread_start() {
+	suite.discussionAction.Interactive.Index = 2
+	suite.mockOutput.EXPECT().Println("Thread 1, 1 reply")
+	suite.mockOutput.EXPECT().Println("mario")
+	suite.mockOutput.EXPECT().Println("Use a star")
+
+	suite.discussionAction.PreviousThread()
+	suite.Equal(1, suite.discussionAction.Interactive.Index)
+}
+
+func (suite *DiscussionActionTestSuite) TestPreviousThread_from_first_thread_goes_to_discussion() {
+	suite.discussionAction.Interactive.Index = 1
+	suite.mockOutput.EXPECT().Println("toad")
+	suite.mockOutput.EXPECT().Println("What if pipes were faster?")
+
+	suite.discussionAction.PreviousThread()
+	suite.Equal(0, suite.discussionAction.Interactive.Index)
+}
+
+func (suite *DiscussionActionTestSuite) TestComment_replies_in_thread() {
+	suite.discussionAction.Confirm = false
+	suite.discussionAction.Interactive.Index = 2
+	suite.mockClient.EXPECT().Reply("Agreed", &suite.discussionAction.Results[2], "D_kwDOA").Return(nil)
+	suite.mockOutput.EXPECT().Println("Posted reply")
+
+	err := suite.discussionAction.Comment(func() string { return "Agreed" }, false)
+	suite.NoError(err)
+}
+
+func (suite *DiscussionActionTestSuite) TestComment_new_thread() {
+	suite.discussionAction.Interactive.Index = 2
+	suite.expectDraftSaved("", "New idea")
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("New idea")
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("p")
+	suite.mockClient.EXPECT().Reply("New idea", &suite.discussionAction.Results[0], "D_kwDOA").Return(nil)
+	suite.mockOutput.EXPECT().Println("Started a new thread")
+	suite.expectDraftDeleted("", "New idea")
+
+	err := suite.discussionAction.Comment(func() string { return "New idea" }, true)
+	suite.NoError(err)
+}
+
+func (suite *DiscussionActionTestSuite) TestComment_keeps_draft_of_thread_when_cancelled() {
+	suite.discussionAction.Interactive.Index = 2
+	otherThread := func() history.History {
+		return history.History{Prs: map[string]history.PR{suite.historyKey(): {Drafts: map[string]string{"DC_3": "Other thread"}}}}
+	}
+	bothThreads := func() history.History {
+		return history.History{Prs: map[string]history.PR{suite.historyKey(): {Drafts: map[string]string{"DC_1": "Agreed", "DC_3": "Other thread"}}}}
+	}
+	suite.mockHistory.EXPECT().Load().Return(otherThread(), nil)
+	suite.mockHistory.EXPECT().Load().Return(otherThread(), nil)
+	suite.mockHistory.EXPECT().Save(bothThreads()).Return(nil)
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("Agreed")
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("c")
+	suite.mockOutput.EXPECT().Println("Comment cancelled")
+	suite.mockHistory.EXPECT().Load().Return(bothThreads(), nil)
+	suite.mockHistory.EXPECT().Save(otherThread()).Return(nil)
+
+	err := suite.discussionAction.Comment(func() string { return "Agreed" }, false)
+	suite.NoError(err)
+}
+
+func (suite *DiscussionActionTestSuite) TestComment_has_error() {
+	suite.discussionAction.Confirm = false
+	suite.mockClient.EXPECT().Reply("Agreed", gomock.Any(), "D_kwDOA").Return(errors.New("offline"))
+	suite.mockOutput.EXPECT().Println("Warning failed to comment: offline")
+
+	err := suite.discussionAction.Comment(func() string { return "Agreed" }, false)
+	suite.EqualError(err, "offline")
+}
+
+func (suite *DiscussionActionTestSuite) TestAnswer_marks_comment() {
+	suite.discussionAction.Interactive.Index = 1
+	suite.mockClient.EXPECT().MarkAnswer(&suite.discussionAction.Results[1]).Return(nil)
+	suite.mockOutput.EXPECT().Println("Marked as the answer")
+
+	err := suite.discussionAction.Answer()
+	suite.NoError(err)
+	suite.True(suite.discussionAction.Results[1].IsAnswer)
+	suite.False(suite.discussionAction.Results[3].IsAnswer)
+}
+
+func (suite *DiscussionActionTestSuite) TestAnswer_unmarks_answer() {
+	suite.discussionAction.Interactive.Index = 3
+	suite.mockClient.EXPECT().UnmarkAnswer(&suite.discussionAction.Results[3]).Return(nil)
+	suite.mockOutput.EXPECT().Println("No longer the answer")
+
+	err := suite.discussionAction.Answer()
+	suite.NoError(err)
+	suite.False(suite.discussionAction.Results[3].IsAnswer)
+}
+
+func (suite *DiscussionActionTestSuite) TestAnswer_category_without_answers() {
+	suite.discussionAction.Answerable = false
+	suite.discussionAction.Category = "Ideas"
+	suite.mockOutput.EXPECT().Println("Ideas discussions do not have answers")
+
+	err := suite.discussionAction.Answer()
+	suite.Error(err)
+}
+
+func (suite *DiscussionActionTestSuite) TestAnswer_discussion_itself() {
+	suite.mockOutput.EXPECT().Println("Only a comment can be the answer")
+
+	err := suite.discussionAction.Answer()
+	suite.Error(err)
+}
+
+func (suite *DiscussionActionTestSuite) TestRun_thread_commands() {
+	gomock.InOrder(
+		suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("tn"),
+		suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("c"),
+		suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("q"),
+	)
+	suite.discussionAction.Confirm = false
+	suite.mockOutput.EXPECT().Println("Thread 1, 1 reply")
+	suite.mockOutput.EXPECT().Println("mario")
+	suite.mockOutput.EXPECT().Println("Use a star")
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("")
+	suite.mockOutput.EXPECT().Println("Comment is empty, nothing posted")
+
+	err := suite.discussionAction.Run()
+	suite.EqualError(err, "1 command failed")
+}
+
+func (suite *DiscussionActionTestSuite) TestCommands_navigation_keys_act_on_a_single_press() {
+	for _, key := range []string{"n", "p", "r", "q"} {
+		suite.True(internal.ActsOnPress(key, commands), key)
+	}
+}
+
+func (suite *DiscussionActionTestSuite) historyKey() string {
+	return history.Key(suite.discussionAction.Repo, suite.discussionAction.Number)
+}
+
+func (suite *DiscussionActionTestSuite) expectDraftSaved(key string, body string) {
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{}}, nil).Times(2)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{suite.historyKey(): {Drafts: map[string]string{key: body}}}}).Return(nil)
+}
+
+func (suite *DiscussionActionTestSuite) expectDraftDeleted(key string, body string) {
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{suite.historyKey(): {Drafts: map[string]string{key: body}}}}, nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{suite.historyKey(): {}}}).Return(nil)
+}
+
+func TestDiscussionActionTestSuite(t *testing.T) {
+	suite.Run(t, new(DiscussionActionTestSuite))
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/hbk619/gh-peruse/cmd/issue/internal"
+	"github.com/hbk619/gh-peruse/internal/cli"
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/github"
+	"github.com/hbk619/gh-peruse/internal/history"
+	internal_os "github.com/hbk619/gh-peruse/internal/os"
+	"github.com/spf13/cobra"
+)
+
+var IssueCmd = &cobra.Command{
+	Use:   "issue <number>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Browse Github issue comments",
+	Long:  `View an issue and its comments one by one, reply, react and close or reopen it`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Browse(cmd, func(settings cli.Settings) error {
+			return browse(cmd, args, settings)
+		})
+	},
+}
+
+// browse reads the issue with the settings from its flags
+func browse(cmd *cobra.Command, args []string, settings cli.Settings) error {
+	historyService, err := history.NewHistoryService(os.Getenv("HOME"), filesystem.NewFS())
+	if err != nil {
+		return err
+	}
+	repo, err := cli.RepoFlag(cmd)
+	if err != nil {
+		return err
+	}
+	graphQlClient, _, err := cli.NewClients(repo.Host)
+	if err != nil {
+		return err
+	}
+
+	issue := internal.NewIssueAction(github.NewIssueClient(graphQlClient), historyService, settings.Output, internal_os.NewClipboard(), settings.Prompt, internal_os.NewEditor())
+	issue.Repo = repo
+	issue.Confirm = settings.Confirm
+	issue.Raw = settings.Raw
+	issue.Renderer.ReadURLs = settings.ReadURLs
+	issue.Verbosity = settings.Verbosity
+	err = issue.Init(args)
+	if err != nil {
+		return err
+	}
+	return issue.Run()
+}
+
+func init() {
+	cli.AddBrowseFlags(IssueCmd)
+	cli.AddVerbosityFlag(IssueCmd, "author,time,body,reactions")
+}
@@ -0,0 +1,254 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hbk619/gh-peruse/internal"
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github"
+	"github.com/hbk619/gh-peruse/internal/history"
+	"github.com/hbk619/gh-peruse/internal/markdown"
+	internal_os "github.com/hbk619/gh-peruse/internal/os"
+)
+
+// commands are what can be typed while browsing, single key input needs them to know which keys act alone
+var commands = []string{"n", "p", "r", "c", "cm", "ce", "cq", "emoji", "close", "open", "h", "x", "q"}
+
+type IssueAction struct {
+	Id        string
+	Viewer    string
+	Number    int
+	Title     string
+	Closed    bool
+	Repo      *git.Repo
+	Results   []git.Comment
+	HelpText  string
+	Confirm   bool
+	Raw       bool
+	Renderer  *markdown.Renderer
+	Verbosity internal.Verbosity
+	client    github.IssuesClient
+	history   history.Storage
+	output    filesystem.Output
+	clipboard internal_os.Clippy
+	prompt    internal.Prompt
+	composer  *internal.Composer
+	now       func() time.Time
+	internal.Interactive
+	internal.CommandLoop
+}
+
+func NewIssueAction(client github.IssuesClient, history history.Storage, output filesystem.Output, clipboard internal_os.Clippy, prompt internal.Prompt, editor internal_os.TextEditor) *IssueAction {
+	return &IssueAction{
+		Repo:        &git.Repo{},
+		Confirm:     true,
+		Renderer:    markdown.NewRenderer(),
+		Verbosity:   internal.Normal,
+		now:         time.Now,
+		HelpText:    "Type c to comment, cm to write a comment over several lines, ce to write a comment in your editor, cq to quote the comment in your reply, emoji to add or remove a reaction, close to close the issue, close not planned to close it as not planned, open to reopen it or h to hear this again",
+		client:      client,
+		history:     history,
+		output:      output,
+		clipboard:   clipboard,
+		prompt:      prompt,
+		composer:    internal.NewComposer(prompt, editor, output),
+		Interactive: internal.Interactive{Output: output},
+	}
+}
+
+// Init gets the issue and its comments, reads the title and then the issue itself
+func (issue *IssueAction) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("please provide an issue number")
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+	if err != nil {
+		return errors.New("please provide a valid issue number")
+	}
+
+	if issue.Repo.Owner == "" {
+		repoDetails, err := issue.client.GetRepoDetails()
+		if err != nil {
+			return err
+		}
+		issue.Repo.Host = repoDetails.Host
+		issue.Repo.Owner = repoDetails.Owner
+		issue.Repo.Name = repoDetails.Name
+	}
+	issue.Number = number
+
+	details, err := issue.client.GetIssue(issue.Repo, number)
+	if err != nil {
+		return err
+	}
+	issue.Results = details.Comments
+	issue.Id = details.Id
+	issue.Title = details.Title
+	issue.Closed = details.Closed
+	issue.Viewer = details.Viewer
+	issue.composer.SetParticipants(internal.Participants(issue.Results, issue.Viewer))
+
+	_ = issue.output.Println(issue.Title)
+	if issue.Closed {
+		_ = issue.output.Println("This issue is closed")
+	}
+	issue.Interactive.MaxIndex = len(issue.Results) - 1
+	issue.Print()
+	return nil
+}
+
+// Comment writes a comment on the issue, reads it back for confirmation and keeps it as a draft until it is posted
+func (issue *IssueAction) Comment(write func() string) error {
+	draft := internal.Draft{
+		Storage: issue.history,
+		Item:    history.Key(issue.Repo, issue.Number),
+	}
+	return issue.composer.Compose(write, issue.Confirm, draft, issue.post)
+}
+
+func (issue *IssueAction) post(body string) error {
+	if strings.TrimSpace(body) == "" {
+		_ = issue.output.Println("Comment is empty, nothing posted")
+		return errors.New("comment is empty")
+	}
+	err := issue.client.Comment(body, issue.Id)
+	if err != nil {
+		_ = issue.output.Println(fmt.Sprintf("Warning failed to comment: %s", err.Error()))
+		return err
+	}
+	_ = issue.output.Println("Posted comment")
+	return nil
+}
+
+// React adds the reaction to the current comment, or removes it if already added
+func (issue *IssueAction) React(input string) error {
+	return internal.ToggleReaction(issue.client, &issue.Results[issue.Interactive.Index], input, issue.prompt, issue.output)
+}
+
+// Close closes the issue as completed, or as not planned when the reason is "not planned"
+func (issue *IssueAction) Close(reason string) error {
+	if issue.Closed {
+		_ = issue.output.Println("This issue is already closed")
+		return errors.New("issue already closed")
+	}
+	stateReason, description := "COMPLETED", "completed"
+	switch strings.ToLower(reason) {
+	case "", "completed":
+	case "not planned":
+		stateReason, description = "NOT_PLANNED", "not planned"
+	default:
+		err := fmt.Errorf("unknown reason %s, use completed or not planned", reason)
+		_ = issue.output.Println(err.Error())
+		return err
+	}
+	if issue.Confirm && issue.prompt.String(fmt.Sprintf("y to close this issue as %s, anything else to keep it open", description)) != "y" {
+		_ = issue.output.Println("Issue left open")
+		return nil
+	}
+
+	err := issue.client.Close(issue.Id, stateReason)
+	if err != nil {
+		_ = issue.output.Println(fmt.Sprintf("Warning failed to close issue: %s", err.Error()))
+		return err
+	}
+	issue.Closed = true
+	_ = issue.output.Println("Issue closed")
+	return nil
+}
+
+// Reopen opens a closed issue again
+func (issue *IssueAction) Reopen() error {
+	if !issue.Closed {
+		_ = issue.output.Println("This issue is already open")
+		return errors.New("issue already open")
+	}
+	if issue.Confirm && issue.prompt.String("y to reopen this issue, anything else to keep it closed") != "y" {
+		_ = issue.output.Println("Issue left closed")
+		return nil
+	}
+
+	err := issue.client.Reopen(issue.Id)
+	if err != nil {
+		_ = issue.output.Println(fmt.Sprintf("Warning failed to reopen issue: %s", err.Error()))
+		return err
+	}
+	issue.Closed = false
+	_ = issue.output.Println("Issue reopened")
+	return nil
+}
+
+// Run reads commands until q is entered or there is nothing left to read, it returns an error if any command failed
+func (issue *IssueAction) Run() error {
+	return issue.RunLoop(issue.doPrompt)
+}
+
+func (issue *IssueAction) doPrompt() error {
+	prompt := "n to go to the next comment, p for previous, r to repeat, c to comment, x to copy, h for more or q to quit"
+	currentComment := issue.Results[issue.Interactive.Index]
+	result := internal.ReadCommand(issue.prompt, prompt, commands)
+	command, argument, _ := strings.Cut(result, " ")
+	argument = strings.TrimSpace(argument)
+	var err error
+	switch command {
+	case "n":
+		issue.Interactive.Next(issue.Print)
+	case "p":
+		issue.Interactive.Previous(issue.Print)
+	case "r":
+		issue.Interactive.Repeat(issue.Print)
+	case "c", "cm", "ce", "cq":
+		write, _ := issue.composer.Writer(command, argument, currentComment, issue.Viewer)
+		err = issue.Comment(write)
+	case "emoji":
+		err = issue.React(argument)
+	case "close":
+		err = issue.Close(argument)
+	case "open":
+		err = issue.Reopen()
+	case "h":
+		_ = issue.output.Println(issue.HelpText)
+	case "x":
+		err = issue.clipboard.Write(currentComment.Body)
+		if err != nil {
+			_ = issue.output.Println(err.Error())
+		}
+	case "q":
+		issue.Stop()
+	default:
+		_ = issue.output.Println("Invalid choice")
+		err = fmt.Errorf("invalid choice %s", result)
+	}
+	return err
+}
+
+// Print reads the fields of the current comment chosen by the verbosity, issues have no files so those fields are skipped
+func (issue *IssueAction) Print() {
+	current := issue.Results[issue.Interactive.Index]
+	for _, field := range issue.Verbosity.Fields {
+		switch field {
+		case internal.AuthorField:
+			_ = issue.output.Println(current.Author.Login)
+		case internal.TimeField:
+			if !current.CreatedAt.IsZero() {
+				_ = issue.output.Println(internal.RelativeTime(current.CreatedAt, issue.now()))
+			}
+		case internal.BodyField:
+			body := current.Body
+			if strings.TrimSpace(body) == "" {
+				body = "No description provided"
+			} else if !issue.Raw {
+				body = issue.Renderer.Render(body)
+			}
+			_ = issue.output.Println(body)
+		case internal.ReactionsField:
+			if reactions := current.ReactionSummary(); reactions != "" {
+				_ = issue.output.Println(reactions)
+			}
+		}
+	}
+}
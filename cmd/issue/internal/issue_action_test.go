@@ -0,0 +1,285 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/golang/mock/gomock"
+	"github.com/hbk619/gh-peruse/internal"
+	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
+	"github.com/hbk619/gh-peruse/internal/git"
+	mock_github "github.com/hbk619/gh-peruse/internal/github/mocks"
+	"github.com/hbk619/gh-peruse/internal/history"
+	mock_history "github.com/hbk619/gh-peruse/internal/history/mocks"
+	mock_internal "github.com/hbk619/gh-peruse/internal/mocks"
+	mock_os "github.com/hbk619/gh-peruse/internal/os/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type IssueActionTestSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	issueAction   *IssueAction
+	mockOutput    *mock_filesystem.MockOutput
+	mockClipboard *mock_os.MockClippy
+	mockClient    *mock_github.MockIssuesClient
+	mockHistory   *mock_history.MockStorage
+	mockPrompt    *mock_internal.MockPrompt
+	mockEditor    *mock_os.MockTextEditor
+}
+
+func (suite *IssueActionTestSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOutput = mock_filesystem.NewMockOutput(suite.ctrl)
+	suite.mockClient = mock_github.NewMockIssuesClient(suite.ctrl)
+	suite.mockClipboard = mock_os.NewMockClippy(suite.ctrl)
+	suite.mockPrompt = mock_internal.NewMockPrompt(suite.ctrl)
+	suite.mockEditor = mock_os.NewMockTextEditor(suite.ctrl)
+	suite.mockHistory = mock_history.NewMockStorage(suite.ctrl)
+	suite.issueAction = NewIssueAction(suite.mockClient, suite.mockHistory, suite.mockOutput, suite.mockClipboard, suite.mockPrompt, suite.mockEditor)
+	suite.issueAction.Id = "I_kwDOA"
+	suite.issueAction.Number = 7
+	suite.issueAction.Results = []git.Comment{
+		{Id: "I_kwDOA", Body: "She is not here", Author: git.Author{Login: "toad"}, Kind: git.IssueKind},
+		{Id: "IC_1", Body: "Which castle?", Author: git.Author{Login: "mario"}, Kind: git.IssueCommentKind},
+	}
+	suite.issueAction.Interactive.MaxIndex = 1
+}
+
+func (suite *IssueActionTestSuite) TestInit_reads_title_and_issue() {
+	suite.mockClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	suite.mockClient.EXPECT().GetIssue(&git.Repo{Owner: "luigi", Name: "castle"}, 7).Return(&git.IssueDetails{
+		Comments: []git.Comment{{Body: "", Author: git.Author{Login: "toad"}, Kind: git.IssueKind}},
+		Title:    "The princess is in another castle",
+		Id:       "I_kwDOA",
+		Viewer:   "mario",
+		Closed:   true,
+	}, nil)
+	suite.mockOutput.EXPECT().Println("The princess is in another castle")
+	suite.mockOutput.EXPECT().Println("This issue is closed")
+	suite.mockOutput.EXPECT().Println("toad")
+	suite.mockOutput.EXPECT().Println("No description provided")
+
+	err := suite.issueAction.Init([]string{"#7"})
+	suite.NoError(err)
+	suite.Equal(7, suite.issueAction.Number)
+	suite.Equal("mario", suite.issueAction.Viewer)
+	suite.Equal(0, suite.issueAction.Interactive.MaxIndex)
+}
+
+func (suite *IssueActionTestSuite) TestInit_uses_repo_from_flag() {
+	suite.issueAction.Repo = &git.Repo{Host: "github.example.com", Owner: "peach", Name: "palace"}
+	suite.mockClient.EXPECT().GetIssue(&git.Repo{Host: "github.example.com", Owner: "peach", Name: "palace"}, 7).Return(&git.IssueDetails{
+		Comments: []git.Comment{{Body: "Cake", Author: git.Author{Login: "toad"}, Kind: git.IssueKind}},
+		Title:    "Bake a cake",
+	}, nil)
+	suite.mockOutput.EXPECT().Println("Bake a cake")
+	suite.mockOutput.EXPECT().Println("toad")
+	suite.mockOutput.EXPECT().Println("Cake")
+
+	suite.NoError(suite.issueAction.Init([]string{"7"}))
+}
+
+func (suite *IssueActionTestSuite) TestInit_invalid_number() {
+	err := suite.issueAction.Init([]string{"castle"})
+	suite.EqualError(err, "please provide a valid issue number")
+}
+
+func (suite *IssueActionTestSuite) TestInit_has_error() {
+	suite.mockClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	suite.mockClient.EXPECT().GetIssue(gomock.Any(), 7).Return(nil, errors.New("failed to fetch issue bad gateway"))
+
+	err := suite.issueAction.Init([]string{"7"})
+	suite.EqualError(err, "failed to fetch issue bad gateway")
+}
+
+func (suite *IssueActionTestSuite) TestPrint_follows_verbosity() {
+	suite.issueAction.Verbosity = internal.Verbosity{Fields: []internal.Field{internal.BodyField, internal.AuthorField}}
+	suite.issueAction.Results[0].Body = "**Not** here"
+	suite.mockOutput.EXPECT().Println("Not here")
+	suite.mockOutput.EXPECT().Println("toad")
+
+	suite.issueAction.Print()
+}
+
+func (suite *IssueActionTestSuite) TestComment_reads_back_and_posts() {
+	suite.expectDraftSaved("On it")
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("On it")
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("p")
+	suite.mockClient.EXPECT().Comment("On it", "I_kwDOA").Return(nil)
+	suite.mockOutput.EXPECT().Println("Posted comment")
+	suite.expectDraftDeleted("On it")
+
+	err := suite.issueAction.Comment(func() string { return "On it" })
+	suite.NoError(err)
+}
+
+func (suite *IssueActionTestSuite) TestComment_cancelled() {
+	suite.expectDraftSaved("On it")
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("On it")
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("c")
+	suite.mockOutput.EXPECT().Println("Comment cancelled")
+	suite.expectDraftDeleted("On it")
+
+	err := suite.issueAction.Comment(func() string { return "On it" })
+	suite.NoError(err)
+}
+
+func (suite *IssueActionTestSuite) TestComment_keeps_draft_when_post_fails() {
+	suite.expectDraftSaved("On it")
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("On it")
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("p")
+	suite.mockClient.EXPECT().Comment("On it", "I_kwDOA").Return(errors.New("offline"))
+	suite.mockOutput.EXPECT().Println("Warning failed to comment: offline")
+
+	err := suite.issueAction.Comment(func() string { return "On it" })
+	suite.EqualError(err, "offline")
+}
+
+func (suite *IssueActionTestSuite) TestComment_uses_unsent_draft() {
+	drafts := history.History{Prs: map[string]history.PR{suite.historyKey(): {Drafts: map[string]string{"": "Half written"}}}}
+	suite.mockHistory.EXPECT().Load().Return(drafts, nil).Times(2)
+	suite.mockOutput.EXPECT().Println("You have an unsent draft")
+	suite.mockOutput.EXPECT().Println("Half written")
+	suite.mockPrompt.EXPECT().String("y to continue with it or n to start again").Return("y")
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("Half written")
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("p")
+	suite.mockClient.EXPECT().Comment("Half written", "I_kwDOA").Return(nil)
+	suite.mockOutput.EXPECT().Println("Posted comment")
+	suite.expectDraftDeleted("Half written")
+
+	err := suite.issueAction.Comment(func() string {
+		suite.Fail("should not write a new comment")
+		return ""
+	})
+	suite.NoError(err)
+}
+
+func (suite *IssueActionTestSuite) TestComment_has_error() {
+	suite.issueAction.Confirm = false
+	suite.mockClient.EXPECT().Comment("On it", "I_kwDOA").Return(errors.New("offline"))
+	suite.mockOutput.EXPECT().Println("Warning failed to comment: offline")
+
+	err := suite.issueAction.Comment(func() string { return "On it" })
+	suite.EqualError(err, "offline")
+}
+
+func (suite *IssueActionTestSuite) TestReact_toggles_reaction() {
+	suite.issueAction.Results[0].Reactions = []git.Reaction{{Content: "HEART", Users: git.ReactionUsers{TotalCount: 1}, ViewerHasReacted: true}}
+	suite.mockClient.EXPECT().RemoveReaction(&suite.issueAction.Results[0], "HEART").Return(nil)
+	suite.mockOutput.EXPECT().Println("Removed heart")
+
+	err := suite.issueAction.React("heart")
+	suite.NoError(err)
+	suite.False(suite.issueAction.Results[0].HasReacted("HEART"))
+}
+
+func (suite *IssueActionTestSuite) TestClose_as_not_planned() {
+	suite.mockPrompt.EXPECT().String("y to close this issue as not planned, anything else to keep it open").Return("y")
+	suite.mockClient.EXPECT().Close("I_kwDOA", "NOT_PLANNED").Return(nil)
+	suite.mockOutput.EXPECT().Println("Issue closed")
+
+	err := suite.issueAction.Close("not planned")
+	suite.NoError(err)
+	suite.True(suite.issueAction.Closed)
+}
+
+func (suite *IssueActionTestSuite) TestClose_kept_open() {
+	suite.mockPrompt.EXPECT().String("y to close this issue as completed, anything else to keep it open").Return("n")
+	suite.mockOutput.EXPECT().Println("Issue left open")
+
+	err := suite.issueAction.Close("")
+	suite.NoError(err)
+	suite.False(suite.issueAction.Closed)
+}
+
+func (suite *IssueActionTestSuite) TestClose_unknown_reason() {
+	suite.mockOutput.EXPECT().Println("unknown reason duplicate, use completed or not planned")
+
+	err := suite.issueAction.Close("duplicate")
+	suite.Error(err)
+}
+
+func (suite *IssueActionTestSuite) TestClose_already_closed() {
+	suite.issueAction.Closed = true
+	suite.mockOutput.EXPECT().Println("This issue is already closed")
+
+	err := suite.issueAction.Close("")
+	suite.Error(err)
+}
+
+func (suite *IssueActionTestSuite) TestReopen() {
+	suite.issueAction.Closed = true
+	suite.mockPrompt.EXPECT().String("y to reopen this issue, anything else to keep it closed").Return("y")
+	suite.mockClient.EXPECT().Reopen("I_kwDOA").Return(nil)
+	suite.mockOutput.EXPECT().Println("Issue reopened")
+
+	err := suite.issueAction.Reopen()
+	suite.NoError(err)
+	suite.False(suite.issueAction.Closed)
+}
+
+func (suite *IssueActionTestSuite) TestReopen_kept_closed() {
+	suite.issueAction.Closed = true
+	suite.mockPrompt.EXPECT().String("y to reopen this issue, anything else to keep it closed").Return("n")
+	suite.mockOutput.EXPECT().Println("Issue left closed")
+
+	err := suite.issueAction.Reopen()
+	suite.NoError(err)
+	suite.True(suite.issueAction.Closed)
+}
+
+func (suite *IssueActionTestSuite) TestReopen_has_error() {
+	suite.issueAction.Closed = true
+	suite.issueAction.Confirm = false
+	suite.mockClient.EXPECT().Reopen("I_kwDOA").Return(errors.New("forbidden"))
+	suite.mockOutput.EXPECT().Println("Warning failed to reopen issue: forbidden")
+
+	err := suite.issueAction.Reopen()
+	suite.Error(err)
+	suite.True(suite.issueAction.Closed)
+}
+
+func (suite *IssueActionTestSuite) TestRun_navigates_and_counts_failures() {
+	gomock.InOrder(
+		suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("n"),
+		suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("open"),
+		suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("q"),
+	)
+	suite.mockOutput.EXPECT().Println("mario")
+	suite.mockOutput.EXPECT().Println("Which castle?")
+	suite.mockOutput.EXPECT().Println("This issue is already open")
+
+	err := suite.issueAction.Run()
+	suite.EqualError(err, "1 command failed")
+	suite.Equal(1, suite.issueAction.Interactive.Index)
+}
+
+func (suite *IssueActionTestSuite) TestCommands_navigation_keys_act_on_a_single_press() {
+	for _, key := range []string{"n", "p", "r", "q"} {
+		suite.True(internal.ActsOnPress(key, commands), key)
+	}
+}
+
+func (suite *IssueActionTestSuite) historyKey() string {
+	return history.Key(suite.issueAction.Repo, suite.issueAction.Number)
+}
+
+func (suite *IssueActionTestSuite) expectDraftSaved(body string) {
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{}}, nil).Times(2)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{suite.historyKey(): {Drafts: map[string]string{"": body}}}}).Return(nil)
+}
+
+func (suite *IssueActionTestSuite) expectDraftDeleted(body string) {
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{suite.historyKey(): {Drafts: map[string]string{"": body}}}}, nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{suite.historyKey(): {}}}).Return(nil)
+}
+
+func TestIssueActionTestSuite(t *testing.T) {
+	suite.Run(t, new(IssueActionTestSuite))
+}
@@ -1,6 +1,8 @@
 package peruse
 
 import (
+	discussion "github.com/hbk619/gh-peruse/cmd/discussion/cmd"
+	issue "github.com/hbk619/gh-peruse/cmd/issue/cmd"
 	"github.com/hbk619/gh-peruse/cmd/pr/cmd"
 	"github.com/spf13/cobra"
 	"os"
@@ -17,6 +19,8 @@ var PeruseCmd = &cobra.Command{
 
 func init() {
 	PeruseCmd.AddCommand(cmd.PRCmd)
+	PeruseCmd.AddCommand(issue.IssueCmd)
+	PeruseCmd.AddCommand(discussion.DiscussionCmd)
 }
 
 func Execute() {
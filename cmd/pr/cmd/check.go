@@ -4,10 +4,8 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/cli/cli/v2/git"
-	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/hbk619/gh-peruse/cmd/pr/internal/new_comments"
-
+	"github.com/hbk619/gh-peruse/internal/cli"
 	"github.com/hbk619/gh-peruse/internal/filesystem"
 	"github.com/hbk619/gh-peruse/internal/github"
 	"github.com/hbk619/gh-peruse/internal/history"
@@ -26,14 +24,14 @@ var CheckCommentCountCmd = &cobra.Command{
 			fmt.Println(err)
 			return
 		}
-		graphQlClient, err := api.DefaultGraphQLClient()
+		graphQlClient, restClient, err := cli.NewClients("")
 		if err != nil {
 			fmt.Println(err)
 			return
 		}
-		gitClient := &git.Client{}
+		gitClient := github.NewGitClient()
 
-		prClient := github.NewPRClient(graphQlClient, gitClient)
+		prClient := github.NewPRClient(graphQlClient, restClient, gitClient)
 		if err != nil {
 			fmt.Println(err)
 			return
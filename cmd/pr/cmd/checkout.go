@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hbk619/gh-peruse/cmd/pr/internal"
+	common "github.com/hbk619/gh-peruse/internal"
+	"github.com/hbk619/gh-peruse/internal/cli"
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var CheckoutCmd = &cobra.Command{
+	Use:   "checkout [number | url | owner/repo#number | branch]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Check out the branch of a Github PR",
+	Long:  `Fetch the head of a PR, including PRs from forks, and switch to it`,
+	Run: func(cmd *cobra.Command, args []string) {
+		repo, err := targetRepo(cmd, args)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		graphQlClient, restClient, err := cli.NewClients(repo.Host)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		prClient := github.NewPRClient(graphQlClient, restClient, github.NewGitClient())
+		output := filesystem.NewStdOut()
+		err = internal.ResolvePR(prClient, repo, args, common.NewPrompt(os.Stdin, output), output)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = internal.Checkout(prClient, repo, force, output)
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	CheckoutCmd.Flags().BoolP("force", "f", false, "Check out even when the working tree has uncommitted changes")
+	cli.AddRepoFlag(CheckoutCmd)
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/hbk619/gh-peruse/cmd/pr/internal/checks"
+	"github.com/hbk619/gh-peruse/internal/cli"
+	"github.com/hbk619/gh-peruse/internal/github"
+	"github.com/hbk619/gh-peruse/internal/notifications"
+	"github.com/spf13/cobra"
+)
+
+var ChecksCmd = &cobra.Command{
+	Use:   "checks [number | url | owner/repo#number | branch]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Browse the checks of a Github PR",
+	Long:  `View the checks and commit statuses of a PR one by one, with the details and log of failed checks`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Browse(cmd, func(settings cli.Settings) error {
+			return browseChecks(cmd, args, settings)
+		})
+	},
+}
+
+// browseChecks reads the checks of a PR one at a time, or with --watch waits for them to finish
+func browseChecks(cmd *cobra.Command, args []string, settings cli.Settings) error {
+	repo, err := targetRepo(cmd, args)
+	if err != nil {
+		return err
+	}
+	graphQlClient, restClient, err := cli.NewClients(repo.Host)
+	if err != nil {
+		return err
+	}
+
+	prClient := github.NewPRClient(graphQlClient, restClient, github.NewGitClient())
+	checksClient := github.NewChecksClient(graphQlClient, restClient)
+	action := checks.NewChecksAction(prClient, checksClient, settings.Output, settings.Prompt)
+	action.Repo = repo
+	action.Raw = settings.Raw
+	action.Renderer.ReadURLs = settings.ReadURLs
+	watch, err := cmd.Flags().GetBool("watch")
+	if err != nil {
+		return err
+	}
+	if watch {
+		interval, err := cmd.Flags().GetDuration("interval")
+		if err != nil {
+			return err
+		}
+		return action.Watch(args, interval, notifications.NewNotifier())
+	}
+	err = action.Init(args)
+	if err != nil {
+		return err
+	}
+	return action.Run()
+}
+
+func init() {
+	cli.AddBrowseFlags(ChecksCmd)
+	ChecksCmd.Flags().BoolP("watch", "w", false, "Wait until all checks have finished and show a notification with how they went")
+	ChecksCmd.Flags().Duration("interval", 30*time.Second, "How often to look at the checks when watching, at least 5s")
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hbk619/gh-peruse/cmd/pr/internal/create"
+	"github.com/hbk619/gh-peruse/internal/cli"
+	"github.com/hbk619/gh-peruse/internal/github"
+	internal_os "github.com/hbk619/gh-peruse/internal/os"
+	"github.com/spf13/cobra"
+)
+
+var CreateCmd = &cobra.Command{
+	Use:   "create",
+	Args:  cobra.NoArgs,
+	Short: "Create a Github PR from the current branch",
+	Long:  `Answer one question at a time for the base branch, title, description, draft, reviewers and labels, then read the new PR`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Browse(cmd, func(settings cli.Settings) error {
+			return createPR(cmd, args, settings)
+		})
+	},
+}
+
+// createPR asks for the details of the PR and then reads it, unless there is nothing in it to read yet
+func createPR(cmd *cobra.Command, args []string, settings cli.Settings) error {
+	repo, err := targetRepo(cmd, args)
+	if err != nil {
+		return err
+	}
+	graphQlClient, restClient, err := cli.NewClients(repo.Host)
+	if err != nil {
+		return err
+	}
+	prClient := github.NewPRClient(graphQlClient, restClient, github.NewGitClient())
+
+	action := create.NewCreateAction(prClient, settings.Output, settings.Prompt, internal_os.NewEditor())
+	action.Repo = repo
+	action.Confirm = settings.Confirm
+	number, err := action.Run()
+	if err != nil {
+		return err
+	}
+	// a PR without a description has no comments to read yet and would fail to open
+	if strings.TrimSpace(action.NewPR.Body) == "" {
+		_ = settings.Output.Println(fmt.Sprintf("Pull request %d has no description or comments to read yet, open it later with gh peruse pr %d", number, number))
+		return nil
+	}
+	return browse(cmd, []string{fmt.Sprintf("%s/%s#%d", repo.Owner, repo.Name, number)}, settings)
+}
+
+func init() {
+	addBrowseFlags(CreateCmd)
+}
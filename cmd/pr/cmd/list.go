@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hbk619/gh-peruse/cmd/pr/internal/list"
+	"github.com/hbk619/gh-peruse/internal/cli"
+	peruse_git "github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var ListCmd = &cobra.Command{
+	Use:   "list",
+	Args:  cobra.NoArgs,
+	Short: "List open Github PRs",
+	Long:  `Hear the open PRs one by one, with their author, unresolved threads and checks, and open one to read its comments`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cli.Browse(cmd, func(settings cli.Settings) error {
+			return listPRs(cmd, args, settings)
+		})
+	},
+}
+
+// listPRs reads the open PRs matching the filter flags and then the comments of the one picked
+func listPRs(cmd *cobra.Command, args []string, settings cli.Settings) error {
+	filter, err := listFilter(cmd)
+	if err != nil {
+		return err
+	}
+	repo, err := targetRepo(cmd, args)
+	if err != nil {
+		return err
+	}
+	graphQlClient, restClient, err := cli.NewClients(repo.Host)
+	if err != nil {
+		return err
+	}
+	prClient := github.NewPRClient(graphQlClient, restClient, github.NewGitClient())
+
+	action := list.NewListAction(prClient, settings.Output, settings.Prompt)
+	action.Repo = repo
+	err = action.Init(filter)
+	if err != nil {
+		return err
+	}
+	err = action.Run()
+	if action.Selected == 0 {
+		return err
+	}
+	return errors.Join(err, browse(cmd, []string{fmt.Sprintf("%s/%s#%d", repo.Owner, repo.Name, action.Selected)}, settings))
+}
+
+func listFilter(cmd *cobra.Command) (peruse_git.PRFilter, error) {
+	filter := peruse_git.PRFilter{Scope: "all"}
+	mine, err := cmd.Flags().GetBool("mine")
+	if err != nil {
+		return filter, err
+	}
+	review, err := cmd.Flags().GetBool("review-requested")
+	if err != nil {
+		return filter, err
+	}
+	switch {
+	case mine && review:
+		return filter, errors.New("use either --mine or --review-requested")
+	case mine:
+		filter.Scope = "mine"
+	case review:
+		filter.Scope = "review"
+	}
+	filter.Label, err = cmd.Flags().GetString("label")
+	if err != nil {
+		return filter, err
+	}
+	filter.Author, err = cmd.Flags().GetString("author")
+	if err != nil {
+		return filter, err
+	}
+	filter.Base, err = cmd.Flags().GetString("base")
+	return filter, err
+}
+
+func init() {
+	addBrowseFlags(ListCmd)
+	ListCmd.Flags().Bool("mine", false, "Only PRs you opened")
+	ListCmd.Flags().Bool("review-requested", false, "Only PRs waiting for your review")
+	ListCmd.Flags().String("label", "", "Only PRs with this label")
+	ListCmd.Flags().String("author", "", "Only PRs opened by this login")
+	ListCmd.Flags().String("base", "", "Only PRs merging into this branch")
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/hbk619/gh-peruse/internal/cli"
+	peruse_git "github.com/hbk619/gh-peruse/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// targetRepo builds the repo from --repo, taking the host from a PR URL argument when there is one.
+// The owner is left empty when neither gives it so the repository of the current directory is used.
+func targetRepo(cmd *cobra.Command, args []string) (*peruse_git.Repo, error) {
+	repo, err := cli.RepoFlag(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 0 {
+		reference, err := peruse_git.ParsePRReference(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if reference.Host != "" {
+			repo.Host = reference.Host
+		}
+	}
+	return repo, nil
+}
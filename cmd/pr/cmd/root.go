@@ -1,57 +1,82 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
+	"path"
 
-	"github.com/cli/cli/v2/git"
-	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/hbk619/gh-peruse/cmd/pr/internal"
+	"github.com/hbk619/gh-peruse/internal/cli"
 	"github.com/hbk619/gh-peruse/internal/filesystem"
 	"github.com/hbk619/gh-peruse/internal/github"
 	"github.com/hbk619/gh-peruse/internal/history"
 	internal_os "github.com/hbk619/gh-peruse/internal/os"
-	common "github.com/hbk619/gh-peruse/internal"
+	"github.com/hbk619/gh-peruse/internal/sound"
 	"github.com/spf13/cobra"
 )
 
 var PRCmd = &cobra.Command{
-	Use:   "pr [number]",
+	Use:   "pr [number | url | owner/repo#number | branch]",
 	Args:  cobra.MaximumNArgs(1),
 	Short: "Browse Github PR comments",
 	Long:  `View comments from a PR one by one and reply to them`,
 	Run: func(cmd *cobra.Command, args []string) {
-		historyService, err := history.NewHistoryService(os.Getenv("HOME"), filesystem.NewFS())
-		if err != nil {
-			fmt.Println(err)
-			return
-		}
-		graphQlClient, err := api.DefaultGraphQLClient()
-		if err != nil {
-			fmt.Println(err)
-			return
-		}
-		gitClient := &git.Client{}
-
-		prClient := github.NewPRClient(graphQlClient, gitClient)
-		clipboard := internal_os.NewClipboard()
-		output := filesystem.NewStdOut()
-		prompt := common.NewPrompt(os.Stdin, output)
-		pr := internal.NewPRAction(prClient, historyService, output, clipboard, prompt)
-		verbose, err := cmd.Flags().GetBool("verbose")
-		if err != nil {
-			fmt.Println(err)
-			return
-		}
-		err = pr.Init(args, verbose)
-		if err != nil {
-			fmt.Println(err)
-			return
-		}
-		pr.Run()
+		cli.Browse(cmd, func(settings cli.Settings) error {
+			return browse(cmd, args, settings)
+		})
 	},
 }
 
+// browse reads the comments of a PR with the settings from the flags added by addBrowseFlags
+func browse(cmd *cobra.Command, args []string, settings cli.Settings) error {
+	historyService, err := history.NewHistoryService(os.Getenv("HOME"), filesystem.NewFS())
+	if err != nil {
+		return err
+	}
+	repo, err := targetRepo(cmd, args)
+	if err != nil {
+		return err
+	}
+	graphQlClient, restClient, err := cli.NewClients(repo.Host)
+	if err != nil {
+		return err
+	}
+	gitClient := github.NewGitClient()
+
+	prClient := github.NewPRClient(graphQlClient, restClient, gitClient)
+	clipboard := internal_os.NewClipboard()
+	editor := internal_os.NewEditor()
+	pr := internal.NewPRAction(prClient, historyService, settings.Output, clipboard, settings.Prompt, editor)
+	pr.Repo = repo
+	pr.Confirm = settings.Confirm
+	pr.Raw = settings.Raw
+	pr.Renderer.ReadURLs = settings.ReadURLs
+	pr.Verbosity = settings.Verbosity
+	sounds, err := cmd.Flags().GetBool("sounds")
+	if err != nil {
+		return err
+	}
+	player, err := cmd.Flags().GetString("player")
+	if err != nil {
+		return err
+	}
+	if sounds || player != "" {
+		pr.Sounds = sound.NewEarcons(player, path.Join(os.Getenv("HOME"), ".config", "gh-peruse-sounds"))
+	}
+	pr.Timeline, err = cmd.Flags().GetBool("timeline")
+	if err != nil {
+		return err
+	}
+	verbose, err := cmd.Flags().GetBool("verbose")
+	if err != nil {
+		return err
+	}
+	err = pr.Init(args, verbose)
+	if err != nil {
+		return err
+	}
+	return pr.Run()
+}
+
 func Execute() {
 	err := PRCmd.Execute()
 	if err != nil {
@@ -61,5 +86,19 @@ func Execute() {
 
 func init() {
 	PRCmd.AddCommand(CheckCommentCountCmd)
-	PRCmd.Flags().BoolP("verbose", "v", false, "Verbose mode")
+	PRCmd.AddCommand(ChecksCmd)
+	PRCmd.AddCommand(ListCmd)
+	PRCmd.AddCommand(CheckoutCmd)
+	PRCmd.AddCommand(CreateCmd)
+	addBrowseFlags(PRCmd)
+}
+
+// addBrowseFlags adds the flags that control how a PR's comments are read
+func addBrowseFlags(cmd *cobra.Command) {
+	cli.AddBrowseFlags(cmd)
+	cmd.Flags().BoolP("verbose", "v", false, "Verbose mode")
+	cmd.Flags().BoolP("timeline", "t", false, "Read comments, reviews, pushes, labels, review requests and merges in the order they happened")
+	cli.AddVerbosityFlag(cmd, "file,path,line,code,author,time,body,reactions")
+	cmd.Flags().Bool("sounds", false, "Play short sounds for resolved, outdated and unread comments, the ends of the list and errors")
+	cmd.Flags().String("player", "", "Command used to play sounds, e.g. \"mpv --no-terminal\", implies --sounds")
 }
@@ -0,0 +1,21 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github"
+)
+
+// Checkout switches to the branch of the PR, fetching it first, refusing when there are uncommitted changes
+// unless force is set
+func Checkout(client github.PullRequestClient, repo *git.Repo, force bool, output filesystem.Output) error {
+	branch, err := client.Checkout(repo, force)
+	if err != nil {
+		_ = output.Println(fmt.Sprintf("Warning failed to check out: %s", err.Error()))
+		return err
+	}
+	_ = output.Println(fmt.Sprintf("Switched to branch %s", branch))
+	return nil
+}
@@ -0,0 +1,333 @@
+package checks
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	pr "github.com/hbk619/gh-peruse/cmd/pr/internal"
+	"github.com/hbk619/gh-peruse/internal"
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github"
+	"github.com/hbk619/gh-peruse/internal/markdown"
+	internal_os "github.com/hbk619/gh-peruse/internal/os"
+)
+
+const LogLines = 30
+
+// MinInterval is the shortest time allowed between looking at the checks when watching, to go easy on the API
+const MinInterval = 5 * time.Second
+
+// StartGrace is how long watching waits for the first checks to appear, right after a push they have not started yet
+const StartGrace = 2 * time.Minute
+
+// checks are ordered by how they finished
+const (
+	failedRank = iota
+	pendingRank
+	passedRank
+)
+
+// commands are what can be typed while browsing, single key input needs them to know which keys act alone
+var commands = []string{"n", "p", "r", "e", "l", "web", "q"}
+
+var (
+	failed = []string{"FAILURE", "TIMED_OUT", "CANCELLED", "ACTION_REQUIRED", "STARTUP_FAILURE", "ERROR"}
+	passed = []string{"SUCCESS", "NEUTRAL", "SKIPPED"}
+
+	logTimestamp = regexp.MustCompile(`^\d{4}-\d\d-\d\dT[\d:.]+Z ?`)
+	colour       = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+type ChecksAction struct {
+	Repo     *git.Repo
+	Results  []git.Status
+	Browser  internal_os.WebBrowser
+	Raw      bool
+	Renderer *markdown.Renderer
+	client   github.PullRequestClient
+	checks   github.ChecksClient
+	output   filesystem.Output
+	prompt   internal.Prompt
+	sleep    func(time.Duration)
+	internal.Interactive
+	internal.CommandLoop
+}
+
+func NewChecksAction(client github.PullRequestClient, checks github.ChecksClient, output filesystem.Output, prompt internal.Prompt) *ChecksAction {
+	return &ChecksAction{
+		Repo:        &git.Repo{},
+		Browser:     internal_os.NewBrowser(),
+		client:      client,
+		checks:      checks,
+		output:      output,
+		prompt:      prompt,
+		Renderer:    markdown.NewRenderer(),
+		sleep:       time.Sleep,
+		Interactive: internal.Interactive{Output: output},
+	}
+}
+
+// Init gets the checks for the PR and reads the first one
+func (action *ChecksAction) Init(args []string) error {
+	err := action.load(args)
+	if err != nil {
+		return err
+	}
+	if len(action.Results) == 0 {
+		return errors.New("no checks found")
+	}
+
+	_ = action.output.Println(action.Summary())
+	action.Print()
+	return nil
+}
+
+// Watch checks again every interval until none are still running, then sends the summary to the notifier.
+// When there are no checks yet it waits up to StartGrace for them to start.
+func (action *ChecksAction) Watch(args []string, interval time.Duration, notifier filesystem.Output) error {
+	if interval < MinInterval {
+		return fmt.Errorf("interval must be at least %s, got %s", MinInterval, interval)
+	}
+	err := action.load(args)
+	if err != nil {
+		return err
+	}
+
+	waitingFor := 0
+	var waitedToStart time.Duration
+	for {
+		pending := action.count(pendingRank)
+		if len(action.Results) == 0 {
+			if waitedToStart >= StartGrace {
+				return errors.New("no checks found")
+			}
+			if waitedToStart == 0 {
+				_ = action.output.Println("Waiting for checks to start")
+			}
+			waitedToStart += interval
+		} else if pending == 0 {
+			break
+		} else if pending != waitingFor {
+			_ = action.output.Println(fmt.Sprintf("Waiting for %d of %d checks", pending, len(action.Results)))
+			waitingFor = pending
+		}
+		action.sleep(interval)
+		err = action.refresh()
+		if err != nil {
+			return err
+		}
+	}
+
+	summary := fmt.Sprintf("Checks finished for pull request %d, %s", action.Repo.PRNumber, action.Summary())
+	_ = action.output.Println(summary)
+	err = notifier.Println(summary)
+	if err != nil {
+		return fmt.Errorf("failed to notify %w", err)
+	}
+	return nil
+}
+
+func (action *ChecksAction) load(args []string) error {
+	err := pr.ResolvePR(action.client, action.Repo, args, action.prompt, action.output)
+	if err != nil {
+		return err
+	}
+
+	return action.refresh()
+}
+
+// refresh gets the checks with failed checks first, then those still running and then those that passed
+func (action *ChecksAction) refresh() error {
+	statuses, err := action.checks.GetChecks(action.Repo)
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(statuses, func(i, j git.Status) int {
+		return rank(i) - rank(j)
+	})
+	action.Results = statuses
+	action.Interactive.MaxIndex = len(statuses) - 1
+	return nil
+}
+
+// Summary counts the checks that failed, are still running and passed
+func (action *ChecksAction) Summary() string {
+	return fmt.Sprintf("%d failed, %d pending, %d passed", action.count(failedRank), action.count(pendingRank), action.count(passedRank))
+}
+
+func (action *ChecksAction) count(wanted int) int {
+	count := 0
+	for _, status := range action.Results {
+		if rank(status) == wanted {
+			count++
+		}
+	}
+	return count
+}
+
+// Run reads commands until q is entered or there is nothing left to read, it returns an error if any command failed
+func (action *ChecksAction) Run() error {
+	return action.RunLoop(action.doPrompt)
+}
+
+func (action *ChecksAction) doPrompt() error {
+	current := action.Results[action.Interactive.Index]
+	prompt := "n to go to the next check, p for previous, r to repeat, e to expand"
+	if hasLog(current) {
+		prompt += ", l to read the end of the log"
+	}
+	if link(current) != "" {
+		prompt += ", web to open it in your browser"
+	}
+	prompt += " or q to quit"
+
+	result := internal.ReadCommand(action.prompt, prompt, commands)
+	var err error
+	switch result {
+	case "n":
+		action.Interactive.Next(action.Print)
+	case "p":
+		action.Interactive.Previous(action.Print)
+	case "r":
+		action.Interactive.Repeat(action.Print)
+	case "e":
+		action.Expand()
+	case "l":
+		err = action.Log()
+		if err != nil {
+			_ = action.output.Println(fmt.Sprintf("Warning failed to get log: %s", err.Error()))
+		}
+	case "web":
+		err = action.OpenInBrowser()
+	case "q":
+		action.Stop()
+	default:
+		_ = action.output.Println("Invalid choice")
+		err = fmt.Errorf("invalid choice %s", result)
+	}
+	return err
+}
+
+// OpenInBrowser opens the details page of a check run or the target of a commit status in the browser
+func (action *ChecksAction) OpenInBrowser() error {
+	url := link(action.Results[action.Interactive.Index])
+	if url == "" {
+		_ = action.output.Println("This check has no page to open")
+		return errors.New("check has no url")
+	}
+	err := action.Browser.Open(url)
+	if err != nil {
+		_ = action.output.Println(fmt.Sprintf("Warning failed to open browser: %s", err.Error()))
+	}
+	return err
+}
+
+func link(status git.Status) string {
+	if status.DetailsUrl != "" {
+		return status.DetailsUrl
+	}
+	return status.TargetUrl
+}
+
+func (action *ChecksAction) Print() {
+	current := action.Results[action.Interactive.Index]
+	_ = action.output.Println(fmt.Sprintf("Check %s %s", current.Name, Conclusion(current)))
+}
+
+// Expand reads why a check finished the way it did, with any annotations on files
+func (action *ChecksAction) Expand() {
+	current := action.Results[action.Interactive.Index]
+	details := false
+	if current.Title != "" {
+		_ = action.output.Println(current.Title)
+		details = true
+	}
+	if current.Summary != "" {
+		summary := current.Summary
+		if !action.Raw {
+			summary = action.Renderer.Render(summary)
+		}
+		_ = action.output.Println(summary)
+		details = true
+	}
+	for _, annotation := range current.Annotations.Nodes {
+		location := annotation.Path
+		if annotation.Location.Start.Line != 0 {
+			location = fmt.Sprintf("%s line %d", annotation.Path, annotation.Location.Start.Line)
+		}
+		level := strings.ToLower(annotation.AnnotationLevel)
+		_ = action.output.Println(fmt.Sprintf("%s %s: %s", level, location, annotation.Message))
+		details = true
+	}
+	if !details {
+		_ = action.output.Println("No details for this check")
+	}
+}
+
+// Log reads the last lines of a GitHub Actions job's log
+func (action *ChecksAction) Log() error {
+	current := action.Results[action.Interactive.Index]
+	if !hasLog(current) {
+		return errors.New("only GitHub Actions jobs have logs")
+	}
+	log, err := action.checks.GetJobLog(action.Repo, current.DatabaseId)
+	if err != nil {
+		return err
+	}
+	lines := LogTail(log, LogLines)
+	_ = action.output.Println(fmt.Sprintf("Last %d lines of the log", len(lines)))
+	for _, line := range lines {
+		_ = action.output.Println(line)
+	}
+	return nil
+}
+
+// LogTail gives the last non-empty lines of a log without timestamps, colours or group markers
+func LogTail(log string, count int) []string {
+	var lines []string
+	for _, line := range strings.Split(log, "\n") {
+		line = logTimestamp.ReplaceAllString(strings.TrimRight(line, "\r"), "")
+		line = colour.ReplaceAllString(line, "")
+		if strings.HasPrefix(line, "##[group]") || strings.HasPrefix(line, "##[endgroup]") {
+			continue
+		}
+		line = strings.Replace(line, "##[error]", "error: ", 1)
+		line = strings.Replace(line, "##[warning]", "warning: ", 1)
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > count {
+		lines = lines[len(lines)-count:]
+	}
+	return lines
+}
+
+// Conclusion says how a check finished, or what it is doing if it has not
+func Conclusion(status git.Status) string {
+	conclusion := status.Conclusion
+	if conclusion == "" {
+		conclusion = status.Status
+	}
+	return strings.ReplaceAll(strings.ToLower(conclusion), "_", " ")
+}
+
+func hasLog(status git.Status) bool {
+	return status.DatabaseId != 0 && strings.Contains(status.DetailsUrl, "/actions/")
+}
+
+func rank(status git.Status) int {
+	switch {
+	case slices.Contains(failed, status.Conclusion):
+		return failedRank
+	case slices.Contains(passed, status.Conclusion):
+		return passedRank
+	default:
+		return pendingRank
+	}
+}
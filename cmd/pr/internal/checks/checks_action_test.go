@@ -0,0 +1,233 @@
+package checks
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/golang/mock/gomock"
+	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
+	"github.com/hbk619/gh-peruse/internal/git"
+	mock_github "github.com/hbk619/gh-peruse/internal/github/mocks"
+	mock_internal "github.com/hbk619/gh-peruse/internal/mocks"
+	mock_os "github.com/hbk619/gh-peruse/internal/os/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChecksActionTestSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	mockOutput   *mock_filesystem.MockOutput
+	mockPrClient *mock_github.MockPullRequestClient
+	mockChecks   *mock_github.MockChecksClient
+	mockPrompt   *mock_internal.MockPrompt
+	mockBrowser  *mock_os.MockWebBrowser
+	action       *ChecksAction
+	lint         git.Status
+}
+
+func (suite *ChecksActionTestSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOutput = mock_filesystem.NewMockOutput(suite.ctrl)
+	suite.mockPrClient = mock_github.NewMockPullRequestClient(suite.ctrl)
+	suite.mockChecks = mock_github.NewMockChecksClient(suite.ctrl)
+	suite.mockPrompt = mock_internal.NewMockPrompt(suite.ctrl)
+	suite.mockBrowser = mock_os.NewMockWebBrowser(suite.ctrl)
+	suite.action = NewChecksAction(suite.mockPrClient, suite.mockChecks, suite.mockOutput, suite.mockPrompt)
+	suite.action.Browser = suite.mockBrowser
+	suite.lint = git.Status{
+		DatabaseId: 42,
+		Name:       "lint",
+		Conclusion: "FAILURE",
+		DetailsUrl: "https://github.com/luigi/castle/actions/runs/1/job/42",
+		Title:      "1 error",
+		Summary:    "Lint **failed**",
+		Annotations: git.Annotations{Nodes: []git.Annotation{{
+			Path:            "main.go",
+			Location:        git.AnnotationLocation{Start: git.AnnotationPosition{Line: 12}},
+			Message:         "unused variable",
+			AnnotationLevel: "FAILURE",
+		}}},
+	}
+}
+
+func (suite *ChecksActionTestSuite) TestInit_sorts_failed_checks_first() {
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	suite.mockChecks.EXPECT().GetChecks(&git.Repo{Owner: "luigi", Name: "castle", PRNumber: 3}).Return([]git.Status{
+		{Name: "test", Conclusion: "SUCCESS"},
+		{Name: "deploy", Status: "IN_PROGRESS"},
+		suite.lint,
+	}, nil)
+	suite.mockOutput.EXPECT().Println("1 failed, 1 pending, 1 passed")
+	suite.mockOutput.EXPECT().Println("Check lint failure")
+
+	err := suite.action.Init([]string{"3"})
+	suite.NoError(err)
+	suite.Equal([]string{"lint", "deploy", "test"}, []string{suite.action.Results[0].Name, suite.action.Results[1].Name, suite.action.Results[2].Name})
+	suite.Equal(2, suite.action.MaxIndex)
+
+	suite.mockOutput.EXPECT().Println("Check deploy in progress")
+	suite.action.Next(suite.action.Print)
+}
+
+func (suite *ChecksActionTestSuite) TestInit_detects_pr_and_errors_without_checks() {
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	suite.mockPrClient.EXPECT().DetectCurrentPR(gomock.Any()).Return(4, nil)
+	suite.mockChecks.EXPECT().GetChecks(&git.Repo{Owner: "luigi", Name: "castle", PRNumber: 4}).Return(nil, nil)
+
+	err := suite.action.Init(nil)
+	suite.EqualError(err, "no checks found")
+}
+
+func (suite *ChecksActionTestSuite) TestExpand_reads_summary_and_annotations() {
+	suite.action.Results = []git.Status{suite.lint}
+	gomock.InOrder(
+		suite.mockOutput.EXPECT().Println("1 error"),
+		suite.mockOutput.EXPECT().Println("Lint failed"),
+		suite.mockOutput.EXPECT().Println("failure main.go line 12: unused variable"),
+	)
+	suite.action.Expand()
+}
+
+func (suite *ChecksActionTestSuite) TestExpand_without_details() {
+	suite.action.Results = []git.Status{{Name: "ci/jenkins", Conclusion: "ERROR"}}
+	suite.mockOutput.EXPECT().Println("No details for this check")
+	suite.action.Expand()
+}
+
+func (suite *ChecksActionTestSuite) TestDoPrompt_reads_log_tail() {
+	suite.action.Results = []git.Status{suite.lint}
+	suite.mockPrompt.EXPECT().Command("n to go to the next check, p for previous, r to repeat, e to expand, l to read the end of the log, web to open it in your browser or q to quit").Return("l")
+	suite.mockChecks.EXPECT().GetJobLog(suite.action.Repo, 42).Return("2025-03-04T12:00:00.1234567Z ##[group]Run lint\n2025-03-04T12:00:01.1234567Z \x1b[31mmain.go:12: unused variable\x1b[0m\n2025-03-04T12:00:02.1234567Z ##[error]Process completed with exit code 1.\n", nil)
+	gomock.InOrder(
+		suite.mockOutput.EXPECT().Println("Last 2 lines of the log"),
+		suite.mockOutput.EXPECT().Println("main.go:12: unused variable"),
+		suite.mockOutput.EXPECT().Println("error: Process completed with exit code 1."),
+	)
+	suite.NoError(suite.action.doPrompt())
+}
+
+func (suite *ChecksActionTestSuite) TestDoPrompt_log_error() {
+	suite.action.Results = []git.Status{suite.lint}
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("l")
+	suite.mockChecks.EXPECT().GetJobLog(suite.action.Repo, 42).Return("", errors.New("failed to fetch log 410 Gone"))
+	suite.mockOutput.EXPECT().Println("Warning failed to get log: failed to fetch log 410 Gone")
+	suite.EqualError(suite.action.doPrompt(), "failed to fetch log 410 Gone")
+}
+
+func (suite *ChecksActionTestSuite) TestDoPrompt_no_log_for_commit_status() {
+	suite.action.Results = []git.Status{{Name: "ci/jenkins", Conclusion: "ERROR", DetailsUrl: "https://jenkins.example.com/1"}}
+	suite.mockPrompt.EXPECT().Command("n to go to the next check, p for previous, r to repeat, e to expand, web to open it in your browser or q to quit").Return("l")
+	suite.mockOutput.EXPECT().Println("Warning failed to get log: only GitHub Actions jobs have logs")
+	suite.EqualError(suite.action.doPrompt(), "only GitHub Actions jobs have logs")
+}
+
+func (suite *ChecksActionTestSuite) TestDoPrompt_opens_status_target_in_browser() {
+	suite.action.Results = []git.Status{{Context: "ci/jenkins", State: "ERROR", TargetUrl: "https://jenkins.example.com/1"}}
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("web")
+	suite.mockBrowser.EXPECT().Open("https://jenkins.example.com/1").Return(nil)
+	suite.NoError(suite.action.doPrompt())
+}
+
+func (suite *ChecksActionTestSuite) TestRun_returns_error_after_invalid_choice() {
+	suite.action.Results = []git.Status{suite.lint}
+	gomock.InOrder(
+		suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("wat"),
+		suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("q"),
+	)
+	suite.mockOutput.EXPECT().Println("Invalid choice")
+	suite.EqualError(suite.action.Run(), "1 command failed")
+}
+
+func (suite *ChecksActionTestSuite) TestOpenInBrowser_without_url() {
+	suite.action.Results = []git.Status{{Name: "lint", Conclusion: "FAILURE"}}
+	suite.mockOutput.EXPECT().Println("This check has no page to open")
+	err := suite.action.OpenInBrowser()
+	suite.Error(err)
+}
+
+func (suite *ChecksActionTestSuite) TestWatch_polls_until_checks_finish_and_notifies() {
+	mockNotifier := mock_filesystem.NewMockOutput(suite.ctrl)
+	var slept []time.Duration
+	suite.action.sleep = func(interval time.Duration) {
+		slept = append(slept, interval)
+	}
+	repo := &git.Repo{Owner: "luigi", Name: "castle", PRNumber: 3}
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	gomock.InOrder(
+		suite.mockChecks.EXPECT().GetChecks(repo).Return([]git.Status{{Name: "lint", Status: "IN_PROGRESS"}, {Name: "test", Status: "QUEUED"}}, nil),
+		suite.mockOutput.EXPECT().Println("Waiting for 2 of 2 checks"),
+		suite.mockChecks.EXPECT().GetChecks(repo).Return([]git.Status{{Name: "lint", Status: "IN_PROGRESS"}, {Name: "test", Status: "QUEUED"}}, nil),
+		suite.mockChecks.EXPECT().GetChecks(repo).Return([]git.Status{suite.lint, {Name: "test", Status: "IN_PROGRESS"}}, nil),
+		suite.mockOutput.EXPECT().Println("Waiting for 1 of 2 checks"),
+		suite.mockChecks.EXPECT().GetChecks(repo).Return([]git.Status{suite.lint, {Name: "test", Conclusion: "SUCCESS"}}, nil),
+		suite.mockOutput.EXPECT().Println("Checks finished for pull request 3, 1 failed, 0 pending, 1 passed"),
+		mockNotifier.EXPECT().Println("Checks finished for pull request 3, 1 failed, 0 pending, 1 passed").Return(nil),
+	)
+
+	err := suite.action.Watch([]string{"3"}, time.Minute, mockNotifier)
+	suite.NoError(err)
+	suite.Equal([]time.Duration{time.Minute, time.Minute, time.Minute}, slept)
+}
+
+func (suite *ChecksActionTestSuite) TestWatch_waits_for_checks_to_start() {
+	mockNotifier := mock_filesystem.NewMockOutput(suite.ctrl)
+	suite.action.sleep = func(time.Duration) {}
+	repo := &git.Repo{Owner: "luigi", Name: "castle", PRNumber: 3}
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	gomock.InOrder(
+		suite.mockChecks.EXPECT().GetChecks(repo).Return(nil, nil),
+		suite.mockOutput.EXPECT().Println("Waiting for checks to start"),
+		suite.mockChecks.EXPECT().GetChecks(repo).Return(nil, nil),
+		suite.mockChecks.EXPECT().GetChecks(repo).Return([]git.Status{{Name: "test", Status: "QUEUED"}}, nil),
+		suite.mockOutput.EXPECT().Println("Waiting for 1 of 1 checks"),
+		suite.mockChecks.EXPECT().GetChecks(repo).Return([]git.Status{{Name: "test", Conclusion: "SUCCESS"}}, nil),
+		suite.mockOutput.EXPECT().Println("Checks finished for pull request 3, 0 failed, 0 pending, 1 passed"),
+		mockNotifier.EXPECT().Println("Checks finished for pull request 3, 0 failed, 0 pending, 1 passed").Return(nil),
+	)
+
+	err := suite.action.Watch([]string{"3"}, time.Minute, mockNotifier)
+	suite.NoError(err)
+}
+
+func (suite *ChecksActionTestSuite) TestWatch_gives_up_when_no_checks_start() {
+	var slept []time.Duration
+	suite.action.sleep = func(interval time.Duration) {
+		slept = append(slept, interval)
+	}
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	suite.mockChecks.EXPECT().GetChecks(gomock.Any()).Return(nil, nil).Times(3)
+	suite.mockOutput.EXPECT().Println("Waiting for checks to start")
+
+	err := suite.action.Watch([]string{"3"}, time.Minute, mock_filesystem.NewMockOutput(suite.ctrl))
+	suite.EqualError(err, "no checks found")
+	suite.Equal([]time.Duration{time.Minute, time.Minute}, slept)
+}
+
+func (suite *ChecksActionTestSuite) TestWatch_returns_error_when_checks_fail_to_load() {
+	suite.action.sleep = func(time.Duration) {}
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	suite.mockChecks.EXPECT().GetChecks(gomock.Any()).Return([]git.Status{{Name: "lint", Status: "IN_PROGRESS"}}, nil)
+	suite.mockOutput.EXPECT().Println("Waiting for 1 of 1 checks")
+	suite.mockChecks.EXPECT().GetChecks(gomock.Any()).Return(nil, errors.New("failed to fetch checks bad"))
+
+	err := suite.action.Watch([]string{"3"}, MinInterval, mock_filesystem.NewMockOutput(suite.ctrl))
+	suite.EqualError(err, "failed to fetch checks bad")
+}
+
+func (suite *ChecksActionTestSuite) TestWatch_rejects_short_intervals() {
+	for _, interval := range []time.Duration{0, -time.Minute, time.Second} {
+		err := suite.action.Watch([]string{"3"}, interval, mock_filesystem.NewMockOutput(suite.ctrl))
+		suite.EqualError(err, fmt.Sprintf("interval must be at least 5s, got %s", interval))
+	}
+}
+
+func (suite *ChecksActionTestSuite) TestLogTail_keeps_last_lines() {
+	suite.Equal([]string{"c", "d"}, LogTail("a\nb\n\nc\r\nd\n", 2))
+}
+
+func TestChecksActionTestSuite(t *testing.T) {
+	suite.Run(t, new(ChecksActionTestSuite))
+}
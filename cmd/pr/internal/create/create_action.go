@@ -0,0 +1,156 @@
+package create
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hbk619/gh-peruse/internal"
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github"
+	internal_os "github.com/hbk619/gh-peruse/internal/os"
+)
+
+var errCancelled = errors.New("pull request not created")
+
+type CreateAction struct {
+	Repo     *git.Repo
+	NewPR    *git.NewPR
+	Confirm  bool
+	client   github.PullRequestClient
+	output   filesystem.Output
+	prompt   internal.Prompt
+	composer *internal.Composer
+}
+
+func NewCreateAction(client github.PullRequestClient, output filesystem.Output, prompt internal.Prompt, editor internal_os.TextEditor) *CreateAction {
+	return &CreateAction{
+		Repo:     &git.Repo{},
+		Confirm:  true,
+		client:   client,
+		output:   output,
+		prompt:   prompt,
+		composer: internal.NewComposer(prompt, editor, output),
+	}
+}
+
+// Run asks for each part of the pull request one at a time, creates it and returns its number
+func (action *CreateAction) Run() (int, error) {
+	if action.Repo.Owner == "" {
+		repoDetails, err := action.client.GetRepoDetails()
+		if err != nil {
+			return 0, err
+		}
+		action.Repo.Host = repoDetails.Host
+		action.Repo.Owner = repoDetails.Owner
+		action.Repo.Name = repoDetails.Name
+	}
+	newPR, err := action.client.NewPR(action.Repo)
+	if err != nil {
+		return 0, err
+	}
+	action.NewPR = newPR
+	if !newPR.Pushed {
+		if action.prompt.String(fmt.Sprintf("%s has not been pushed, y to push it, anything else to cancel", newPR.Branch)) != "y" {
+			return 0, errCancelled
+		}
+		newPR.Head, err = action.client.PushBranch(action.Repo, newPR.Branch)
+		if err != nil {
+			return 0, err
+		}
+		_ = action.output.Println(fmt.Sprintf("Pushed %s", newPR.Branch))
+	}
+
+	if base := strings.TrimSpace(action.prompt.String(fmt.Sprintf("Type the branch to merge into, or nothing for %s", newPR.Base))); base != "" {
+		newPR.Base = base
+	}
+	newPR.Title = action.askTitle(newPR.Title)
+	if newPR.Title == "" {
+		_ = action.output.Println("A pull request needs a title")
+		return 0, errCancelled
+	}
+	newPR.Body = action.askBody(newPR.Template)
+	newPR.Draft = action.prompt.String("y to open it as a draft, anything else to open it ready for review") == "y"
+	newPR.Reviewers = splitNames(action.prompt.String("Type reviewers separated by commas, teams as org/team, or nothing for none"))
+	newPR.Labels = splitNames(action.prompt.String("Type labels separated by commas, or nothing for none"))
+
+	if action.Confirm {
+		action.readBack(newPR)
+		if action.prompt.String("y to create the pull request, anything else to cancel") != "y" {
+			_ = action.output.Println("Pull request not created")
+			return 0, errCancelled
+		}
+	}
+
+	created, err := action.client.CreatePR(newPR)
+	if err != nil {
+		return 0, err
+	}
+	_ = action.output.Println(fmt.Sprintf("Created pull request %d", created.Number))
+	if len(newPR.Reviewers) > 0 {
+		err = action.client.RequestReviewers(created.Id, newPR.Reviewers)
+		if err != nil {
+			_ = action.output.Println(fmt.Sprintf("Warning failed to request reviews: %s", err.Error()))
+		}
+	}
+	if len(newPR.Labels) > 0 {
+		err = action.client.AddLabels(action.Repo, created.Id, newPR.Labels)
+		if err != nil {
+			_ = action.output.Println(fmt.Sprintf("Warning failed to add labels: %s", err.Error()))
+		}
+	}
+	return created.Number, nil
+}
+
+func (action *CreateAction) askTitle(suggested string) string {
+	question := "Type the title"
+	if suggested != "" {
+		question = fmt.Sprintf("Type the title, or nothing for %s", suggested)
+	}
+	if title := strings.TrimSpace(action.prompt.String(question)); title != "" {
+		return title
+	}
+	return suggested
+}
+
+// askBody writes the description in the editor, starting from the PR template when the repo has one
+func (action *CreateAction) askBody(template string) string {
+	question := "e to write the description in your editor, anything else to leave it empty"
+	if template != "" {
+		question = "e to write the description in your editor starting from the template, anything else to use the template as it is"
+	}
+	if action.prompt.String(question) != "e" {
+		return template
+	}
+	return action.composer.Revise(template)
+}
+
+func (action *CreateAction) readBack(newPR *git.NewPR) {
+	_ = action.output.Println(fmt.Sprintf("Merge %s into %s", newPR.Head, newPR.Base))
+	_ = action.output.Println(newPR.Title)
+	if strings.TrimSpace(newPR.Body) == "" {
+		_ = action.output.Println("No description")
+	} else {
+		_ = action.output.Println(newPR.Body)
+	}
+	if newPR.Draft {
+		_ = action.output.Println("Draft")
+	}
+	if len(newPR.Reviewers) > 0 {
+		_ = action.output.Println(fmt.Sprintf("Reviewers: %s", strings.Join(newPR.Reviewers, ", ")))
+	}
+	if len(newPR.Labels) > 0 {
+		_ = action.output.Println(fmt.Sprintf("Labels: %s", strings.Join(newPR.Labels, ", ")))
+	}
+}
+
+func splitNames(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,170 @@
+package create
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/golang/mock/gomock"
+	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
+	"github.com/hbk619/gh-peruse/internal/git"
+	mock_github "github.com/hbk619/gh-peruse/internal/github/mocks"
+	mock_internal "github.com/hbk619/gh-peruse/internal/mocks"
+	mock_os "github.com/hbk619/gh-peruse/internal/os/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type CreateActionTestSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	createAction *CreateAction
+	mockOutput   *mock_filesystem.MockOutput
+	mockPrClient *mock_github.MockPullRequestClient
+	mockPrompt   *mock_internal.MockPrompt
+	mockEditor   *mock_os.MockTextEditor
+	repo         *git.Repo
+}
+
+func (suite *CreateActionTestSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOutput = mock_filesystem.NewMockOutput(suite.ctrl)
+	suite.mockPrClient = mock_github.NewMockPullRequestClient(suite.ctrl)
+	suite.mockPrompt = mock_internal.NewMockPrompt(suite.ctrl)
+	suite.mockEditor = mock_os.NewMockTextEditor(suite.ctrl)
+	suite.createAction = NewCreateAction(suite.mockPrClient, suite.mockOutput, suite.mockPrompt, suite.mockEditor)
+	suite.repo = &git.Repo{Owner: "luigi", Name: "castle"}
+	suite.createAction.Repo = suite.repo
+}
+
+func (suite *CreateActionTestSuite) newPR() *git.NewPR {
+	return &git.NewPR{
+		RepositoryId: "R_1",
+		Base:         "main",
+		Head:         "pipes",
+		Branch:       "pipes",
+		Pushed:       true,
+		Template:     "## Why",
+		Title:        "Faster pipes",
+	}
+}
+
+func (suite *CreateActionTestSuite) TestRun_creates_pr_from_answers() {
+	suite.mockPrClient.EXPECT().NewPR(suite.repo).Return(suite.newPR(), nil)
+	gomock.InOrder(
+		suite.mockPrompt.EXPECT().String("Type the branch to merge into, or nothing for main").Return("release"),
+		suite.mockPrompt.EXPECT().String("Type the title, or nothing for Faster pipes").Return(""),
+		suite.mockPrompt.EXPECT().String("e to write the description in your editor starting from the template, anything else to use the template as it is").Return("e"),
+		suite.mockEditor.EXPECT().Edit("## Why").Return("## Why\nSpeed\n", nil),
+		suite.mockPrompt.EXPECT().String("y to open it as a draft, anything else to open it ready for review").Return("y"),
+		suite.mockPrompt.EXPECT().String("Type reviewers separated by commas, teams as org/team, or nothing for none").Return("peach, mushroom/plumbers"),
+		suite.mockPrompt.EXPECT().String("Type labels separated by commas, or nothing for none").Return(""),
+		suite.mockOutput.EXPECT().Println("Merge pipes into release"),
+		suite.mockOutput.EXPECT().Println("Faster pipes"),
+		suite.mockOutput.EXPECT().Println("## Why\nSpeed"),
+		suite.mockOutput.EXPECT().Println("Draft"),
+		suite.mockOutput.EXPECT().Println("Reviewers: peach, mushroom/plumbers"),
+		suite.mockPrompt.EXPECT().String("y to create the pull request, anything else to cancel").Return("y"),
+	)
+	expected := suite.newPR()
+	expected.Base = "release"
+	expected.Body = "## Why\nSpeed"
+	expected.Draft = true
+	expected.Reviewers = []string{"peach", "mushroom/plumbers"}
+	suite.mockPrClient.EXPECT().CreatePR(expected).Return(&git.PullRequest{Id: "PR_kwDOA", Number: 7}, nil)
+	suite.mockOutput.EXPECT().Println("Created pull request 7")
+	suite.mockPrClient.EXPECT().RequestReviewers("PR_kwDOA", []string{"peach", "mushroom/plumbers"}).Return(nil)
+
+	number, err := suite.createAction.Run()
+	suite.NoError(err)
+	suite.Equal(7, number)
+}
+
+func (suite *CreateActionTestSuite) TestRun_pushes_branch_and_uses_current_repo() {
+	suite.createAction.Repo = &git.Repo{}
+	suite.createAction.Confirm = false
+	newPR := suite.newPR()
+	newPR.Pushed = false
+	newPR.Template = ""
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Host: "github.com", Owner: "luigi", Name: "castle"}, nil)
+	suite.mockPrClient.EXPECT().NewPR(&git.Repo{Host: "github.com", Owner: "luigi", Name: "castle"}).Return(newPR, nil)
+	suite.mockPrompt.EXPECT().String("pipes has not been pushed, y to push it, anything else to cancel").Return("y")
+	suite.mockPrClient.EXPECT().PushBranch(gomock.Any(), "pipes").Return("pipes", nil)
+	suite.mockOutput.EXPECT().Println("Pushed pipes")
+	suite.mockPrompt.EXPECT().String("e to write the description in your editor, anything else to leave it empty").Return("")
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("").Times(5)
+	suite.mockPrClient.EXPECT().CreatePR(gomock.Any()).Return(&git.PullRequest{Id: "PR_kwDOA", Number: 8}, nil)
+	suite.mockOutput.EXPECT().Println("Created pull request 8")
+
+	number, err := suite.createAction.Run()
+	suite.NoError(err)
+	suite.Equal(8, number)
+	suite.Equal("", suite.createAction.NewPR.Body)
+}
+
+func (suite *CreateActionTestSuite) TestRun_opens_pr_from_fork_it_pushed_to() {
+	suite.createAction.Confirm = false
+	newPR := suite.newPR()
+	newPR.Pushed = false
+	suite.mockPrClient.EXPECT().NewPR(suite.repo).Return(newPR, nil)
+	suite.mockPrompt.EXPECT().String("pipes has not been pushed, y to push it, anything else to cancel").Return("y")
+	suite.mockPrClient.EXPECT().PushBranch(suite.repo, "pipes").Return("toad:pipes", nil)
+	suite.mockOutput.EXPECT().Println("Pushed pipes")
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("").Times(6)
+	suite.mockPrClient.EXPECT().CreatePR(gomock.Any()).DoAndReturn(func(created *git.NewPR) (*git.PullRequest, error) {
+		suite.Equal("toad:pipes", created.Head)
+		return &git.PullRequest{Id: "PR_kwDOA", Number: 8}, nil
+	})
+	suite.mockOutput.EXPECT().Println("Created pull request 8")
+
+	_, err := suite.createAction.Run()
+	suite.NoError(err)
+}
+
+func (suite *CreateActionTestSuite) TestRun_needs_a_title() {
+	newPR := suite.newPR()
+	newPR.Title = ""
+	suite.mockPrClient.EXPECT().NewPR(suite.repo).Return(newPR, nil)
+	suite.mockPrompt.EXPECT().String("Type the branch to merge into, or nothing for main").Return("")
+	suite.mockPrompt.EXPECT().String("Type the title").Return(" ")
+	suite.mockOutput.EXPECT().Println("A pull request needs a title")
+
+	_, err := suite.createAction.Run()
+	suite.EqualError(err, "pull request not created")
+}
+
+func (suite *CreateActionTestSuite) TestRun_cancelled() {
+	suite.mockPrClient.EXPECT().NewPR(suite.repo).Return(suite.newPR(), nil)
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("").Times(6)
+	suite.mockOutput.EXPECT().Println(gomock.Any()).Times(3)
+	suite.mockPrompt.EXPECT().String("y to create the pull request, anything else to cancel").Return("n")
+	suite.mockOutput.EXPECT().Println("Pull request not created")
+
+	_, err := suite.createAction.Run()
+	suite.EqualError(err, "pull request not created")
+}
+
+func (suite *CreateActionTestSuite) TestRun_warns_when_labels_fail() {
+	suite.createAction.Confirm = false
+	suite.mockPrClient.EXPECT().NewPR(suite.repo).Return(suite.newPR(), nil)
+	suite.mockPrompt.EXPECT().String("Type labels separated by commas, or nothing for none").Return("urgent")
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("").Times(5)
+	suite.mockPrClient.EXPECT().CreatePR(gomock.Any()).Return(&git.PullRequest{Id: "PR_kwDOA", Number: 9}, nil)
+	suite.mockOutput.EXPECT().Println("Created pull request 9")
+	suite.mockPrClient.EXPECT().AddLabels(suite.repo, "PR_kwDOA", []string{"urgent"}).Return(errors.New("label urgent not found"))
+	suite.mockOutput.EXPECT().Println("Warning failed to add labels: label urgent not found")
+
+	number, err := suite.createAction.Run()
+	suite.NoError(err)
+	suite.Equal(9, number)
+}
+
+func (suite *CreateActionTestSuite) TestRun_has_error() {
+	suite.mockPrClient.EXPECT().NewPR(suite.repo).Return(nil, errors.New("main is the default branch, switch to the branch with your changes first"))
+
+	_, err := suite.createAction.Run()
+	suite.EqualError(err, "main is the default branch, switch to the branch with your changes first")
+}
+
+func TestCreateActionTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateActionTestSuite))
+}
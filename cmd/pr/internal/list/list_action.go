@@ -0,0 +1,129 @@
+package list
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hbk619/gh-peruse/internal"
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github"
+)
+
+// commands are what can be typed while browsing, single key input needs them to know which keys act alone
+var commands = []string{"n", "p", "r", "o", "q"}
+
+var checkStates = map[string]string{
+	"SUCCESS":  "checks passing",
+	"FAILURE":  "checks failing",
+	"ERROR":    "checks failing",
+	"PENDING":  "checks running",
+	"EXPECTED": "checks running",
+	"":         "no checks",
+}
+
+type ListAction struct {
+	Repo     *git.Repo
+	Results  []git.PRSummary
+	Selected int
+	client   github.PullRequestClient
+	output   filesystem.Output
+	prompt   internal.Prompt
+	internal.Interactive
+	internal.CommandLoop
+}
+
+func NewListAction(client github.PullRequestClient, output filesystem.Output, prompt internal.Prompt) *ListAction {
+	return &ListAction{
+		Repo:        &git.Repo{},
+		client:      client,
+		output:      output,
+		prompt:      prompt,
+		Interactive: internal.Interactive{Output: output},
+	}
+}
+
+// Init finds the open PRs matching the filter in the repo, or the current directory's repo when none is set,
+// says how many there are and reads the first one
+func (action *ListAction) Init(filter git.PRFilter) error {
+	if action.Repo.Owner == "" {
+		repoDetails, err := action.client.GetRepoDetails()
+		if err != nil {
+			return err
+		}
+		action.Repo.Host = repoDetails.Host
+		action.Repo.Owner = repoDetails.Owner
+		action.Repo.Name = repoDetails.Name
+	}
+
+	var err error
+	action.Results, err = action.client.ListPRs(action.Repo, filter)
+	if err != nil {
+		return err
+	}
+	if len(action.Results) == 0 {
+		return errors.New("no open pull requests found")
+	}
+
+	if len(action.Results) == 1 {
+		_ = action.output.Println("1 open pull request")
+	} else {
+		_ = action.output.Println(fmt.Sprintf("%d open pull requests", len(action.Results)))
+	}
+	action.Interactive.MaxIndex = len(action.Results) - 1
+	action.Print()
+	return nil
+}
+
+// Run reads commands until a PR is opened, leaving its number in Selected, or q is entered. It returns an error if
+// any command failed.
+func (action *ListAction) Run() error {
+	return action.RunLoop(action.doPrompt)
+}
+
+func (action *ListAction) doPrompt() error {
+	result := internal.ReadCommand(action.prompt, "n to go to the next pull request, p for previous, r to repeat, o to open it or q to quit", commands)
+	var err error
+	switch result {
+	case "n":
+		action.Interactive.Next(action.Print)
+	case "p":
+		action.Interactive.Previous(action.Print)
+	case "r":
+		action.Interactive.Repeat(action.Print)
+	case "o":
+		action.Selected = action.Results[action.Interactive.Index].Number
+		action.Stop()
+	case "q":
+		action.Stop()
+	default:
+		_ = action.output.Println("Invalid choice")
+		err = fmt.Errorf("invalid choice %s", result)
+	}
+	return err
+}
+
+func (action *ListAction) Print() {
+	current := action.Results[action.Interactive.Index]
+	_ = action.output.Println(fmt.Sprintf("%d %s", current.Number, current.Title))
+	_ = action.output.Println(fmt.Sprintf("by %s into %s", current.Author, current.BaseRefName))
+	if current.IsDraft {
+		_ = action.output.Println("Draft")
+	}
+	switch current.UnresolvedThreads {
+	case 0:
+		_ = action.output.Println("No unresolved threads")
+	case 1:
+		_ = action.output.Println("1 unresolved thread")
+	default:
+		_ = action.output.Println(fmt.Sprintf("%d unresolved threads", current.UnresolvedThreads))
+	}
+	_ = action.output.Println(CheckState(current.CheckState))
+}
+
+func CheckState(state string) string {
+	if description, ok := checkStates[state]; ok {
+		return description
+	}
+	return "checks " + state
+}
@@ -0,0 +1,125 @@
+package list
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/golang/mock/gomock"
+	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
+	"github.com/hbk619/gh-peruse/internal/git"
+	mock_github "github.com/hbk619/gh-peruse/internal/github/mocks"
+	mock_internal "github.com/hbk619/gh-peruse/internal/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListActionTestSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	listAction   *ListAction
+	mockOutput   *mock_filesystem.MockOutput
+	mockPrClient *mock_github.MockPullRequestClient
+	mockPrompt   *mock_internal.MockPrompt
+}
+
+func (suite *ListActionTestSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOutput = mock_filesystem.NewMockOutput(suite.ctrl)
+	suite.mockPrClient = mock_github.NewMockPullRequestClient(suite.ctrl)
+	suite.mockPrompt = mock_internal.NewMockPrompt(suite.ctrl)
+	suite.listAction = NewListAction(suite.mockPrClient, suite.mockOutput, suite.mockPrompt)
+}
+
+var prs = []git.PRSummary{
+	{Number: 12, Title: "Faster pipes", Author: "mario", IsDraft: true, BaseRefName: "main", UnresolvedThreads: 2, CheckState: "FAILURE"},
+	{Number: 9, Title: "Docs", Author: "toad", BaseRefName: "release", UnresolvedThreads: 1, CheckState: "SUCCESS"},
+}
+
+func (suite *ListActionTestSuite) TestInit_reads_count_and_first_pr() {
+	filter := git.PRFilter{Scope: "mine"}
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	suite.mockPrClient.EXPECT().ListPRs(&git.Repo{Owner: "luigi", Name: "castle"}, filter).Return(prs, nil)
+	suite.mockOutput.EXPECT().Println("2 open pull requests")
+	suite.mockOutput.EXPECT().Println("12 Faster pipes")
+	suite.mockOutput.EXPECT().Println("by mario into main")
+	suite.mockOutput.EXPECT().Println("Draft")
+	suite.mockOutput.EXPECT().Println("2 unresolved threads")
+	suite.mockOutput.EXPECT().Println("checks failing")
+
+	err := suite.listAction.Init(filter)
+	suite.NoError(err)
+	suite.Equal(1, suite.listAction.Interactive.MaxIndex)
+}
+
+func (suite *ListActionTestSuite) TestInit_uses_repo_from_flag() {
+	repo := &git.Repo{Host: "github.example.com", Owner: "peach", Name: "garden"}
+	suite.listAction.Repo = repo
+	suite.mockPrClient.EXPECT().ListPRs(repo, git.PRFilter{}).Return(prs[1:], nil)
+	suite.mockOutput.EXPECT().Println("1 open pull request")
+	suite.mockOutput.EXPECT().Println(gomock.Any()).Times(4)
+
+	err := suite.listAction.Init(git.PRFilter{})
+	suite.NoError(err)
+}
+
+func (suite *ListActionTestSuite) TestInit_no_prs() {
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	suite.mockPrClient.EXPECT().ListPRs(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	err := suite.listAction.Init(git.PRFilter{})
+	suite.EqualError(err, "no open pull requests found")
+}
+
+func (suite *ListActionTestSuite) TestInit_has_error() {
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "luigi", Name: "castle"}, nil)
+	suite.mockPrClient.EXPECT().ListPRs(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to list prs bad gateway"))
+
+	err := suite.listAction.Init(git.PRFilter{})
+	suite.EqualError(err, "failed to list prs bad gateway")
+}
+
+func (suite *ListActionTestSuite) TestRun_opens_selected_pr() {
+	suite.listAction.Results = prs
+	suite.listAction.Interactive.MaxIndex = 1
+	gomock.InOrder(
+		suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("n"),
+		suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("o"),
+	)
+	suite.mockOutput.EXPECT().Println("9 Docs")
+	suite.mockOutput.EXPECT().Println("by toad into release")
+	suite.mockOutput.EXPECT().Println("1 unresolved thread")
+	suite.mockOutput.EXPECT().Println("checks passing")
+
+	suite.NoError(suite.listAction.Run())
+	suite.Equal(9, suite.listAction.Selected)
+}
+
+func (suite *ListActionTestSuite) TestRun_quit() {
+	suite.listAction.Results = prs
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("q")
+
+	suite.NoError(suite.listAction.Run())
+	suite.Equal(0, suite.listAction.Selected)
+}
+
+func (suite *ListActionTestSuite) TestRun_returns_error_after_invalid_choice() {
+	suite.listAction.Results = prs
+	gomock.InOrder(
+		suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("wat"),
+		suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("q"),
+	)
+	suite.mockOutput.EXPECT().Println("Invalid choice")
+
+	suite.EqualError(suite.listAction.Run(), "1 command failed")
+	suite.Equal(0, suite.listAction.Selected)
+}
+
+func (suite *ListActionTestSuite) TestCheckState() {
+	suite.Equal("no checks", CheckState(""))
+	suite.Equal("checks running", CheckState("PENDING"))
+	suite.Equal("checks skipped", CheckState("skipped"))
+}
+
+func TestListActionTestSuite(t *testing.T) {
+	suite.Run(t, new(ListActionTestSuite))
+}
@@ -15,6 +15,7 @@ func CheckForNewComments(prClient github.PullRequestClient, historyService histo
 		return fmt.Errorf("failed to get repo info %w", err)
 	}
 	internalRepo := &git.Repo{
+		Host:  repo.Host,
 		Owner: repo.Owner,
 		Name:  repo.Name,
 	}
@@ -30,7 +31,7 @@ func CheckForNewComments(prClient github.PullRequestClient, historyService histo
 
 	var notifyError error
 	for pr, count := range prs {
-		oldCount := prHistory.Prs[pr].CommentCount
+		oldCount := prHistory.Prs[history.Key(internalRepo, pr)].CommentCount
 		if oldCount != count {
 			msg := fmt.Sprintf("Pull request %d has new comments", pr)
 			err = output.Println(msg)
@@ -48,13 +48,16 @@ func (suite *CheckNewComments) TestCheckForNewComments_finds_comments() {
 			3: 1,
 		}, nil)
 	suite.mockHistory.EXPECT().Load().Return(history.History{
-		Prs: map[int]history.PR{
-			2: history.PR{
+		Prs: map[string]history.PR{
+			"github.com/luigi/mansion#2": history.PR{
 				CommentCount: 2,
 			},
-			3: history.PR{
+			"github.com/luigi/mansion#3": history.PR{
 				CommentCount: 1,
 			},
+			"github.com/mario/kart#1": history.PR{
+				CommentCount: 7,
+			},
 		},
 	}, nil)
 	suite.mockOutput.EXPECT().Println("Pull request 1 has new comments")
@@ -94,11 +97,11 @@ func (suite *CheckNewComments) TestCheckForNewComments_returns_errors_from_outpu
 			3: 1,
 		}, nil)
 	suite.mockHistory.EXPECT().Load().Return(history.History{
-		Prs: map[int]history.PR{
-			2: history.PR{
+		Prs: map[string]history.PR{
+			"github.com/luigi/mansion#2": history.PR{
 				CommentCount: 2,
 			},
-			3: history.PR{
+			"github.com/luigi/mansion#3": history.PR{
 				CommentCount: 1,
 			},
 		},
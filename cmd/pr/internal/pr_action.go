@@ -3,152 +3,595 @@ package internal
 import (
 	"errors"
 	"fmt"
-	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hbk619/gh-peruse/internal"
 	"github.com/hbk619/gh-peruse/internal/filesystem"
 	"github.com/hbk619/gh-peruse/internal/git"
 	"github.com/hbk619/gh-peruse/internal/github"
 	"github.com/hbk619/gh-peruse/internal/history"
+	"github.com/hbk619/gh-peruse/internal/markdown"
 	internal_os "github.com/hbk619/gh-peruse/internal/os"
+	"github.com/hbk619/gh-peruse/internal/sound"
 )
 
 type PRAction struct {
 	Id                  string
+	Title               string
+	Viewer              string
+	HeadOid             string
+	URL                 string
 	Repo                *git.Repo
 	Results             []git.Comment
 	PrintedPathLastTime bool
 	LastFullPath        string
 	HelpText            string
 	State               git.State
+	Confirm             bool
+	Raw                 bool
+	Renderer            *markdown.Renderer
+	Verbosity           internal.Verbosity
+	Sounds              sound.Player
+	Browser             internal_os.WebBrowser
+	Timeline            bool
 	client              github.PullRequestClient
 	history             history.Storage
 	output              filesystem.Output
 	clipboard           internal_os.Clippy
+	editor              internal_os.TextEditor
 	prompt              internal.Prompt
+	composer            *internal.Composer
+	now                 func() time.Time
+	lastSeen            time.Time
 	internal.Interactive
+	internal.CommandLoop
 }
 
-func NewPRAction(client github.PullRequestClient, history history.Storage, output filesystem.Output, clipboard internal_os.Clippy, prompt internal.Prompt) *PRAction {
-	return &PRAction{
+func NewPRAction(client github.PullRequestClient, history history.Storage, output filesystem.Output, clipboard internal_os.Clippy, prompt internal.Prompt, editor internal_os.TextEditor) *PRAction {
+	pr := &PRAction{
 		Repo:                &git.Repo{},
 		PrintedPathLastTime: true,
 		LastFullPath:        "",
-		HelpText:            "Type c to comment",
+		Confirm:             true,
+		Renderer:            markdown.NewRenderer(),
+		Verbosity:           internal.Normal,
+		Sounds:              sound.NewSilent(),
+		Browser:             internal_os.NewBrowser(),
+		now:                 time.Now,
+		HelpText:            "Type c to comment, cm to write a comment over several lines, ce to write a comment in your editor, cq to quote the comment in your reply, emoji to add or remove a reaction, edit to change your comment, del to delete your comment, o to open the file in your editor, web to open the comment in your browser, x url to copy its link, merge to merge the pull request, checkout to switch to its branch, checkout force to switch even with uncommitted changes, info to hear the title, labels, reviewers and assignees, title to change the title, body to edit the description, label, ask for reviewers or assignee followed by add or remove and names separated by commas, draft or undraft to change whether it is a draft or h to hear this again",
 		client:              client,
 		history:             history,
 		output:              output,
 		clipboard:           clipboard,
+		editor:              editor,
 		prompt:              prompt,
+		composer:            internal.NewComposer(prompt, editor, output),
+		Interactive:         internal.Interactive{Output: output},
 	}
+	pr.Interactive.OnEnd = func() {
+		pr.play(sound.End)
+	}
+	return pr
 }
 
 func (pr *PRAction) Init(args []string, verbose bool) error {
-	repoDetails, err := pr.client.GetRepoDetails()
+	err := ResolvePR(pr.client, pr.Repo, args, pr.prompt, pr.output)
 	if err != nil {
 		return err
 	}
-	pr.Repo.Owner = repoDetails.Owner
-	pr.Repo.Name = repoDetails.Name
 
-	pr.Repo.PRNumber, err = pr.getPRNumber(args)
-	if err != nil {
-		return err
+	getDetails := pr.client.GetPRDetails
+	if pr.Timeline {
+		getDetails = pr.client.GetPRTimeline
 	}
-
-	prDetails, err := pr.client.GetPRDetails(pr.Repo, verbose)
+	prDetails, err := getDetails(pr.Repo, verbose)
 	if err != nil {
 		return err
 	}
 	pr.Results = prDetails.Comments
 	pr.State = prDetails.State
 	pr.Id = prDetails.Id
+	pr.Title = prDetails.Title
+	pr.Viewer = prDetails.Viewer
+	pr.HeadOid = prDetails.HeadOid
+	pr.URL = prDetails.URL
+	pr.composer.SetParticipants(internal.Participants(pr.Results, pr.Viewer))
 	if verbose {
 		pr.PrintState()
 	}
 
-	commentCount := len(pr.Results)
-	pr.updateHistory(pr.Repo.PRNumber, commentCount)
+	commentCount := 0
+	for _, comment := range pr.Results {
+		if comment.Kind != git.EventKind {
+			commentCount++
+		}
+	}
+	pr.updateHistory(history.Key(pr.Repo, pr.Repo.PRNumber), commentCount)
 
-	if commentCount == 0 {
+	if len(pr.Results) == 0 {
 		return errors.New("no comments found")
 	}
 
-	pr.Interactive.MaxIndex = commentCount - 1
+	pr.Interactive.MaxIndex = len(pr.Results) - 1
 	pr.Print()
 	return nil
 }
 
-func (pr *PRAction) getPRNumber(args []string) (int, error) {
-	if len(args) > 0 {
-		number, err := strconv.Atoi(args[0])
-		if err != nil {
-			return 0, fmt.Errorf("please provide a valid PR number")
-		}
-		return number, nil
-	} else {
-		return pr.client.DetectCurrentPR(pr.Repo)
-	}
-}
-
-func (pr *PRAction) updateHistory(prNumber int, commentCount int) {
+func (pr *PRAction) updateHistory(key string, commentCount int) {
 	prHistory, err := pr.history.Load()
 	if err != nil {
 		_ = pr.output.Println(fmt.Sprintf("Warning failed to load comments to history: %s", err.Error()))
 		return
 	}
 
-	existingPrHistory := prHistory.Prs[prNumber]
+	existingPrHistory := prHistory.Prs[key]
 	if existingPrHistory.CommentCount != commentCount {
 		_ = pr.output.Println("New comments ahead!")
 	}
 
 	existingPrHistory.CommentCount = commentCount
-	prHistory.Prs[prNumber] = existingPrHistory
+	if existingPrHistory.LastSeen != nil {
+		pr.lastSeen = *existingPrHistory.LastSeen
+	}
+	newest := pr.lastSeen
+	for _, comment := range pr.Results {
+		if comment.CreatedAt.After(newest) {
+			newest = comment.CreatedAt
+		}
+	}
+	if !newest.IsZero() {
+		existingPrHistory.LastSeen = &newest
+	}
+	prHistory.Prs[key] = existingPrHistory
 	err = pr.history.Save(prHistory)
 	if err != nil {
 		_ = pr.output.Println(fmt.Sprintf("Warning failed to save comments to history: %s", err.Error()))
 	}
 }
 
-func (pr *PRAction) Reply(contents string) {
+// Comment writes a reply to the current comment, reads it back for confirmation and keeps it as a draft until it is posted
+func (pr *PRAction) Comment(write func() string) error {
+	draft := internal.Draft{
+		Storage: pr.history,
+		Item:    history.Key(pr.Repo, pr.Repo.PRNumber),
+		Key:     draftKey(pr.Results[pr.Interactive.Index]),
+	}
+	return pr.composer.Compose(write, pr.Confirm, draft, pr.Reply)
+}
+
+func (pr *PRAction) Reply(contents string) error {
+	if strings.TrimSpace(contents) == "" {
+		_ = pr.output.Println("Comment is empty, nothing posted")
+		return errors.New("comment is empty")
+	}
 	err := pr.client.Reply(contents, &pr.Results[pr.Interactive.Index], pr.Id)
 	if err != nil {
 		_ = pr.output.Println(fmt.Sprintf("Warning failed to comment: %s", err.Error()))
 	} else {
 		_ = pr.output.Println("Posted comment")
 	}
+	return err
+}
+
+func draftKey(comment git.Comment) string {
+	if comment.Thread.ID != "" {
+		return comment.Thread.ID
+	}
+	return comment.File.FullPath
 }
 
-func (pr *PRAction) Resolve() {
+func (pr *PRAction) Resolve() error {
 	err := pr.client.Resolve(&pr.Results[pr.Interactive.Index])
 	if err != nil {
 		_ = pr.output.Println(fmt.Sprintf("Warning failed to resolve thread: %s", err.Error()))
 	} else {
 		_ = pr.output.Println("Conversation resolved")
 	}
+	return err
+}
+
+func (pr *PRAction) EditComment() error {
+	current := &pr.Results[pr.Interactive.Index]
+	if !pr.isOwn(*current) {
+		_ = pr.output.Println("You can only edit your own comments")
+		return errors.New("not your comment")
+	}
+	body := pr.composer.Revise(current.Body)
+	if body == strings.TrimSpace(current.Body) {
+		_ = pr.output.Println("Nothing changed")
+		return nil
+	}
+	if pr.Confirm {
+		var post bool
+		body, post = pr.composer.Review(body, pr.composer.Line, func(string) {})
+		if !post {
+			return nil
+		}
+	}
+
+	err := pr.client.Edit(body, current)
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to edit comment: %s", err.Error()))
+		return err
+	}
+	current.Body = body
+	_ = pr.output.Println("Comment updated")
+	return nil
+}
+
+func (pr *PRAction) DeleteComment() error {
+	current := pr.Results[pr.Interactive.Index]
+	if !pr.isOwn(current) {
+		_ = pr.output.Println("You can only delete your own comments")
+		return errors.New("not your comment")
+	}
+	if pr.Confirm && pr.prompt.String("y to delete this comment, anything else to keep it") != "y" {
+		_ = pr.output.Println("Comment kept")
+		return nil
+	}
+
+	err := pr.client.Delete(&current)
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to delete comment: %s", err.Error()))
+		return err
+	}
+	_ = pr.output.Println("Comment deleted")
+
+	pr.Results = slices.Delete(pr.Results, pr.Interactive.Index, pr.Interactive.Index+1)
+	if len(pr.Results) == 0 {
+		_ = pr.output.Println("No comments left")
+		pr.Stop()
+		return nil
+	}
+	pr.Interactive.MaxIndex = len(pr.Results) - 1
+	pr.Interactive.Index = min(pr.Interactive.Index, pr.Interactive.MaxIndex)
+	pr.LastFullPath = ""
+	pr.Print()
+	return nil
+}
+
+// React adds the reaction to the current comment, or removes it if already added
+func (pr *PRAction) React(input string) error {
+	return internal.ToggleReaction(pr.client, &pr.Results[pr.Interactive.Index], input, pr.prompt, pr.output)
+}
+
+// commands are what can be typed while browsing, single key input needs them to know which keys act alone
+var commands = []string{"n", "p", "r", "e", "done", "c", "cm", "ce", "cq", "emoji", "edit", "del", "merge", "checkout", "o", "info", "title", "body", "label", "ask", "assignee", "draft", "undraft", "h", "x", "web", "q"}
+
+var mergeMethods = map[string]string{
+	"merge":  "MERGE",
+	"squash": "SQUASH",
+	"rebase": "REBASE",
+}
+
+var mergeDescriptions = map[string]string{
+	"MERGE":  "merge",
+	"SQUASH": "squash and merge",
+	"REBASE": "rebase and merge",
+}
+
+// Merge merges the pull request after reading back whether it can be merged, options are a merge method of
+// merge, squash or rebase, auto to merge once requirements are met and delete to delete the branch afterwards
+func (pr *PRAction) Merge(options string) error {
+	method := "MERGE"
+	auto, deleteBranch := false, false
+	for _, option := range strings.Fields(strings.ToLower(options)) {
+		switch option {
+		case "auto":
+			auto = true
+		case "delete":
+			deleteBranch = true
+		default:
+			var ok bool
+			method, ok = mergeMethods[option]
+			if !ok {
+				err := fmt.Errorf("unknown merge option %s, use merge, squash, rebase, auto or delete", option)
+				_ = pr.output.Println(err.Error())
+				return err
+			}
+		}
+	}
+	if auto && deleteBranch {
+		err := errors.New("the branch cannot be deleted until auto-merge has merged the pull request")
+		_ = pr.output.Println(err.Error())
+		return err
+	}
+
+	state, err := pr.client.GetMergeState(pr.Repo)
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to merge: %s", err.Error()))
+		return err
+	}
+	_ = pr.output.Println(fmt.Sprintf("Merge status: %s", state.MergeStatus))
+	_ = pr.output.Println(fmt.Sprintf("Conflict status: %s", state.ConflictStatus))
+
+	action := mergeDescriptions[method]
+	if auto {
+		action = "enable auto-merge with " + action
+	}
+	if deleteBranch {
+		action += " and delete " + state.HeadRef.Name
+	}
+	if pr.Confirm && pr.prompt.String(fmt.Sprintf("y to %s, anything else to cancel", action)) != "y" {
+		_ = pr.output.Println("Merge cancelled")
+		return nil
+	}
+
+	if auto {
+		err = pr.client.EnableAutoMerge(pr.Id, method)
+	} else {
+		err = pr.client.Merge(pr.Id, method)
+	}
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to merge: %s", err.Error()))
+		return err
+	}
+	if auto {
+		_ = pr.output.Println("Auto-merge enabled")
+		return nil
+	}
+	_ = pr.output.Println("Pull request merged")
+
+	if deleteBranch {
+		err = pr.client.DeleteBranch(state.HeadRef)
+		if err != nil {
+			_ = pr.output.Println(fmt.Sprintf("Warning failed to delete branch: %s", err.Error()))
+			return err
+		}
+		_ = pr.output.Println(fmt.Sprintf("Deleted branch %s", state.HeadRef.Name))
+	}
+	return nil
+}
+
+// Checkout switches to the branch of the pull request, the option force switches even with uncommitted changes
+func (pr *PRAction) Checkout(option string) error {
+	switch strings.ToLower(option) {
+	case "":
+		return Checkout(pr.client, pr.Repo, false, pr.output)
+	case "force":
+		return Checkout(pr.client, pr.Repo, true, pr.output)
+	default:
+		err := fmt.Errorf("unknown checkout option %s, use force", option)
+		_ = pr.output.Println(err.Error())
+		return err
+	}
+}
+
+// OpenFile opens the file of the current comment in the editor at the commented line, warning first when the
+// local checkout is not the PR's head commit as the line may have moved
+func (pr *PRAction) OpenFile() error {
+	current := pr.Results[pr.Interactive.Index]
+	if current.Kind != git.ReviewCommentKind {
+		_ = pr.output.Println("This comment is not on a file")
+		return errors.New("comment is not on a file")
+	}
+	localPath, err := pr.client.LocalPath(pr.Repo, current.File.Path+current.File.FileName)
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to open file: %s", err.Error()))
+		return err
+	}
+	head, err := pr.client.LocalHead()
+	if err != nil || head != pr.HeadOid {
+		_ = pr.output.Println("Warning your checkout is not at the latest commit of the pull request, the line may have moved")
+	}
+
+	line := current.File.Line
+	if line == 0 {
+		line = current.File.OriginalLine
+	}
+	err = pr.editor.Open(localPath, line)
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to open file: %s", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// OpenInBrowser opens the current comment, or the pull request for anything without a page of its own, in the browser
+func (pr *PRAction) OpenInBrowser() error {
+	err := pr.Browser.Open(pr.link(pr.Results[pr.Interactive.Index]))
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to open browser: %s", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// link is the permalink of the comment or pushed commit, falling back to the pull request
+func (pr *PRAction) link(comment git.Comment) string {
+	if comment.URL != "" {
+		return comment.URL
+	}
+	return pr.URL
+}
+
+// Info reads the title, whether the pull request is a draft, its labels, requested reviewers and assignees
+func (pr *PRAction) Info() error {
+	metadata, err := pr.client.GetMetadata(pr.Repo)
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to get details: %s", err.Error()))
+		return err
+	}
+	_ = pr.output.Println(metadata.Title)
+	if metadata.IsDraft {
+		_ = pr.output.Println("Draft")
+	} else {
+		_ = pr.output.Println("Ready for review")
+	}
+	_ = pr.output.Println(describeList("Labels", "No labels", metadata.Labels))
+	_ = pr.output.Println(describeList("Reviewers requested", "No reviewers requested", metadata.Reviewers))
+	_ = pr.output.Println(describeList("Assignees", "No assignees", metadata.Assignees))
+	return nil
+}
+
+func describeList(name string, empty string, values []string) string {
+	if len(values) == 0 {
+		return empty
+	}
+	return fmt.Sprintf("%s: %s", name, strings.Join(values, ", "))
 }
 
-func (pr *PRAction) Run() {
-	for {
-		pr.doPrompt()
+// EditTitle changes the title, asking for it after reading the current one when none is given
+func (pr *PRAction) EditTitle(title string) error {
+	if title == "" {
+		_ = pr.output.Println(pr.Title)
+		title = strings.TrimSpace(pr.prompt.String("Type the new title, or nothing to keep it"))
+	}
+	if title == "" || title == pr.Title {
+		_ = pr.output.Println("Title unchanged")
+		return nil
 	}
+	err := pr.client.EditTitle(pr.Id, title)
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to change title: %s", err.Error()))
+		return err
+	}
+	pr.Title = title
+	_ = pr.output.Println("Title updated")
+	return nil
 }
 
-func (pr *PRAction) doPrompt() {
+// EditBody opens the description in the editor and reads it back before saving
+func (pr *PRAction) EditBody() error {
+	metadata, err := pr.client.GetMetadata(pr.Repo)
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to get description: %s", err.Error()))
+		return err
+	}
+	body := pr.composer.Revise(metadata.Body)
+	if body == strings.TrimSpace(metadata.Body) {
+		_ = pr.output.Println("Nothing changed")
+		return nil
+	}
+	if pr.Confirm {
+		var post bool
+		body, post = pr.composer.Review(body, pr.composer.Line, func(string) {})
+		if !post {
+			return nil
+		}
+	}
+
+	err = pr.client.Edit(body, &git.Comment{Id: pr.Id, Kind: git.PullRequestKind})
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to edit description: %s", err.Error()))
+		return err
+	}
+	for i := range pr.Results {
+		if pr.Results[i].Kind == git.PullRequestKind {
+			pr.Results[i].Body = body
+		}
+	}
+	_ = pr.output.Println("Description updated")
+	return nil
+}
+
+// EditList adds or removes labels, reviewers or assignees, the argument is add or remove followed by
+// names separated by commas, reviewers can be teams written as org/team
+// listNames are what each list changed by EditList holds
+var listNames = map[string]string{
+	"label":    "label",
+	"ask":      "reviewer",
+	"assignee": "assignee",
+}
+
+func (pr *PRAction) EditList(kind string, argument string) error {
+	change, list, _ := strings.Cut(argument, " ")
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if (change != "add" && change != "remove") || len(names) == 0 {
+		err := fmt.Errorf("type %s add or %s remove followed by names separated by commas", kind, kind)
+		_ = pr.output.Println(err.Error())
+		return err
+	}
+
+	var err error
+	var message string
+	joined := strings.Join(names, ", ")
+	switch kind + " " + change {
+	case "label add":
+		err = pr.client.AddLabels(pr.Repo, pr.Id, names)
+		message = fmt.Sprintf("Added %s", joined)
+	case "label remove":
+		err = pr.client.RemoveLabels(pr.Repo, pr.Id, names)
+		message = fmt.Sprintf("Removed %s", joined)
+	case "ask add":
+		err = pr.client.RequestReviewers(pr.Id, names)
+		message = fmt.Sprintf("Requested a review from %s", joined)
+	case "ask remove":
+		err = pr.client.RemoveReviewers(pr.Repo, names)
+		message = fmt.Sprintf("No longer waiting for a review from %s", joined)
+	case "assignee add":
+		err = pr.client.AddAssignees(pr.Id, names)
+		message = fmt.Sprintf("Assigned %s", joined)
+	case "assignee remove":
+		err = pr.client.RemoveAssignees(pr.Id, names)
+		message = fmt.Sprintf("Unassigned %s", joined)
+	}
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to change %ss: %s", listNames[kind], err.Error()))
+		return err
+	}
+	_ = pr.output.Println(message)
+	return nil
+}
+
+// SetDraft converts the pull request to a draft, or marks it ready for review when draft is false
+func (pr *PRAction) SetDraft(draft bool) error {
+	err := pr.client.SetDraft(pr.Id, draft)
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to change draft state: %s", err.Error()))
+		return err
+	}
+	if draft {
+		_ = pr.output.Println("Converted to draft")
+	} else {
+		_ = pr.output.Println("Marked ready for review")
+	}
+	return nil
+}
+
+func (pr *PRAction) isOwn(comment git.Comment) bool {
+	return pr.Viewer != "" && comment.Author.Login == pr.Viewer
+}
+
+// Run reads commands until q is entered or there is nothing left to read, it returns an error if any command failed
+func (pr *PRAction) Run() error {
+	return pr.RunLoop(pr.doPrompt)
+}
+
+func (pr *PRAction) doPrompt() error {
 	prompt := "n to go to the next result, p for previous, r to repeat, x to copy or q to quit"
 	currentComment := pr.Results[pr.Interactive.Index]
 	pr.LastFullPath = currentComment.File.FullPath
 	if currentComment.Thread.ID != "" && !currentComment.Thread.IsResolved {
-		prompt += ", res to resolve"
+		prompt += ", done to resolve"
 	}
 	if currentComment.Thread.IsResolved || currentComment.Outdated {
 		prompt += ", e to expand"
 	}
-	result := pr.prompt.String(prompt)
-	switch result {
+	if currentComment.Kind == git.ReviewCommentKind {
+		prompt += ", o to open the file"
+	}
+	if pr.isOwn(currentComment) {
+		switch currentComment.Kind {
+		case git.IssueCommentKind, git.ReviewCommentKind:
+			prompt += ", edit to edit, del to delete"
+		case git.PullRequestKind, git.ReviewKind:
+			prompt += ", edit to edit"
+		}
+	}
+	result := internal.ReadCommand(pr.prompt, prompt, commands)
+	command, argument, _ := strings.Cut(result, " ")
+	argument = strings.TrimSpace(argument)
+	var err error
+	switch command {
 	case "n":
 		pr.Interactive.Next(pr.Print)
 	case "p":
@@ -158,47 +601,134 @@ func (pr *PRAction) doPrompt() {
 	case "e":
 		pr.LastFullPath = ""
 		pr.printContents(currentComment)
-	case "res":
-		pr.Resolve()
-	case "c":
-		comment := pr.prompt.String("Type comment and press enter")
-		pr.Reply(comment)
+	case "done":
+		err = pr.Resolve()
+	case "c", "cm", "ce", "cq":
+		write, _ := pr.composer.Writer(command, argument, currentComment, pr.Viewer)
+		err = pr.Comment(write)
+	case "emoji":
+		err = pr.React(argument)
+	case "edit":
+		err = pr.EditComment()
+	case "del":
+		err = pr.DeleteComment()
+	case "merge":
+		err = pr.Merge(argument)
+	case "checkout":
+		err = pr.Checkout(argument)
+	case "o":
+		err = pr.OpenFile()
+	case "info":
+		err = pr.Info()
+	case "title":
+		err = pr.EditTitle(argument)
+	case "body":
+		err = pr.EditBody()
+	case "label", "ask", "assignee":
+		err = pr.EditList(command, argument)
+	case "draft":
+		err = pr.SetDraft(true)
+	case "undraft":
+		err = pr.SetDraft(false)
+	case "h":
+		_ = pr.output.Println(pr.HelpText)
 	case "x":
-		err := pr.clipboard.Write(currentComment.Body)
+		contents := currentComment.Body
+		if argument == "url" {
+			contents = pr.link(currentComment)
+		}
+		err = pr.clipboard.Write(contents)
 		if err != nil {
 			pr.output.Println(err.Error())
 		}
+	case "web":
+		err = pr.OpenInBrowser()
 	case "q":
-		os.Exit(0)
+		pr.Stop()
 	default:
 		_ = pr.output.Println("Invalid choice")
+		err = fmt.Errorf("invalid choice %s", result)
+	}
+	if err != nil {
+		pr.play(sound.Failure)
 	}
+	return err
 }
 
 func (pr *PRAction) Print() {
 	current := pr.Results[pr.Interactive.Index]
 	if current.Thread.IsResolved {
-		_ = pr.output.Println("This comment is resolved")
-		return
-	}
-	if current.Outdated {
-		_ = pr.output.Println("This comment is outdated")
-		return
+		pr.play(sound.Resolved)
+		_ = pr.output.Println(pr.Verbosity.Resolved)
+		if !pr.Verbosity.ReadHidden {
+			return
+		}
+	} else if current.Outdated {
+		pr.play(sound.Outdated)
+		_ = pr.output.Println(pr.Verbosity.Outdated)
+		if !pr.Verbosity.ReadHidden {
+			return
+		}
+	} else if pr.isUnread(current) {
+		pr.play(sound.Unread)
 	}
 	pr.printContents(current)
 }
 
+// isUnread reports whether the comment was written since the PR was last opened
+func (pr *PRAction) isUnread(comment git.Comment) bool {
+	return !pr.lastSeen.IsZero() && comment.CreatedAt.After(pr.lastSeen)
+}
+
+// play gives an audio cue, sounds are turned off after the first one fails so the warning is not repeated
+func (pr *PRAction) play(cue sound.Cue) {
+	err := pr.Sounds.Play(cue)
+	if err != nil {
+		_ = pr.output.Println(fmt.Sprintf("Warning failed to play sound: %s", err.Error()))
+		pr.Sounds = sound.NewSilent()
+	}
+}
+
+// printContents reads the fields of the comment chosen by the verbosity, file details are only read when the
+// file changes unless the verbosity repeats them
 func (pr *PRAction) printContents(current git.Comment) {
-	if pr.LastFullPath != current.File.FullPath {
-		_ = pr.output.Println(current.File.FileName)
-		if current.File.Path != "" {
-			_ = pr.output.Println(current.File.Path)
-			_ = pr.output.Println(strconv.Itoa(current.File.Line))
-			_ = pr.output.Println(current.File.LineContents)
+	newFile := pr.LastFullPath != current.File.FullPath || pr.Verbosity.RepeatPath
+	for _, field := range pr.Verbosity.Fields {
+		switch field {
+		case internal.FileNameField:
+			if newFile {
+				_ = pr.output.Println(current.File.FileName)
+			}
+		case internal.PathField:
+			if newFile && current.File.Path != "" {
+				_ = pr.output.Println(current.File.Path)
+			}
+		case internal.LineField:
+			if newFile && current.File.Path != "" {
+				_ = pr.output.Println(strconv.Itoa(current.File.Line))
+			}
+		case internal.LineContentsField:
+			if newFile && current.File.Path != "" {
+				_ = pr.output.Println(current.File.LineContents)
+			}
+		case internal.AuthorField:
+			_ = pr.output.Println(current.Author.Login)
+		case internal.TimeField:
+			if !current.CreatedAt.IsZero() {
+				_ = pr.output.Println(internal.RelativeTime(current.CreatedAt, pr.now()))
+			}
+		case internal.BodyField:
+			if pr.Raw {
+				_ = pr.output.Println(current.Body)
+			} else {
+				_ = pr.output.Println(pr.Renderer.Render(current.Body))
+			}
+		case internal.ReactionsField:
+			if reactions := current.ReactionSummary(); reactions != "" {
+				_ = pr.output.Println(reactions)
+			}
 		}
 	}
-	_ = pr.output.Println(current.Author.Login)
-	_ = pr.output.Println(current.Body)
 }
 
 func (pr *PRAction) PrintState() {
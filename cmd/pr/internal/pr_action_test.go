@@ -3,9 +3,11 @@ package internal
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/repository"
 	"github.com/golang/mock/gomock"
+	"github.com/hbk619/gh-peruse/internal"
 	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
 	"github.com/hbk619/gh-peruse/internal/git"
 	"github.com/hbk619/gh-peruse/internal/github"
@@ -14,6 +16,8 @@ import (
 	mock_history "github.com/hbk619/gh-peruse/internal/history/mocks"
 	mock_internal "github.com/hbk619/gh-peruse/internal/mocks"
 	mock_os "github.com/hbk619/gh-peruse/internal/os/mocks"
+	"github.com/hbk619/gh-peruse/internal/sound"
+	mock_sound "github.com/hbk619/gh-peruse/internal/sound/mocks"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -26,6 +30,8 @@ type PRActionTestSuite struct {
 	mockClipboard *mock_os.MockClippy
 	mockPrClient  *mock_github.MockPullRequestClient
 	mockPrompt    *mock_internal.MockPrompt
+	mockEditor    *mock_os.MockTextEditor
+	mockSounds    *mock_sound.MockPlayer
 }
 
 func (suite *PRActionTestSuite) BeforeTest(string, string) {
@@ -35,13 +41,15 @@ func (suite *PRActionTestSuite) BeforeTest(string, string) {
 	suite.mockPrClient = mock_github.NewMockPullRequestClient(suite.ctrl)
 	suite.mockClipboard = mock_os.NewMockClippy(suite.ctrl)
 	suite.mockPrompt = mock_internal.NewMockPrompt(suite.ctrl)
-	suite.prAction = NewPRAction(suite.mockPrClient, suite.mockHistory, suite.mockOutput, suite.mockClipboard, suite.mockPrompt)
+	suite.mockEditor = mock_os.NewMockTextEditor(suite.ctrl)
+	suite.mockSounds = mock_sound.NewMockPlayer(suite.ctrl)
+	suite.prAction = NewPRAction(suite.mockPrClient, suite.mockHistory, suite.mockOutput, suite.mockClipboard, suite.mockPrompt, suite.mockEditor)
 }
 
 func (suite *PRActionTestSuite) TestInit_no_comments() {
-	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: make(map[int]history.PR)}, nil)
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: make(map[string]history.PR)}, nil)
 	prHistory := history.PR{CommentCount: 0}
-	suite.mockHistory.EXPECT().Save(history.History{Prs: map[int]history.PR{2: prHistory}}).Return(nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": prHistory}}).Return(nil)
 	repo := repository.Repository{
 		Owner: "Bowser",
 		Name:  "castle",
@@ -58,9 +66,9 @@ func (suite *PRActionTestSuite) TestInit_no_comments() {
 }
 
 func (suite *PRActionTestSuite) TestInit_gets_pr_number() {
-	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: make(map[int]history.PR)}, nil)
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: make(map[string]history.PR)}, nil)
 	prHistory := history.PR{CommentCount: 0}
-	suite.mockHistory.EXPECT().Save(history.History{Prs: map[int]history.PR{2: prHistory}}).Return(nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": prHistory}}).Return(nil)
 	repo := repository.Repository{
 		Owner: "Bowser",
 		Name:  "castle",
@@ -91,19 +99,13 @@ func (suite *PRActionTestSuite) TestInit_error_when_getting_pr_number() {
 }
 
 func (suite *PRActionTestSuite) TestInit_invalid_pr_number() {
-	repo := repository.Repository{
-		Owner: "Bowser",
-		Name:  "castle",
-	}
-	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repo, nil)
-
-	err := suite.prAction.Init([]string{"asdasd"}, false)
+	err := suite.prAction.Init([]string{"not a branch"}, false)
 	suite.ErrorContains(err, "provide a valid PR number")
 }
 
 func (suite *PRActionTestSuite) TestInit_new_comments_never_viewed_pr() {
-	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[int]history.PR{}}, nil)
-	suite.mockHistory.EXPECT().Save(history.History{Prs: map[int]history.PR{2: {CommentCount: 2}}}).Return(nil)
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{}}, nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": {CommentCount: 2}}}).Return(nil)
 	repo := repository.Repository{
 		Owner: "Bowser",
 		Name:  "castle",
@@ -123,8 +125,8 @@ func (suite *PRActionTestSuite) TestInit_new_comments_never_viewed_pr() {
 }
 
 func (suite *PRActionTestSuite) TestInit_new_comments_since_last_view() {
-	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[int]history.PR{2: {CommentCount: 1}}}, nil)
-	suite.mockHistory.EXPECT().Save(history.History{Prs: map[int]history.PR{2: {CommentCount: 2}}}).Return(nil)
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": {CommentCount: 1}}}, nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": {CommentCount: 2}}}).Return(nil)
 	repo := repository.Repository{
 		Owner: "Bowser",
 		Name:  "castle",
@@ -143,9 +145,56 @@ func (suite *PRActionTestSuite) TestInit_new_comments_since_last_view() {
 	suite.NoError(err)
 }
 
+func (suite *PRActionTestSuite) TestInit_plays_cue_for_unread_comments() {
+	lastSeen := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	suite.prAction.Sounds = suite.mockSounds
+	newest := lastSeen.Add(time.Hour)
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": {CommentCount: 1, LastSeen: &lastSeen}}}, nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": {CommentCount: 2, LastSeen: &newest}}}).Return(nil)
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "Bowser", Name: "castle"}, nil)
+	suite.mockPrClient.EXPECT().GetPRDetails(suite.prAction.Repo, false).Return(&git.PR{
+		Comments: []git.Comment{
+			{Body: "Comment 1", Author: git.Author{Login: "Mario"}, CreatedAt: lastSeen.Add(time.Hour)},
+			{Body: "Comment 2", Author: git.Author{Login: "Peach"}, CreatedAt: lastSeen},
+		},
+	}, nil)
+
+	gomock.InOrder(
+		suite.mockOutput.EXPECT().Println("New comments ahead!"),
+		suite.mockSounds.EXPECT().Play(sound.Unread).Return(nil),
+		suite.mockOutput.EXPECT().Println("Mario"),
+		suite.mockOutput.EXPECT().Println("Comment 1"),
+	)
+	err := suite.prAction.Init([]string{"2"}, false)
+	suite.NoError(err)
+
+	suite.mockOutput.EXPECT().Println("Peach")
+	suite.mockOutput.EXPECT().Println("Comment 2")
+	suite.prAction.Next(suite.prAction.Print)
+}
+
+func (suite *PRActionTestSuite) TestInit_timeline_does_not_count_events() {
+	suite.prAction.Timeline = true
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": {CommentCount: 1}}}, nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": {CommentCount: 1}}}).Return(nil)
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Owner: "Bowser", Name: "castle"}, nil)
+	suite.mockPrClient.EXPECT().GetPRTimeline(suite.prAction.Repo, false).Return(&git.PR{
+		Comments: []git.Comment{
+			{Body: "pushed 1 commit\nabc1234 Add castle", Author: git.Author{Login: "Mario"}, Kind: git.EventKind},
+			{Body: "Comment 1", Author: git.Author{Login: "Peach"}, Kind: git.IssueCommentKind},
+		},
+	}, nil)
+
+	suite.mockOutput.EXPECT().Println("Mario")
+	suite.mockOutput.EXPECT().Println("pushed 1 commit\nabc1234 Add castle")
+	err := suite.prAction.Init([]string{"2"}, false)
+	suite.NoError(err)
+	suite.Equal(1, suite.prAction.MaxIndex)
+}
+
 func (suite *PRActionTestSuite) TestInit_verbose_prints_state() {
-	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[int]history.PR{}}, nil)
-	suite.mockHistory.EXPECT().Save(history.History{Prs: map[int]history.PR{2: {CommentCount: 2}}}).Return(nil)
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{}}, nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": {CommentCount: 2}}}).Return(nil)
 	repo := repository.Repository{
 		Owner: "Bowser",
 		Name:  "castle",
@@ -182,8 +231,8 @@ func (suite *PRActionTestSuite) TestInit_verbose_prints_state() {
 }
 
 func (suite *PRActionTestSuite) TestInit_no_new_comments_since_last_view() {
-	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[int]history.PR{2: {CommentCount: 2}}}, nil)
-	suite.mockHistory.EXPECT().Save(history.History{Prs: map[int]history.PR{2: {CommentCount: 2}}}).Return(nil)
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": {CommentCount: 2}}}, nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": {CommentCount: 2}}}).Return(nil)
 	repo := repository.Repository{
 		Owner: "Bowser",
 		Name:  "castle",
@@ -251,8 +300,8 @@ func (suite *PRActionTestSuite) TestInit_err_loading_history() {
 func (suite *PRActionTestSuite) TestInit_err_saving_history() {
 	expectedErr := errors.New("no permission to write file")
 	prHistory := history.PR{CommentCount: 2}
-	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[int]history.PR{2: {CommentCount: 1}}}, nil)
-	suite.mockHistory.EXPECT().Save(history.History{Prs: map[int]history.PR{2: prHistory}}).Return(expectedErr)
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": {CommentCount: 1}}}, nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{"github.com/bowser/castle#2": prHistory}}).Return(expectedErr)
 	repo := repository.Repository{
 		Owner: "Bowser",
 		Name:  "castle",
@@ -560,7 +609,7 @@ func (suite *PRActionTestSuite) TestResolve_error() {
 }
 
 func (suite *PRActionTestSuite) TestDoPrompt_repeat() {
-	suite.mockPrompt.EXPECT().String("n to go to the next result, p for previous, r to repeat, x to copy or q to quit").Return("r")
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit").Return("r")
 	suite.mockOutput.EXPECT().Println("Mario")
 	suite.mockOutput.EXPECT().Println("Comment 1")
 	suite.prAction.Index = 0
@@ -582,7 +631,7 @@ func (suite *PRActionTestSuite) TestDoPrompt_repeat() {
 }
 
 func (suite *PRActionTestSuite) TestDoPrompt_next() {
-	suite.mockPrompt.EXPECT().String("n to go to the next result, p for previous, r to repeat, x to copy or q to quit").Return("n")
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit").Return("n")
 	suite.mockOutput.EXPECT().Println("Luigi")
 	suite.mockOutput.EXPECT().Println("README.md")
 	suite.mockOutput.EXPECT().Println("/")
@@ -609,7 +658,7 @@ func (suite *PRActionTestSuite) TestDoPrompt_next() {
 }
 
 func (suite *PRActionTestSuite) TestDoPrompt_previous() {
-	suite.mockPrompt.EXPECT().String("n to go to the next result, p for previous, r to repeat, x to copy or q to quit").Return("p")
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit").Return("p")
 	suite.mockOutput.EXPECT().Println(github.MainThread)
 	suite.mockOutput.EXPECT().Println("Mario")
 	suite.mockOutput.EXPECT().Println("Comment 1")
@@ -636,7 +685,7 @@ func (suite *PRActionTestSuite) TestDoPrompt_previous() {
 }
 
 func (suite *PRActionTestSuite) TestDoPrompt_invalid() {
-	suite.mockPrompt.EXPECT().String("n to go to the next result, p for previous, r to repeat, x to copy or q to quit").Return("w")
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit").Return("w")
 	suite.mockOutput.EXPECT().Println("Invalid choice")
 	suite.prAction.Index = 0
 	suite.prAction.MaxIndex = 0
@@ -655,7 +704,7 @@ func (suite *PRActionTestSuite) TestDoPrompt_invalid() {
 }
 
 func (suite *PRActionTestSuite) TestDoPrompt_expand() {
-	suite.mockPrompt.EXPECT().String("n to go to the next result, p for previous, r to repeat, x to copy or q to quit, e to expand").Return("e")
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit, e to expand").Return("e")
 	suite.mockOutput.EXPECT().Println("Luigi")
 	suite.mockOutput.EXPECT().Println("README.md")
 	suite.mockOutput.EXPECT().Println("/")
@@ -689,7 +738,7 @@ func (suite *PRActionTestSuite) TestDoPrompt_expand() {
 }
 
 func (suite *PRActionTestSuite) TestDoPrompt_copy() {
-	suite.mockPrompt.EXPECT().String("n to go to the next result, p for previous, r to repeat, x to copy or q to quit, e to expand").Return("x")
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit, e to expand").Return("x")
 	suite.mockClipboard.EXPECT().Write("Comment 2").Return(nil)
 	suite.prAction.Index = 1
 	suite.prAction.MaxIndex = 1
@@ -717,7 +766,7 @@ func (suite *PRActionTestSuite) TestDoPrompt_copy() {
 }
 
 func (suite *PRActionTestSuite) TestDoPrompt_copy_fails() {
-	suite.mockPrompt.EXPECT().String("n to go to the next result, p for previous, r to repeat, x to copy or q to quit, e to expand").Return("x")
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit, e to expand").Return("x")
 	suite.mockClipboard.EXPECT().Write("Comment 2").Return(errors.New("oops"))
 	suite.mockOutput.EXPECT().Println("oops")
 	suite.prAction.Index = 1
@@ -756,7 +805,7 @@ func (suite *PRActionTestSuite) TestDoPrompt_resolve() {
 		},
 		File: git.File{FullPath: "README.md:28", Path: "/", Line: 28, LineContents: "whhhaaayy", FileName: "README.md"},
 	}
-	suite.mockPrompt.EXPECT().String("n to go to the next result, p for previous, r to repeat, x to copy or q to quit, e to expand").Return("res")
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit, e to expand").Return("done")
 	suite.mockPrClient.EXPECT().Resolve(&comment2).Return(nil)
 	suite.mockOutput.EXPECT().Println("Conversation resolved")
 	suite.prAction.Index = 1
@@ -786,10 +835,15 @@ func (suite *PRActionTestSuite) TestDoPrompt_reply() {
 		},
 		File: git.File{FullPath: "README.md:28", Path: "/", Line: 28, LineContents: "whhhaaayy", FileName: "README.md"},
 	}
-	suite.mockPrompt.EXPECT().String("n to go to the next result, p for previous, r to repeat, x to copy or q to quit, e to expand").Return("c")
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit, e to expand").Return("c")
 	suite.mockPrompt.EXPECT().String("Type comment and press enter").Return("sounds good!")
+	suite.expectDraftSaved("README.md:28", "sounds good!")
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("sounds good!")
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("p")
 	suite.mockPrClient.EXPECT().Reply("sounds good!", gomock.Any(), gomock.Any()).Return(nil)
 	suite.mockOutput.EXPECT().Println("Posted comment")
+	suite.expectDraftDeleted("README.md:28", "sounds good!")
 	suite.prAction.Index = 1
 	suite.prAction.MaxIndex = 1
 	suite.prAction.Results = []git.Comment{{
@@ -806,6 +860,979 @@ func (suite *PRActionTestSuite) TestDoPrompt_reply() {
 	suite.prAction.doPrompt()
 }
 
+func (suite *PRActionTestSuite) TestDoPrompt_reply_multi_line() {
+	body := "first line\n\n```go\nfmt.Println()\n```"
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit").Return("cm")
+	suite.mockPrompt.EXPECT().Lines("Type comment, then a line with just . to finish", ".").Return(body)
+	suite.expectDraftSaved(github.MainThread, body)
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println(body)
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("p")
+	suite.mockPrClient.EXPECT().Reply(body, gomock.Any(), gomock.Any()).Return(nil)
+	suite.mockOutput.EXPECT().Println("Posted comment")
+	suite.expectDraftDeleted(github.MainThread, body)
+	suite.prAction.Results = []git.Comment{{
+		Body: "Comment 1",
+		Author: git.Author{
+			Login: "Mario",
+		},
+		File: git.File{
+			FullPath: github.MainThread,
+			FileName: github.MainThread,
+		},
+	}}
+
+	suite.prAction.doPrompt()
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_reply_in_editor() {
+	body := "> Comment 1\n> over two lines\n\nAgreed"
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit").Return("ce")
+	suite.mockEditor.EXPECT().Edit("> Comment 1\n> over two lines\n\n").Return(body+"\n", nil)
+	suite.expectDraftSaved(github.MainThread, body)
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println(body)
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("p")
+	suite.mockPrClient.EXPECT().Reply(body, gomock.Any(), gomock.Any()).Return(nil)
+	suite.mockOutput.EXPECT().Println("Posted comment")
+	suite.expectDraftDeleted(github.MainThread, body)
+	suite.prAction.Results = []git.Comment{{
+		Body: "Comment 1\nover two lines",
+		Author: git.Author{
+			Login: "Mario",
+		},
+		File: git.File{
+			FullPath: github.MainThread,
+			FileName: github.MainThread,
+		},
+	}}
+
+	suite.prAction.doPrompt()
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_reply_in_editor_unchanged_cancels() {
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit").Return("ce")
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{}}, nil).Times(2)
+	suite.mockEditor.EXPECT().Edit("> Comment 1\n\n").Return("> Comment 1\n\n", nil)
+	suite.mockOutput.EXPECT().Println("Comment is empty, nothing posted")
+	suite.prAction.Results = []git.Comment{{
+		Body: "Comment 1",
+		Author: git.Author{
+			Login: "Mario",
+		},
+		File: git.File{
+			FullPath: github.MainThread,
+			FileName: github.MainThread,
+		},
+	}}
+
+	suite.prAction.doPrompt()
+}
+
+func (suite *PRActionTestSuite) TestComment_edit_then_post() {
+	suite.expectDraftSaved(github.MainThread, "frist")
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("frist")
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("e")
+	suite.mockEditor.EXPECT().Edit("frist").Return("first\n", nil)
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{suite.historyKey(): {Drafts: map[string]string{github.MainThread: "frist"}}}}, nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{suite.historyKey(): {Drafts: map[string]string{github.MainThread: "first"}}}}).Return(nil)
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("first")
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("p")
+	suite.mockPrClient.EXPECT().Reply("first", gomock.Any(), gomock.Any()).Return(nil)
+	suite.mockOutput.EXPECT().Println("Posted comment")
+	suite.expectDraftDeleted(github.MainThread, "first")
+	suite.prAction.Results = []git.Comment{{
+		Body: "Comment 1",
+		File: git.File{FullPath: github.MainThread},
+	}}
+
+	suite.prAction.Comment(func() string { return "frist" })
+}
+
+func (suite *PRActionTestSuite) TestComment_cancel_removes_draft() {
+	suite.expectDraftSaved(github.MainThread, "oops")
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("oops")
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("c")
+	suite.mockOutput.EXPECT().Println("Comment cancelled")
+	suite.expectDraftDeleted(github.MainThread, "oops")
+	suite.prAction.Results = []git.Comment{{
+		Body: "Comment 1",
+		File: git.File{FullPath: github.MainThread},
+	}}
+
+	suite.prAction.Comment(func() string { return "oops" })
+}
+
+func (suite *PRActionTestSuite) TestComment_keeps_draft_when_post_fails() {
+	suite.expectDraftSaved(github.MainThread, "LGTM")
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("LGTM")
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("p")
+	suite.mockPrClient.EXPECT().Reply("LGTM", gomock.Any(), gomock.Any()).Return(errors.New("offline"))
+	suite.mockOutput.EXPECT().Println("Warning failed to comment: offline")
+	suite.prAction.Results = []git.Comment{{
+		Body: "Comment 1",
+		File: git.File{FullPath: github.MainThread},
+	}}
+
+	suite.prAction.Comment(func() string { return "LGTM" })
+}
+
+func (suite *PRActionTestSuite) TestComment_uses_unsent_draft() {
+	drafts := history.History{Prs: map[string]history.PR{suite.historyKey(): {Drafts: map[string]string{"thread1": "Half written"}}}}
+	suite.mockHistory.EXPECT().Load().Return(drafts, nil).Times(2)
+	suite.mockOutput.EXPECT().Println("You have an unsent draft")
+	suite.mockOutput.EXPECT().Println("Half written")
+	suite.mockPrompt.EXPECT().String("y to continue with it or n to start again").Return("y")
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("Half written")
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("p")
+	suite.mockPrClient.EXPECT().Reply("Half written", gomock.Any(), gomock.Any()).Return(nil)
+	suite.mockOutput.EXPECT().Println("Posted comment")
+	suite.expectDraftDeleted("thread1", "Half written")
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Comment 1",
+		Thread: git.Thread{ID: "thread1"},
+	}}
+
+	suite.prAction.Comment(func() string {
+		suite.Fail("should not ask for a new comment")
+		return ""
+	})
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_own_comment_offers_edit_and_delete() {
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit, edit to edit, del to delete").Return("w")
+	suite.mockOutput.EXPECT().Println("Invalid choice")
+	suite.prAction.Viewer = "Mario"
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Comment 1",
+		Author: git.Author{Login: "Mario"},
+		Kind:   git.IssueCommentKind,
+	}}
+
+	suite.prAction.doPrompt()
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_own_review_offers_edit() {
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit, edit to edit").Return("w")
+	suite.mockOutput.EXPECT().Println("Invalid choice")
+	suite.prAction.Viewer = "Mario"
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Comment 1",
+		Author: git.Author{Login: "Mario"},
+		Kind:   git.ReviewKind,
+	}}
+
+	suite.prAction.doPrompt()
+}
+
+func (suite *PRActionTestSuite) TestEditComment() {
+	suite.prAction.Viewer = "Mario"
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Typo hree",
+		Author: git.Author{Login: "Mario"},
+		Kind:   git.IssueCommentKind,
+	}}
+	suite.mockEditor.EXPECT().Edit("Typo hree").Return("Typo here\n", nil)
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("Typo here")
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("p")
+	suite.mockPrClient.EXPECT().Edit("Typo here", &suite.prAction.Results[0]).Return(nil)
+	suite.mockOutput.EXPECT().Println("Comment updated")
+
+	suite.prAction.EditComment()
+	suite.Equal("Typo here", suite.prAction.Results[0].Body)
+}
+
+func (suite *PRActionTestSuite) TestEditComment_unchanged() {
+	suite.prAction.Viewer = "Mario"
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Typo hree",
+		Author: git.Author{Login: "Mario"},
+		Kind:   git.IssueCommentKind,
+	}}
+	suite.mockEditor.EXPECT().Edit("Typo hree").Return("Typo hree", nil)
+	suite.mockOutput.EXPECT().Println("Nothing changed")
+
+	suite.prAction.EditComment()
+}
+
+func (suite *PRActionTestSuite) TestEditComment_error() {
+	suite.prAction.Viewer = "Mario"
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Typo hree",
+		Author: git.Author{Login: "Mario"},
+		Kind:   git.IssueCommentKind,
+	}}
+	suite.mockEditor.EXPECT().Edit("Typo hree").Return("Typo here", nil)
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("Typo here")
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("p")
+	suite.mockPrClient.EXPECT().Edit("Typo here", gomock.Any()).Return(errors.New("forbidden"))
+	suite.mockOutput.EXPECT().Println("Warning failed to edit comment: forbidden")
+
+	suite.prAction.EditComment()
+	suite.Equal("Typo hree", suite.prAction.Results[0].Body)
+}
+
+func (suite *PRActionTestSuite) TestEditComment_not_own_comment() {
+	suite.prAction.Viewer = "Mario"
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Rraaawwww",
+		Author: git.Author{Login: "Bowser"},
+		Kind:   git.IssueCommentKind,
+	}}
+	suite.mockOutput.EXPECT().Println("You can only edit your own comments")
+
+	suite.prAction.EditComment()
+}
+
+func (suite *PRActionTestSuite) TestDeleteComment() {
+	suite.prAction.Viewer = "Mario"
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Rraaawwww",
+		Author: git.Author{Login: "Bowser"},
+		File:   git.File{FullPath: github.MainThread, FileName: github.MainThread},
+	}, {
+		Body:   "Oops",
+		Author: git.Author{Login: "Mario"},
+		Kind:   git.IssueCommentKind,
+		File:   git.File{FullPath: github.MainThread, FileName: github.MainThread},
+	}}
+	suite.prAction.Index = 1
+	suite.prAction.MaxIndex = 1
+	suite.mockPrompt.EXPECT().String("y to delete this comment, anything else to keep it").Return("y")
+	suite.mockPrClient.EXPECT().Delete(&git.Comment{
+		Body:   "Oops",
+		Author: git.Author{Login: "Mario"},
+		Kind:   git.IssueCommentKind,
+		File:   git.File{FullPath: github.MainThread, FileName: github.MainThread},
+	}).Return(nil)
+	suite.mockOutput.EXPECT().Println("Comment deleted")
+	suite.mockOutput.EXPECT().Println(github.MainThread)
+	suite.mockOutput.EXPECT().Println("Bowser")
+	suite.mockOutput.EXPECT().Println("Rraaawwww")
+
+	suite.prAction.DeleteComment()
+	suite.Len(suite.prAction.Results, 1)
+	suite.Equal(0, suite.prAction.Index)
+	suite.Equal(0, suite.prAction.MaxIndex)
+}
+
+func (suite *PRActionTestSuite) TestDeleteComment_not_confirmed() {
+	suite.prAction.Viewer = "Mario"
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Oops",
+		Author: git.Author{Login: "Mario"},
+		Kind:   git.IssueCommentKind,
+	}}
+	suite.mockPrompt.EXPECT().String("y to delete this comment, anything else to keep it").Return("n")
+	suite.mockOutput.EXPECT().Println("Comment kept")
+
+	suite.prAction.DeleteComment()
+	suite.Len(suite.prAction.Results, 1)
+}
+
+func (suite *PRActionTestSuite) TestDeleteComment_error() {
+	suite.prAction.Viewer = "Mario"
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Oops",
+		Author: git.Author{Login: "Mario"},
+		Kind:   git.ReviewKind,
+	}}
+	suite.mockPrompt.EXPECT().String("y to delete this comment, anything else to keep it").Return("y")
+	suite.mockPrClient.EXPECT().Delete(gomock.Any()).Return(errors.New("cannot delete a submitted review, edit it instead"))
+	suite.mockOutput.EXPECT().Println("Warning failed to delete comment: cannot delete a submitted review, edit it instead")
+
+	suite.prAction.DeleteComment()
+	suite.Len(suite.prAction.Results, 1)
+}
+
+func (suite *PRActionTestSuite) TestPrint_prints_reactions() {
+	suite.prAction.Results = []git.Comment{{
+		Body: "Comment 1",
+		Author: git.Author{
+			Login: "Mario",
+		},
+		File: git.File{FullPath: github.MainThread},
+		Reactions: []git.Reaction{
+			{Content: "THUMBS_UP", Users: git.ReactionUsers{TotalCount: 3}},
+			{Content: "LAUGH"},
+			{Content: "EYES", Users: git.ReactionUsers{TotalCount: 1}},
+		},
+	}}
+	suite.prAction.LastFullPath = github.MainThread
+	suite.mockOutput.EXPECT().Println("Mario")
+	suite.mockOutput.EXPECT().Println("Comment 1")
+	suite.mockOutput.EXPECT().Println("3 thumbs up, 1 eyes")
+	suite.prAction.Print()
+}
+
+func (suite *PRActionTestSuite) TestPrint_renders_markdown() {
+	suite.prAction.Results = []git.Comment{{
+		Body:   "<!-- template -->\nUse **this**\n```go\nfoo()\n```",
+		Author: git.Author{Login: "Mario"},
+		File:   git.File{FullPath: github.MainThread},
+	}}
+	suite.prAction.LastFullPath = github.MainThread
+	suite.mockOutput.EXPECT().Println("Mario")
+	suite.mockOutput.EXPECT().Println("Use this\ncode block, go\nfoo()\nend code block")
+	suite.prAction.Print()
+}
+
+func (suite *PRActionTestSuite) TestPrint_prints_raw_markdown() {
+	suite.prAction.Raw = true
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Use **this**",
+		Author: git.Author{Login: "Mario"},
+		File:   git.File{FullPath: github.MainThread},
+	}}
+	suite.prAction.LastFullPath = github.MainThread
+	suite.mockOutput.EXPECT().Println("Mario")
+	suite.mockOutput.EXPECT().Println("Use **this**")
+	suite.prAction.Print()
+}
+
+func (suite *PRActionTestSuite) TestPrint_terse_skips_path_and_code() {
+	suite.prAction.Verbosity = internal.Terse
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Comment 1",
+		Author: git.Author{Login: "Mario"},
+		File: git.File{
+			FullPath:     "src/castle.go:4",
+			Path:         "src",
+			FileName:     "castle.go",
+			Line:         4,
+			LineContents: "return nil",
+		},
+		Reactions: []git.Reaction{{Content: "EYES", Users: git.ReactionUsers{TotalCount: 1}}},
+	}}
+	suite.mockOutput.EXPECT().Println("castle.go")
+	suite.mockOutput.EXPECT().Println("4")
+	suite.mockOutput.EXPECT().Println("Mario")
+	suite.mockOutput.EXPECT().Println("Comment 1")
+	suite.prAction.Print()
+}
+
+func (suite *PRActionTestSuite) TestPrint_terse_resolved() {
+	suite.prAction.Verbosity = internal.Terse
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Comment 1",
+		Thread: git.Thread{IsResolved: true},
+	}}
+	suite.mockOutput.EXPECT().Println("Resolved")
+	suite.prAction.Print()
+}
+
+func (suite *PRActionTestSuite) TestPrint_verbose_reads_resolved_with_time_and_repeats_path() {
+	now := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	suite.prAction.now = func() time.Time {
+		return now
+	}
+	suite.prAction.Verbosity = internal.Verbose
+	suite.prAction.LastFullPath = "src/castle.go:4"
+	suite.prAction.Results = []git.Comment{{
+		Body:      "Comment 1",
+		Author:    git.Author{Login: "Mario"},
+		CreatedAt: now.Add(-2 * time.Hour),
+		Thread:    git.Thread{IsResolved: true},
+		File: git.File{
+			FullPath:     "src/castle.go:4",
+			Path:         "src",
+			FileName:     "castle.go",
+			Line:         4,
+			LineContents: "return nil",
+		},
+	}}
+	gomock.InOrder(
+		suite.mockOutput.EXPECT().Println("This comment is resolved"),
+		suite.mockOutput.EXPECT().Println("castle.go"),
+		suite.mockOutput.EXPECT().Println("src"),
+		suite.mockOutput.EXPECT().Println("4"),
+		suite.mockOutput.EXPECT().Println("return nil"),
+		suite.mockOutput.EXPECT().Println("Mario"),
+		suite.mockOutput.EXPECT().Println("2 hours ago"),
+		suite.mockOutput.EXPECT().Println("Comment 1"),
+	)
+	suite.prAction.Print()
+}
+
+func (suite *PRActionTestSuite) TestPrint_custom_order() {
+	verbosity, err := internal.ParseVerbosity("body,author")
+	suite.NoError(err)
+	suite.prAction.Verbosity = verbosity
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Comment 1",
+		Author: git.Author{Login: "Mario"},
+		File:   git.File{FullPath: github.MainThread, FileName: github.MainThread},
+	}}
+	gomock.InOrder(
+		suite.mockOutput.EXPECT().Println("Comment 1"),
+		suite.mockOutput.EXPECT().Println("Mario"),
+	)
+	suite.prAction.Print()
+}
+
+func (suite *PRActionTestSuite) TestPrint_plays_cues_for_resolved_and_outdated() {
+	suite.prAction.Sounds = suite.mockSounds
+	suite.prAction.Results = []git.Comment{
+		{Body: "Comment 1", Thread: git.Thread{IsResolved: true}},
+		{Body: "Comment 2", Outdated: true},
+	}
+	suite.prAction.MaxIndex = 1
+	gomock.InOrder(
+		suite.mockSounds.EXPECT().Play(sound.Resolved).Return(nil),
+		suite.mockOutput.EXPECT().Println("This comment is resolved"),
+		suite.mockSounds.EXPECT().Play(sound.Outdated).Return(nil),
+		suite.mockOutput.EXPECT().Println("This comment is outdated"),
+	)
+	suite.prAction.Print()
+	suite.prAction.Next(suite.prAction.Print)
+}
+
+func (suite *PRActionTestSuite) TestNext_plays_cue_at_end() {
+	suite.prAction.Sounds = suite.mockSounds
+	suite.prAction.Results = []git.Comment{{Body: "Comment 1"}}
+	suite.mockSounds.EXPECT().Play(sound.End).Return(nil).Times(2)
+	suite.mockOutput.EXPECT().Println("Nothing here").Times(2)
+	suite.prAction.Next(suite.prAction.Print)
+	suite.prAction.Previous(suite.prAction.Print)
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_plays_cue_on_failure_and_stops_when_sound_fails() {
+	suite.prAction.Sounds = suite.mockSounds
+	suite.prAction.Results = []git.Comment{{Body: "Comment 1"}}
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("wat").Times(2)
+	suite.mockOutput.EXPECT().Println("Invalid choice").Times(2)
+	suite.mockSounds.EXPECT().Play(sound.Failure).Return(errors.New("paplay not found"))
+	suite.mockOutput.EXPECT().Println("Warning failed to play sound: paplay not found")
+
+	suite.prAction.doPrompt()
+	suite.prAction.doPrompt()
+}
+
+func (suite *PRActionTestSuite) TestReact_adds_reaction() {
+	suite.prAction.Results = []git.Comment{{
+		Id:   "PDDD_e43oidmdm",
+		Body: "Comment 1",
+	}}
+	suite.mockPrompt.EXPECT().String("Type a reaction: +1, -1, laugh, hooray, confused, heart, rocket or eyes").Return("+1")
+	suite.mockPrClient.EXPECT().React(&suite.prAction.Results[0], "THUMBS_UP").Return(nil)
+	suite.mockOutput.EXPECT().Println("Added thumbs up")
+
+	suite.prAction.React("")
+	suite.Equal("1 thumbs up", suite.prAction.Results[0].ReactionSummary())
+}
+
+func (suite *PRActionTestSuite) TestReact_removes_existing_reaction() {
+	suite.prAction.Results = []git.Comment{{
+		Id:        "PDDD_e43oidmdm",
+		Body:      "Comment 1",
+		Reactions: []git.Reaction{{Content: "HEART", Users: git.ReactionUsers{TotalCount: 1}, ViewerHasReacted: true}},
+	}}
+	suite.mockPrompt.EXPECT().String("Type a reaction: +1, -1, laugh, hooray, confused, heart, rocket or eyes").Return("heart")
+	suite.mockPrClient.EXPECT().RemoveReaction(&suite.prAction.Results[0], "HEART").Return(nil)
+	suite.mockOutput.EXPECT().Println("Removed heart")
+
+	suite.prAction.React("")
+	suite.Equal("", suite.prAction.Results[0].ReactionSummary())
+}
+
+func (suite *PRActionTestSuite) TestReact_unknown_reaction() {
+	suite.prAction.Results = []git.Comment{{
+		Id:   "PDDD_e43oidmdm",
+		Body: "Comment 1",
+	}}
+	suite.mockPrompt.EXPECT().String("Type a reaction: +1, -1, laugh, hooray, confused, heart, rocket or eyes").Return("clap")
+	suite.mockOutput.EXPECT().Println("unknown reaction clap")
+
+	suite.prAction.React("")
+}
+
+func (suite *PRActionTestSuite) TestReact_error() {
+	suite.prAction.Results = []git.Comment{{
+		Id:   "PDDD_e43oidmdm",
+		Body: "Comment 1",
+	}}
+	suite.mockPrompt.EXPECT().String("Type a reaction: +1, -1, laugh, hooray, confused, heart, rocket or eyes").Return("rocket")
+	suite.mockPrClient.EXPECT().React(gomock.Any(), "ROCKET").Return(errors.New("offline"))
+	suite.mockOutput.EXPECT().Println("Warning failed to react: offline")
+
+	suite.prAction.React("")
+	suite.Empty(suite.prAction.Results[0].Reactions)
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_quote_reply() {
+	body := "> Comment 1\n\n@Mario Fixed"
+	suite.mockPrompt.EXPECT().Command("n to go to the next result, p for previous, r to repeat, x to copy or q to quit").Return("cq")
+	suite.mockPrompt.EXPECT().String("Type reply to the quote and press enter").Return("Fixed")
+	suite.expectDraftSaved(github.MainThread, body)
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println(body)
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("p")
+	suite.mockPrClient.EXPECT().Reply(body, gomock.Any(), gomock.Any()).Return(nil)
+	suite.mockOutput.EXPECT().Println("Posted comment")
+	suite.expectDraftDeleted(github.MainThread, body)
+	suite.prAction.Results = []git.Comment{{
+		Body: "Comment 1",
+		Author: git.Author{
+			Login: "Mario",
+		},
+		File: git.File{
+			FullPath: github.MainThread,
+			FileName: github.MainThread,
+		},
+	}}
+
+	suite.prAction.doPrompt()
+}
+
+func (suite *PRActionTestSuite) TestRun_returns_when_quitting() {
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("r")
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("q")
+	suite.mockOutput.EXPECT().Println("Mario")
+	suite.mockOutput.EXPECT().Println("Comment 1")
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Comment 1",
+		Author: git.Author{Login: "Mario"},
+		File:   git.File{FullPath: github.MainThread},
+	}}
+
+	err := suite.prAction.Run()
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestRun_returns_error_when_commands_fail() {
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("done")
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("wat")
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("q")
+	suite.mockPrClient.EXPECT().Resolve(gomock.Any()).Return(errors.New("cannot resolve a main or commit comment"))
+	suite.mockOutput.EXPECT().Println("Warning failed to resolve thread: cannot resolve a main or commit comment")
+	suite.mockOutput.EXPECT().Println("Invalid choice")
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Comment 1",
+		Author: git.Author{Login: "Mario"},
+		File:   git.File{FullPath: github.MainThread},
+	}}
+
+	err := suite.prAction.Run()
+	suite.EqualError(err, "2 commands failed")
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_comment_with_argument_without_confirmation() {
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("c LGTM ship it")
+	suite.mockPrClient.EXPECT().Reply("LGTM ship it", gomock.Any(), gomock.Any()).Return(nil)
+	suite.mockOutput.EXPECT().Println("Posted comment")
+	suite.prAction.Confirm = false
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Comment 1",
+		Author: git.Author{Login: "Mario"},
+		File:   git.File{FullPath: github.MainThread},
+	}}
+
+	suite.prAction.doPrompt()
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_react_with_argument() {
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("emoji eyes")
+	suite.mockPrClient.EXPECT().React(gomock.Any(), "EYES").Return(nil)
+	suite.mockOutput.EXPECT().Println("Added eyes")
+	suite.prAction.Results = []git.Comment{{
+		Id:     "PDDD_e43oidmdm",
+		Body:   "Comment 1",
+		Author: git.Author{Login: "Mario"},
+		File:   git.File{FullPath: github.MainThread},
+	}}
+
+	suite.prAction.doPrompt()
+}
+
+func (suite *PRActionTestSuite) TestDeleteComment_last_comment_without_confirmation_finishes() {
+	suite.prAction.Viewer = "Mario"
+	suite.prAction.Confirm = false
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Oops",
+		Author: git.Author{Login: "Mario"},
+		Kind:   git.IssueCommentKind,
+	}}
+	suite.mockPrClient.EXPECT().Delete(gomock.Any()).Return(nil)
+	suite.mockOutput.EXPECT().Println("Comment deleted")
+	suite.mockOutput.EXPECT().Println("No comments left")
+
+	err := suite.prAction.DeleteComment()
+	suite.NoError(err)
+	suite.NoError(suite.prAction.Run())
+}
+
+func (suite *PRActionTestSuite) expectMergeState() {
+	suite.mockPrClient.EXPECT().GetMergeState(suite.prAction.Repo).Return(&git.MergeState{
+		MergeStateStatus: "CLEAN",
+		MergeStatus:      "Mergeable and passing checks",
+		ConflictStatus:   "No conflicts",
+		HeadRef:          git.Ref{Id: "REF_kwDOA", Name: "feature/pipes"},
+	}, nil)
+	suite.mockOutput.EXPECT().Println("Merge status: Mergeable and passing checks")
+	suite.mockOutput.EXPECT().Println("Conflict status: No conflicts")
+}
+
+func (suite *PRActionTestSuite) TestMerge_reads_back_state_and_merges() {
+	suite.prAction.Id = "PR_kwDOA"
+	suite.expectMergeState()
+	suite.mockPrompt.EXPECT().String("y to merge, anything else to cancel").Return("y")
+	suite.mockPrClient.EXPECT().Merge("PR_kwDOA", "MERGE").Return(nil)
+	suite.mockOutput.EXPECT().Println("Pull request merged")
+
+	err := suite.prAction.Merge("")
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestMerge_squash_and_delete_branch() {
+	suite.prAction.Id = "PR_kwDOA"
+	suite.expectMergeState()
+	suite.mockPrompt.EXPECT().String("y to squash and merge and delete feature/pipes, anything else to cancel").Return("y")
+	suite.mockPrClient.EXPECT().Merge("PR_kwDOA", "SQUASH").Return(nil)
+	suite.mockOutput.EXPECT().Println("Pull request merged")
+	suite.mockPrClient.EXPECT().DeleteBranch(git.Ref{Id: "REF_kwDOA", Name: "feature/pipes"}).Return(nil)
+	suite.mockOutput.EXPECT().Println("Deleted branch feature/pipes")
+
+	err := suite.prAction.Merge("squash delete")
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestMerge_enables_auto_merge() {
+	suite.prAction.Id = "PR_kwDOA"
+	suite.expectMergeState()
+	suite.mockPrompt.EXPECT().String("y to enable auto-merge with rebase and merge, anything else to cancel").Return("y")
+	suite.mockPrClient.EXPECT().EnableAutoMerge("PR_kwDOA", "REBASE").Return(nil)
+	suite.mockOutput.EXPECT().Println("Auto-merge enabled")
+
+	err := suite.prAction.Merge("auto rebase")
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestMerge_cancelled() {
+	suite.expectMergeState()
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("n")
+	suite.mockOutput.EXPECT().Println("Merge cancelled")
+
+	err := suite.prAction.Merge("squash")
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestMerge_without_confirmation() {
+	suite.prAction.Id = "PR_kwDOA"
+	suite.prAction.Confirm = false
+	suite.expectMergeState()
+	suite.mockPrClient.EXPECT().Merge("PR_kwDOA", "SQUASH").Return(nil)
+	suite.mockOutput.EXPECT().Println("Pull request merged")
+
+	err := suite.prAction.Merge("squash")
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestMerge_unknown_option() {
+	suite.mockOutput.EXPECT().Println("unknown merge option fast, use merge, squash, rebase, auto or delete")
+
+	err := suite.prAction.Merge("fast")
+	suite.EqualError(err, "unknown merge option fast, use merge, squash, rebase, auto or delete")
+}
+
+func (suite *PRActionTestSuite) TestMerge_auto_with_delete() {
+	suite.mockOutput.EXPECT().Println("the branch cannot be deleted until auto-merge has merged the pull request")
+
+	err := suite.prAction.Merge("auto delete")
+	suite.Error(err)
+}
+
+func (suite *PRActionTestSuite) TestMerge_has_error() {
+	suite.prAction.Id = "PR_kwDOA"
+	suite.prAction.Confirm = false
+	suite.expectMergeState()
+	suite.mockPrClient.EXPECT().Merge("PR_kwDOA", "MERGE").Return(errors.New("Pull Request is not mergeable"))
+	suite.mockOutput.EXPECT().Println("Warning failed to merge: Pull Request is not mergeable")
+
+	err := suite.prAction.Merge("merge")
+	suite.Error(err)
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_merge_with_argument() {
+	suite.prAction.Id = "PR_kwDOA"
+	suite.prAction.Confirm = false
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Comment 1",
+		Author: git.Author{Login: "Mario"},
+		File:   git.File{FullPath: github.MainThread},
+	}}
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("merge squash")
+	suite.expectMergeState()
+	suite.mockPrClient.EXPECT().Merge("PR_kwDOA", "SQUASH").Return(nil)
+	suite.mockOutput.EXPECT().Println("Pull request merged")
+
+	suite.NoError(suite.prAction.doPrompt())
+}
+
+func (suite *PRActionTestSuite) TestCheckout_switches_branch() {
+	suite.mockPrClient.EXPECT().Checkout(suite.prAction.Repo, false).Return("pipes", nil)
+	suite.mockOutput.EXPECT().Println("Switched to branch pipes")
+
+	err := suite.prAction.Checkout("")
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestCheckout_uncommitted_changes() {
+	suite.mockPrClient.EXPECT().Checkout(suite.prAction.Repo, false).Return("", github.ErrUncommittedChanges)
+	suite.mockOutput.EXPECT().Println("Warning failed to check out: the working tree has uncommitted changes, commit or stash them or check out with force")
+
+	err := suite.prAction.Checkout("")
+	suite.ErrorIs(err, github.ErrUncommittedChanges)
+}
+
+func (suite *PRActionTestSuite) TestCheckout_unknown_option() {
+	suite.mockOutput.EXPECT().Println("unknown checkout option now, use force")
+
+	err := suite.prAction.Checkout("now")
+	suite.Error(err)
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_checkout_force() {
+	suite.prAction.Results = []git.Comment{{
+		Body:   "Comment 1",
+		Author: git.Author{Login: "Mario"},
+		File:   git.File{FullPath: github.MainThread},
+	}}
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("checkout force")
+	suite.mockPrClient.EXPECT().Checkout(suite.prAction.Repo, true).Return("pipes", nil)
+	suite.mockOutput.EXPECT().Println("Switched to branch pipes")
+
+	suite.NoError(suite.prAction.doPrompt())
+}
+
+func (suite *PRActionTestSuite) reviewComment(line int, originalLine int) git.Comment {
+	return git.Comment{
+		Body:   "Use a pipe",
+		Author: git.Author{Login: "Mario"},
+		Kind:   git.ReviewCommentKind,
+		File:   git.File{Path: "internal/git/", FileName: "api.go", Line: line, OriginalLine: originalLine},
+	}
+}
+
+func (suite *PRActionTestSuite) TestOpenFile_opens_at_line() {
+	suite.prAction.HeadOid = "abc123"
+	suite.prAction.Results = []git.Comment{suite.reviewComment(12, 10)}
+	suite.mockPrClient.EXPECT().LocalPath(suite.prAction.Repo, "internal/git/api.go").Return("/home/mario/kart/internal/git/api.go", nil)
+	suite.mockPrClient.EXPECT().LocalHead().Return("abc123", nil)
+	suite.mockEditor.EXPECT().Open("/home/mario/kart/internal/git/api.go", 12).Return(nil)
+
+	err := suite.prAction.OpenFile()
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestOpenFile_warns_when_checkout_differs() {
+	suite.prAction.HeadOid = "abc123"
+	suite.prAction.Results = []git.Comment{suite.reviewComment(0, 10)}
+	suite.mockPrClient.EXPECT().LocalPath(suite.prAction.Repo, "internal/git/api.go").Return("/home/mario/kart/internal/git/api.go", nil)
+	suite.mockPrClient.EXPECT().LocalHead().Return("def456", nil)
+	suite.mockOutput.EXPECT().Println("Warning your checkout is not at the latest commit of the pull request, the line may have moved")
+	suite.mockEditor.EXPECT().Open("/home/mario/kart/internal/git/api.go", 10).Return(nil)
+
+	err := suite.prAction.OpenFile()
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestOpenFile_refuses_file_from_another_repository() {
+	suite.prAction.Results = []git.Comment{suite.reviewComment(12, 10)}
+	suite.mockPrClient.EXPECT().LocalPath(suite.prAction.Repo, "internal/git/api.go").Return("", errors.New("luigi/mansion is not checked out here"))
+	suite.mockOutput.EXPECT().Println("Warning failed to open file: luigi/mansion is not checked out here")
+
+	err := suite.prAction.OpenFile()
+	suite.EqualError(err, "luigi/mansion is not checked out here")
+}
+
+func (suite *PRActionTestSuite) TestOpenFile_not_on_a_file() {
+	suite.prAction.Results = []git.Comment{{Body: "LGTM", Kind: git.PullRequestKind}}
+	suite.mockOutput.EXPECT().Println("This comment is not on a file")
+
+	err := suite.prAction.OpenFile()
+	suite.Error(err)
+}
+
+func (suite *PRActionTestSuite) TestOpenFile_has_error_opening_editor() {
+	suite.prAction.HeadOid = "abc123"
+	suite.prAction.Results = []git.Comment{suite.reviewComment(12, 10)}
+	suite.mockPrClient.EXPECT().LocalPath(suite.prAction.Repo, "internal/git/api.go").Return("/home/mario/kart/internal/git/api.go", nil)
+	suite.mockPrClient.EXPECT().LocalHead().Return("abc123", nil)
+	suite.mockEditor.EXPECT().Open(gomock.Any(), 12).Return(errors.New("error running editor no vim"))
+	suite.mockOutput.EXPECT().Println("Warning failed to open file: error running editor no vim")
+
+	err := suite.prAction.OpenFile()
+	suite.Error(err)
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_copy_url() {
+	suite.prAction.Results = []git.Comment{{Body: "Comment 1", URL: "https://github.com/luigi/castle/pull/2#issuecomment-1"}}
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("x url")
+	suite.mockClipboard.EXPECT().Write("https://github.com/luigi/castle/pull/2#issuecomment-1").Return(nil)
+
+	suite.NoError(suite.prAction.doPrompt())
+}
+
+func (suite *PRActionTestSuite) TestOpenInBrowser_opens_comment() {
+	mockBrowser := mock_os.NewMockWebBrowser(suite.ctrl)
+	suite.prAction.Browser = mockBrowser
+	suite.prAction.URL = "https://github.com/luigi/castle/pull/2"
+	suite.prAction.Results = []git.Comment{{Body: "Use a pipe", URL: "https://github.com/luigi/castle/pull/2#discussion_r1"}}
+	mockBrowser.EXPECT().Open("https://github.com/luigi/castle/pull/2#discussion_r1").Return(nil)
+
+	err := suite.prAction.OpenInBrowser()
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestOpenInBrowser_falls_back_to_pull_request() {
+	mockBrowser := mock_os.NewMockWebBrowser(suite.ctrl)
+	suite.prAction.Browser = mockBrowser
+	suite.prAction.URL = "https://github.com/luigi/castle/pull/2"
+	suite.prAction.Results = []git.Comment{{Body: "added the label bug", Kind: git.EventKind}}
+	mockBrowser.EXPECT().Open("https://github.com/luigi/castle/pull/2").Return(errors.New("error opening browser no display"))
+	suite.mockOutput.EXPECT().Println("Warning failed to open browser: error opening browser no display")
+
+	err := suite.prAction.OpenInBrowser()
+	suite.Error(err)
+}
+
+func (suite *PRActionTestSuite) TestInfo() {
+	suite.mockPrClient.EXPECT().GetMetadata(suite.prAction.Repo).Return(&git.PRMetadata{
+		Title:     "Faster pipes",
+		Labels:    []string{"bug", "good first issue"},
+		Reviewers: []string{"peach", "mushroom/plumbers"},
+	}, nil)
+	gomock.InOrder(
+		suite.mockOutput.EXPECT().Println("Faster pipes"),
+		suite.mockOutput.EXPECT().Println("Ready for review"),
+		suite.mockOutput.EXPECT().Println("Labels: bug, good first issue"),
+		suite.mockOutput.EXPECT().Println("Reviewers requested: peach, mushroom/plumbers"),
+		suite.mockOutput.EXPECT().Println("No assignees"),
+	)
+
+	err := suite.prAction.Info()
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestEditTitle_asks_for_title() {
+	suite.prAction.Id = "PR_kwDOA"
+	suite.prAction.Title = "Faster pipes"
+	suite.mockOutput.EXPECT().Println("Faster pipes")
+	suite.mockPrompt.EXPECT().String("Type the new title, or nothing to keep it").Return("Even faster pipes")
+	suite.mockPrClient.EXPECT().EditTitle("PR_kwDOA", "Even faster pipes").Return(nil)
+	suite.mockOutput.EXPECT().Println("Title updated")
+
+	err := suite.prAction.EditTitle("")
+	suite.NoError(err)
+	suite.Equal("Even faster pipes", suite.prAction.Title)
+}
+
+func (suite *PRActionTestSuite) TestEditTitle_unchanged() {
+	suite.prAction.Title = "Faster pipes"
+	suite.mockOutput.EXPECT().Println("Faster pipes")
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("")
+	suite.mockOutput.EXPECT().Println("Title unchanged")
+
+	err := suite.prAction.EditTitle("")
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestEditBody() {
+	suite.prAction.Id = "PR_kwDOA"
+	suite.prAction.Confirm = false
+	suite.prAction.Results = []git.Comment{{Body: "Makes pipes faster", Kind: git.PullRequestKind}}
+	suite.mockPrClient.EXPECT().GetMetadata(suite.prAction.Repo).Return(&git.PRMetadata{Body: "Makes pipes faster"}, nil)
+	suite.mockEditor.EXPECT().Edit("Makes pipes faster").Return("Makes pipes much faster\n", nil)
+	suite.mockPrClient.EXPECT().Edit("Makes pipes much faster", &git.Comment{Id: "PR_kwDOA", Kind: git.PullRequestKind}).Return(nil)
+	suite.mockOutput.EXPECT().Println("Description updated")
+
+	err := suite.prAction.EditBody()
+	suite.NoError(err)
+	suite.Equal("Makes pipes much faster", suite.prAction.Results[0].Body)
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_add_labels() {
+	suite.prAction.Id = "PR_kwDOA"
+	suite.prAction.Results = []git.Comment{{Body: "Comment 1"}}
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("label add bug, good first issue")
+	suite.mockPrClient.EXPECT().AddLabels(suite.prAction.Repo, "PR_kwDOA", []string{"bug", "good first issue"}).Return(nil)
+	suite.mockOutput.EXPECT().Println("Added bug, good first issue")
+
+	suite.NoError(suite.prAction.doPrompt())
+}
+
+func (suite *PRActionTestSuite) TestEditList_removes_team_reviewer() {
+	suite.mockPrClient.EXPECT().RemoveReviewers(suite.prAction.Repo, []string{"mushroom/plumbers"}).Return(nil)
+	suite.mockOutput.EXPECT().Println("No longer waiting for a review from mushroom/plumbers")
+
+	err := suite.prAction.EditList("ask", "remove mushroom/plumbers")
+	suite.NoError(err)
+}
+
+func (suite *PRActionTestSuite) TestEditList_has_error() {
+	suite.prAction.Id = "PR_kwDOA"
+	suite.mockPrClient.EXPECT().AddAssignees("PR_kwDOA", []string{"bowser"}).Return(errors.New("user bowser not found"))
+	suite.mockOutput.EXPECT().Println("Warning failed to change assignees: user bowser not found")
+
+	err := suite.prAction.EditList("assignee", "add bowser")
+	suite.Error(err)
+}
+
+func (suite *PRActionTestSuite) TestEditList_without_names() {
+	suite.mockOutput.EXPECT().Println("type label add or label remove followed by names separated by commas")
+
+	err := suite.prAction.EditList("label", "add")
+	suite.Error(err)
+}
+
+func (suite *PRActionTestSuite) TestDoPrompt_ready() {
+	suite.prAction.Id = "PR_kwDOA"
+	suite.prAction.Results = []git.Comment{{Body: "Comment 1"}}
+	suite.mockPrompt.EXPECT().Command(gomock.Any()).Return("undraft")
+	suite.mockPrClient.EXPECT().SetDraft("PR_kwDOA", false).Return(nil)
+	suite.mockOutput.EXPECT().Println("Marked ready for review")
+
+	suite.NoError(suite.prAction.doPrompt())
+}
+
+func (suite *PRActionTestSuite) historyKey() string {
+	return history.Key(suite.prAction.Repo, suite.prAction.Repo.PRNumber)
+}
+
+func (suite *PRActionTestSuite) expectDraftSaved(key string, body string) {
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{}}, nil).Times(2)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{suite.historyKey(): {Drafts: map[string]string{key: body}}}}).Return(nil)
+}
+
+func (suite *PRActionTestSuite) expectDraftDeleted(key string, body string) {
+	suite.mockHistory.EXPECT().Load().Return(history.History{Prs: map[string]history.PR{suite.historyKey(): {Drafts: map[string]string{key: body}}}}, nil)
+	suite.mockHistory.EXPECT().Save(history.History{Prs: map[string]history.PR{suite.historyKey(): {}}}).Return(nil)
+}
+
+func (suite *PRActionTestSuite) TestReply_empty() {
+	suite.prAction.Results = []git.Comment{{
+		Body: "Comment 1",
+		Author: git.Author{
+			Login: "Mario",
+		},
+	}}
+	suite.mockOutput.EXPECT().Println("Comment is empty, nothing posted")
+	suite.prAction.Reply("   ")
+}
+
+func (suite *PRActionTestSuite) TestCommands_navigation_keys_act_on_a_single_press() {
+	for _, key := range []string{"n", "p", "r", "q"} {
+		suite.True(internal.ActsOnPress(key, commands), key)
+	}
+}
+
 func TestPrActionSuite(t *testing.T) {
 	suite.Run(t, new(PRActionTestSuite))
 }
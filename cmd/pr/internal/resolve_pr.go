@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hbk619/gh-peruse/internal"
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github"
+)
+
+// ResolvePR fills in the repo and PR number from the argument, a PR number, URL, owner/repo#number or branch name.
+// Without an argument the PR for the current branch is used, and without an owner in the argument or already in
+// repo, e.g. from --repo, the repository of the current directory is used.
+func ResolvePR(client github.PullRequestClient, repo *git.Repo, args []string, prompt internal.Prompt, output filesystem.Output) error {
+	var reference git.PRReference
+	var err error
+	if len(args) > 0 {
+		reference, err = git.ParsePRReference(args[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	if reference.Owner != "" {
+		repo.Owner = reference.Owner
+		repo.Name = reference.Name
+		if reference.Host != "" {
+			repo.Host = reference.Host
+		}
+	} else if repo.Owner == "" {
+		repoDetails, err := client.GetRepoDetails()
+		if err != nil {
+			return err
+		}
+		repo.Host = repoDetails.Host
+		repo.Owner = repoDetails.Owner
+		repo.Name = repoDetails.Name
+	}
+
+	if reference.Number != 0 {
+		repo.PRNumber = reference.Number
+		return nil
+	}
+	repo.PRNumber, err = DetectPR(client, repo, reference.Branch, prompt, output)
+	return err
+}
+
+// DetectPR finds the PR for the branch, or the current branch when branch is empty, asking which one to use when
+// the branch has several or none come from the repository the branch is pushed to
+func DetectPR(client github.PullRequestClient, repo *git.Repo, branch string, prompt internal.Prompt, output filesystem.Output) (int, error) {
+	var number int
+	var err error
+	if branch == "" {
+		number, err = client.DetectCurrentPR(repo)
+	} else {
+		number, err = client.FindPRForBranch(repo, branch)
+	}
+	var multiple *github.MultiplePRsError
+	if !errors.As(err, &multiple) {
+		return number, err
+	}
+
+	if multiple.Owner != "" {
+		_ = output.Println(fmt.Sprintf("No open pull request for %s comes from %s", multiple.Branch, multiple.Owner))
+	}
+	found := "pull requests"
+	if len(multiple.Candidates) == 1 {
+		found = "pull request"
+	}
+	_ = output.Println(fmt.Sprintf("%d %s found for %s", len(multiple.Candidates), found, multiple.Branch))
+	for i, candidate := range multiple.Candidates {
+		_ = output.Println(fmt.Sprintf("%d, %d %s, %s from %s into %s", i+1, candidate.Number, candidate.Title,
+			strings.ToLower(candidate.State), candidate.HeadOwner, candidate.BaseRefName))
+	}
+	choice, err := strconv.Atoi(strings.TrimSpace(prompt.String("Type the position of the pull request to read, anything else to cancel")))
+	if err != nil || choice < 1 || choice > len(multiple.Candidates) {
+		return 0, errors.New("no pull request chosen")
+	}
+	return multiple.Candidates[choice-1].Number, nil
+}
@@ -0,0 +1,137 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/golang/mock/gomock"
+	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github"
+	mock_github "github.com/hbk619/gh-peruse/internal/github/mocks"
+	mock_internal "github.com/hbk619/gh-peruse/internal/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResolvePRTestSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	mockOutput   *mock_filesystem.MockOutput
+	mockPrClient *mock_github.MockPullRequestClient
+	mockPrompt   *mock_internal.MockPrompt
+	repo         *git.Repo
+}
+
+func (suite *ResolvePRTestSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOutput = mock_filesystem.NewMockOutput(suite.ctrl)
+	suite.mockPrClient = mock_github.NewMockPullRequestClient(suite.ctrl)
+	suite.mockPrompt = mock_internal.NewMockPrompt(suite.ctrl)
+	suite.repo = &git.Repo{Owner: "mario", Name: "kart"}
+}
+
+func (suite *ResolvePRTestSuite) expectCandidates() {
+	suite.mockPrClient.EXPECT().DetectCurrentPR(suite.repo).Return(0, &github.MultiplePRsError{
+		Branch: "branchy",
+		Candidates: []git.PRSummary{
+			{Number: 2, Title: "Faster karts", State: "OPEN", HeadOwner: "luigi", BaseRefName: "main"},
+			{Number: 4, Title: "Faster karts again", State: "OPEN", HeadOwner: "toad", BaseRefName: "main"},
+		},
+	})
+	suite.mockOutput.EXPECT().Println("2 pull requests found for branchy")
+	suite.mockOutput.EXPECT().Println("1, 2 Faster karts, open from luigi into main")
+	suite.mockOutput.EXPECT().Println("2, 4 Faster karts again, open from toad into main")
+}
+
+func (suite *ResolvePRTestSuite) TestDetectPR_single_pr() {
+	suite.mockPrClient.EXPECT().DetectCurrentPR(suite.repo).Return(7, nil)
+
+	number, err := DetectPR(suite.mockPrClient, suite.repo, "", suite.mockPrompt, suite.mockOutput)
+	suite.NoError(err)
+	suite.Equal(7, number)
+}
+
+func (suite *ResolvePRTestSuite) TestDetectPR_other_error() {
+	suite.mockPrClient.EXPECT().DetectCurrentPR(suite.repo).Return(0, errors.New("no pull request found for branchy"))
+
+	_, err := DetectPR(suite.mockPrClient, suite.repo, "", suite.mockPrompt, suite.mockOutput)
+	suite.EqualError(err, "no pull request found for branchy")
+}
+
+func (suite *ResolvePRTestSuite) TestDetectPR_asks_which_pr() {
+	suite.expectCandidates()
+	suite.mockPrompt.EXPECT().String("Type the position of the pull request to read, anything else to cancel").Return("2")
+
+	number, err := DetectPR(suite.mockPrClient, suite.repo, "", suite.mockPrompt, suite.mockOutput)
+	suite.NoError(err)
+	suite.Equal(4, number)
+}
+
+func (suite *ResolvePRTestSuite) TestDetectPR_cancelled() {
+	suite.expectCandidates()
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("3")
+
+	_, err := DetectPR(suite.mockPrClient, suite.repo, "", suite.mockPrompt, suite.mockOutput)
+	suite.EqualError(err, "no pull request chosen")
+}
+
+func (suite *ResolvePRTestSuite) TestDetectPR_asks_about_pr_from_another_fork() {
+	suite.mockPrClient.EXPECT().DetectCurrentPR(suite.repo).Return(0, &github.MultiplePRsError{
+		Branch:     "fix",
+		Owner:      "yoshi",
+		Candidates: []git.PRSummary{{Number: 5, Title: "Fix brakes", State: "OPEN", HeadOwner: "wario", BaseRefName: "main"}},
+	})
+	gomock.InOrder(
+		suite.mockOutput.EXPECT().Println("No open pull request for fix comes from yoshi"),
+		suite.mockOutput.EXPECT().Println("1 pull request found for fix"),
+		suite.mockOutput.EXPECT().Println("1, 5 Fix brakes, open from wario into main"),
+	)
+	suite.mockPrompt.EXPECT().String(gomock.Any()).Return("")
+
+	_, err := DetectPR(suite.mockPrClient, suite.repo, "", suite.mockPrompt, suite.mockOutput)
+	suite.EqualError(err, "no pull request chosen")
+}
+
+func (suite *ResolvePRTestSuite) TestResolvePR_number_uses_current_repo() {
+	repo := &git.Repo{}
+	suite.mockPrClient.EXPECT().GetRepoDetails().Return(repository.Repository{Host: "github.com", Owner: "mario", Name: "kart"}, nil)
+
+	err := ResolvePR(suite.mockPrClient, repo, []string{"#12"}, suite.mockPrompt, suite.mockOutput)
+	suite.NoError(err)
+	suite.Equal(&git.Repo{Host: "github.com", Owner: "mario", Name: "kart", PRNumber: 12}, repo)
+}
+
+func (suite *ResolvePRTestSuite) TestResolvePR_url_from_enterprise_host() {
+	repo := &git.Repo{}
+
+	err := ResolvePR(suite.mockPrClient, repo, []string{"https://github.example.com/luigi/mansion/pull/7/files"}, suite.mockPrompt, suite.mockOutput)
+	suite.NoError(err)
+	suite.Equal(&git.Repo{Host: "github.example.com", Owner: "luigi", Name: "mansion", PRNumber: 7}, repo)
+}
+
+func (suite *ResolvePRTestSuite) TestResolvePR_shorthand_keeps_repo_host() {
+	repo := &git.Repo{Host: "github.example.com"}
+
+	err := ResolvePR(suite.mockPrClient, repo, []string{"luigi/mansion#7"}, suite.mockPrompt, suite.mockOutput)
+	suite.NoError(err)
+	suite.Equal(&git.Repo{Host: "github.example.com", Owner: "luigi", Name: "mansion", PRNumber: 7}, repo)
+}
+
+func (suite *ResolvePRTestSuite) TestResolvePR_branch_in_repo_from_flag() {
+	repo := &git.Repo{Owner: "luigi", Name: "mansion"}
+	suite.mockPrClient.EXPECT().FindPRForBranch(repo, "feature/ghosts").Return(9, nil)
+
+	err := ResolvePR(suite.mockPrClient, repo, []string{"feature/ghosts"}, suite.mockPrompt, suite.mockOutput)
+	suite.NoError(err)
+	suite.Equal(9, repo.PRNumber)
+}
+
+func (suite *ResolvePRTestSuite) TestResolvePR_invalid_argument() {
+	err := ResolvePR(suite.mockPrClient, &git.Repo{}, []string{"not a branch"}, suite.mockPrompt, suite.mockOutput)
+	suite.EqualError(err, "please provide a valid PR number, URL, owner/repo#number or branch name")
+}
+
+func TestResolvePRTestSuite(t *testing.T) {
+	suite.Run(t, new(ResolvePRTestSuite))
+}
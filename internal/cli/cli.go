@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/hbk619/gh-peruse/internal"
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/speech"
+	"github.com/spf13/cobra"
+)
+
+// AddBrowseFlags adds the flags shared by every command that reads comments one at a time
+func AddBrowseFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("commands", "c", "", "Run commands separated by ; instead of asking, e.g. \"n;n;done;c LGTM;q\", use - to read them from stdin")
+	cmd.Flags().Bool("raw", false, "Print comments as raw Markdown instead of reading out code blocks, links and tables")
+	cmd.Flags().Bool("urls", false, "Read out the address of links after their text")
+	cmd.Flags().BoolP("speak", "s", false, "Read everything aloud with speech-dispatcher on Linux, say on MacOS or the Windows speech synthesizer")
+	cmd.Flags().BoolP("keys", "k", false, "Single key mode, n, p, r and q act without pressing enter, arrow keys navigate and typed lines can be edited and recalled")
+	AddRepoFlag(cmd)
+}
+
+// AddVerbosityFlag adds --verbosity, fields lists what the command can read of each comment
+func AddVerbosityFlag(cmd *cobra.Command, fields string) {
+	cmd.Flags().String("verbosity", "normal", "How much of each comment is read, terse, normal, verbose or the fields to read in order from "+fields)
+}
+
+// Settings are what the flags added by AddBrowseFlags and AddVerbosityFlag say about how to browse
+type Settings struct {
+	Output filesystem.Output
+	Prompt internal.Prompt
+	// Confirm is false when commands come from --commands as a script cannot answer questions
+	Confirm   bool
+	Raw       bool
+	ReadURLs  bool
+	Verbosity internal.Verbosity
+}
+
+// Browse reads the settings from the flags and runs browse with them. Everything is spoken before exiting with
+// status 1 when anything fails.
+func Browse(cmd *cobra.Command, browse func(settings Settings) error) {
+	settings, speaker, err := readSettings(cmd)
+	if err == nil {
+		err = browse(settings)
+	}
+	if speaker != nil {
+		_ = speaker.Flush()
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func readSettings(cmd *cobra.Command) (Settings, *speech.Speaker, error) {
+	settings := Settings{Verbosity: internal.Normal}
+	output, speaker, err := NewOutput(cmd)
+	if err != nil {
+		return settings, nil, err
+	}
+	settings.Output = output
+	prompt, scripted, err := NewPrompt(cmd, output)
+	if err != nil {
+		return settings, speaker, err
+	}
+	settings.Prompt = prompt
+	settings.Confirm = !scripted
+	settings.Raw, err = cmd.Flags().GetBool("raw")
+	if err != nil {
+		return settings, speaker, err
+	}
+	settings.ReadURLs, err = cmd.Flags().GetBool("urls")
+	if err != nil {
+		return settings, speaker, err
+	}
+	if cmd.Flags().Lookup("verbosity") == nil {
+		return settings, speaker, nil
+	}
+	verbosity, err := cmd.Flags().GetString("verbosity")
+	if err != nil {
+		return settings, speaker, err
+	}
+	settings.Verbosity, err = internal.ParseVerbosity(verbosity)
+	return settings, speaker, err
+}
+
+func AddRepoFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("repo", "R", "", "Use the repository [HOST/]OWNER/REPO instead of the one in the current directory")
+}
+
+// RepoFlag reads --repo, the owner is left empty when it is not given so the repository of the current directory is used
+func RepoFlag(cmd *cobra.Command) (*git.Repo, error) {
+	repo := &git.Repo{}
+	value, err := cmd.Flags().GetString("repo")
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return repo, nil
+	}
+	parsed, err := repository.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	repo.Host = parsed.Host
+	repo.Owner = parsed.Owner
+	repo.Name = parsed.Name
+	return repo, nil
+}
+
+// NewClients creates the GraphQL and REST clients for the host, or for the default host when none is given
+func NewClients(host string) (*api.GraphQLClient, *api.RESTClient, error) {
+	if host == "" {
+		graphQlClient, err := api.DefaultGraphQLClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		restClient, err := api.DefaultRESTClient()
+		return graphQlClient, restClient, err
+	}
+	options := api.ClientOptions{Host: host}
+	graphQlClient, err := api.NewGraphQLClient(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	restClient, err := api.NewRESTClient(options)
+	return graphQlClient, restClient, err
+}
+
+// NewOutput prints to stdout and, with --speak, reads everything aloud too. The speaker is nil unless speaking
+// and an error is returned when there is nothing to speak with.
+func NewOutput(cmd *cobra.Command) (filesystem.Output, *speech.Speaker, error) {
+	var output filesystem.Output = filesystem.NewStdOut()
+	speak, err := cmd.Flags().GetBool("speak")
+	if err != nil {
+		return nil, nil, err
+	}
+	if !speak {
+		return output, nil, nil
+	}
+	speaker := speech.NewSpeaker(output)
+	err = speaker.Check()
+	if err != nil {
+		return nil, nil, err
+	}
+	return speaker, speaker, nil
+}
+
+// NewPrompt picks where commands come from, a script given with --commands, single key presses or whole lines.
+// Scripted commands are not asked to confirm anything.
+func NewPrompt(cmd *cobra.Command, output filesystem.Output) (internal.Prompt, bool, error) {
+	commands, err := cmd.Flags().GetString("commands")
+	if err != nil {
+		return nil, false, err
+	}
+	if commands == "-" {
+		script, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read commands %w", err)
+		}
+		commands = string(script)
+	}
+	if commands != "" {
+		return internal.NewScriptPrompt(commands, output), true, nil
+	}
+
+	keys, err := cmd.Flags().GetBool("keys")
+	if err != nil {
+		return nil, false, err
+	}
+	if keys && internal.IsTerminal(os.Stdin) {
+		return internal.NewKeyPrompt(os.Stdin, os.Stdout, output), false, nil
+	}
+	return internal.NewPrompt(os.Stdin, output), false, nil
+}
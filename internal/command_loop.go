@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CommandLoop runs commands one at a time until Stop is called, counting the ones that failed
+type CommandLoop struct {
+	stopped  bool
+	failures int
+}
+
+// Stop ends the loop once the current command has finished
+func (loop *CommandLoop) Stop() {
+	loop.stopped = true
+}
+
+// RunLoop calls step until Stop is called, it returns an error if any step failed
+func (loop *CommandLoop) RunLoop(step func() error) error {
+	for !loop.stopped {
+		if err := step(); err != nil {
+			loop.failures++
+		}
+	}
+	switch loop.failures {
+	case 0:
+		return nil
+	case 1:
+		return errors.New("1 command failed")
+	default:
+		return fmt.Errorf("%d commands failed", loop.failures)
+	}
+}
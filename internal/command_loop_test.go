@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandLoopTestSuite struct {
+	suite.Suite
+	loop *CommandLoop
+}
+
+func (suite *CommandLoopTestSuite) BeforeTest(string, string) {
+	suite.loop = &CommandLoop{}
+}
+
+func (suite *CommandLoopTestSuite) TestRunLoop_runs_until_stopped() {
+	steps := 0
+	err := suite.loop.RunLoop(func() error {
+		steps++
+		if steps == 3 {
+			suite.loop.Stop()
+		}
+		return nil
+	})
+
+	suite.NoError(err)
+	suite.Equal(3, steps)
+}
+
+func (suite *CommandLoopTestSuite) TestRunLoop_counts_failures() {
+	results := []error{errors.New("bad"), nil, errors.New("worse")}
+	steps := 0
+	err := suite.loop.RunLoop(func() error {
+		result := results[steps]
+		steps++
+		if steps == len(results) {
+			suite.loop.Stop()
+		}
+		return result
+	})
+
+	suite.EqualError(err, "2 commands failed")
+}
+
+func (suite *CommandLoopTestSuite) TestRunLoop_one_failure() {
+	err := suite.loop.RunLoop(func() error {
+		suite.loop.Stop()
+		return errors.New("bad")
+	})
+
+	suite.EqualError(err, "1 command failed")
+}
+
+func TestCommandLoopSuite(t *testing.T) {
+	suite.Run(t, new(CommandLoopTestSuite))
+}
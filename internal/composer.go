@@ -0,0 +1,240 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/history"
+	internal_os "github.com/hbk619/gh-peruse/internal/os"
+)
+
+const EndOfComment = "."
+
+type (
+	Composer struct {
+		prompt   Prompt
+		editor   internal_os.TextEditor
+		output   filesystem.Output
+		mentions *Mentions
+	}
+
+	// Draft is where an unsent comment is kept, Item is the history.Key of the PR, issue or discussion and Key the
+	// conversation in it. Nothing is kept without Storage.
+	Draft struct {
+		Storage history.Storage
+		Item    string
+		Key     string
+	}
+)
+
+func NewComposer(prompt Prompt, editor internal_os.TextEditor, output filesystem.Output) *Composer {
+	return &Composer{
+		prompt:   prompt,
+		editor:   editor,
+		output:   output,
+		mentions: NewMentions(nil),
+	}
+}
+
+// SetParticipants sets the logins that partially typed @mentions are completed from
+func (composer *Composer) SetParticipants(logins []string) {
+	composer.mentions = NewMentions(logins)
+	if completer, ok := composer.prompt.(Completer); ok {
+		completer.SetCompletion(composer.mentions.Complete)
+	}
+}
+
+func (composer *Composer) Line() string {
+	return composer.expandMentions(composer.prompt.String("Type comment and press enter"))
+}
+
+func (composer *Composer) MultiLine() string {
+	label := fmt.Sprintf("Type comment, then a line with just %s to finish", EndOfComment)
+	return composer.expandMentions(composer.prompt.Lines(label, EndOfComment))
+}
+
+// QuoteReply quotes a comment and mentions its author before the typed reply
+func (composer *Composer) QuoteReply(body string, author string) string {
+	reply := composer.prompt.String("Type reply to the quote and press enter")
+	if strings.TrimSpace(reply) == "" {
+		return ""
+	}
+	mention := ""
+	if author != "" {
+		mention = "@" + author + " "
+	}
+	return Quote(body) + mention + composer.expandMentions(reply)
+}
+
+func (composer *Composer) expandMentions(text string) string {
+	expanded, ambiguous := composer.mentions.Expand(text)
+	partials := make([]string, 0, len(ambiguous))
+	for partial := range ambiguous {
+		partials = append(partials, partial)
+	}
+	slices.Sort(partials)
+	for _, partial := range partials {
+		message := fmt.Sprintf("@%s could be %s", partial, strings.Join(ambiguous[partial], " or "))
+		_ = composer.output.Println(message)
+	}
+	return expanded
+}
+
+// Editor opens the user's editor pre-filled with the given text, an unchanged or empty file gives an empty comment
+func (composer *Composer) Editor(prefill string) string {
+	contents, err := composer.editor.Edit(prefill)
+	if err != nil {
+		_ = composer.output.Println(fmt.Sprintf("Warning failed to open editor: %s", err.Error()))
+		return ""
+	}
+	contents = strings.TrimSpace(contents)
+	if contents == strings.TrimSpace(prefill) {
+		return ""
+	}
+	return contents
+}
+
+// Revise opens the user's editor with an existing draft so it can be changed
+func (composer *Composer) Revise(draft string) string {
+	contents, err := composer.editor.Edit(draft)
+	if err != nil {
+		_ = composer.output.Println(fmt.Sprintf("Warning failed to open editor: %s", err.Error()))
+		return draft
+	}
+	return strings.TrimSpace(contents)
+}
+
+// Review reads the draft back and asks whether to post, edit or cancel it.
+// keep is called with each version of the draft so it can be stored until it is sent.
+func (composer *Composer) Review(draft string, write func() string, keep func(string)) (string, bool) {
+	for {
+		if strings.TrimSpace(draft) == "" {
+			_ = composer.output.Println("Comment is empty, nothing posted")
+			return "", false
+		}
+		keep(draft)
+		_ = composer.output.Println("Your comment:")
+		_ = composer.output.Println(draft)
+		switch composer.prompt.String("p to post, e to edit in your editor, w to write it again or c to cancel") {
+		case "p":
+			return draft, true
+		case "e":
+			draft = composer.Revise(draft)
+		case "w":
+			draft = write()
+		case "c":
+			_ = composer.output.Println("Comment cancelled")
+			return "", false
+		default:
+			_ = composer.output.Println("Invalid choice")
+		}
+	}
+}
+
+// Writer gives how the compose commands write a comment. c posts the text typed after it or asks for one line, cm asks
+// for several lines, ce opens the editor with the current comment quoted and cq quotes it and mentions its author
+// unless that is the viewer. It returns false for any other command.
+func (composer *Composer) Writer(command string, text string, current git.Comment, viewer string) (func() string, bool) {
+	switch command {
+	case "c":
+		if text != "" {
+			return func() string {
+				return text
+			}, true
+		}
+		return composer.Line, true
+	case "cm":
+		return composer.MultiLine, true
+	case "ce":
+		return func() string {
+			return composer.Editor(Quote(current.Body))
+		}, true
+	case "cq":
+		author := current.Author.Login
+		if author == viewer {
+			author = ""
+		}
+		return func() string {
+			return composer.QuoteReply(current.Body, author)
+		}, true
+	}
+	return nil, false
+}
+
+// Compose writes a comment, reads it back for confirmation and then sends it with post. An unsent draft is offered
+// first and every version is kept until it is posted or cancelled. Without confirm the comment is sent as written.
+func (composer *Composer) Compose(write func() string, confirm bool, draft Draft, post func(string) error) error {
+	if !confirm {
+		return post(write())
+	}
+	body := composer.loadDraft(draft)
+	if body != "" {
+		_ = composer.output.Println("You have an unsent draft")
+		_ = composer.output.Println(body)
+		if composer.prompt.String("y to continue with it or n to start again") != "y" {
+			body = ""
+		}
+	}
+	if body == "" {
+		body = write()
+	}
+
+	body, send := composer.Review(body, write, func(body string) {
+		composer.saveDraft(draft, body)
+	})
+	if !send {
+		composer.saveDraft(draft, "")
+		return nil
+	}
+	err := post(body)
+	if err == nil {
+		composer.saveDraft(draft, "")
+	}
+	return err
+}
+
+func (composer *Composer) loadDraft(draft Draft) string {
+	if draft.Storage == nil {
+		return ""
+	}
+	saved, err := draft.Storage.Load()
+	if err != nil {
+		_ = composer.output.Println(fmt.Sprintf("Warning failed to load drafts from history: %s", err.Error()))
+		return ""
+	}
+	return saved.Draft(draft.Item, draft.Key)
+}
+
+func (composer *Composer) saveDraft(draft Draft, body string) {
+	if draft.Storage == nil {
+		return
+	}
+	saved, err := draft.Storage.Load()
+	if err != nil {
+		_ = composer.output.Println(fmt.Sprintf("Warning failed to load drafts from history: %s", err.Error()))
+		return
+	}
+	if saved.Draft(draft.Item, draft.Key) == body {
+		return
+	}
+	saved.SetDraft(draft.Item, draft.Key, body)
+	err = draft.Storage.Save(saved)
+	if err != nil {
+		_ = composer.output.Println(fmt.Sprintf("Warning failed to save draft to history: %s", err.Error()))
+	}
+}
+
+// Quote turns text into a Markdown block quote followed by a blank line
+func Quote(text string) string {
+	if strings.TrimSpace(text) == "" {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("> "+line, " ")
+	}
+	return strings.Join(lines, "\n") + "\n\n"
+}
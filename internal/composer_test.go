@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
+	"github.com/hbk619/gh-peruse/internal/git"
+	mock_internal "github.com/hbk619/gh-peruse/internal/mocks"
+	mock_os "github.com/hbk619/gh-peruse/internal/os/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type ComposerTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	mockPrompt *mock_internal.MockPrompt
+	mockEditor *mock_os.MockTextEditor
+	mockOutput *mock_filesystem.MockOutput
+	composer   *Composer
+}
+
+func (suite *ComposerTestSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockPrompt = mock_internal.NewMockPrompt(suite.ctrl)
+	suite.mockEditor = mock_os.NewMockTextEditor(suite.ctrl)
+	suite.mockOutput = mock_filesystem.NewMockOutput(suite.ctrl)
+	suite.composer = NewComposer(suite.mockPrompt, suite.mockEditor, suite.mockOutput)
+}
+
+func (suite *ComposerTestSuite) TestEditor_returns_trimmed_contents() {
+	suite.mockEditor.EXPECT().Edit("").Return("\nLooks good\n\n", nil)
+	suite.Equal("Looks good", suite.composer.Editor(""))
+}
+
+func (suite *ComposerTestSuite) TestEditor_empty_file_gives_empty_comment() {
+	suite.mockEditor.EXPECT().Edit("> hi\n\n").Return("", nil)
+	suite.Equal("", suite.composer.Editor("> hi\n\n"))
+}
+
+func (suite *ComposerTestSuite) TestEditor_error_gives_empty_comment() {
+	suite.mockEditor.EXPECT().Edit("").Return("", errors.New("no editor"))
+	suite.mockOutput.EXPECT().Println("Warning failed to open editor: no editor")
+	suite.Equal("", suite.composer.Editor(""))
+}
+
+func (suite *ComposerTestSuite) TestLine_completes_mentions() {
+	suite.composer.SetParticipants([]string{"mario", "mariah", "peach"})
+	suite.mockPrompt.EXPECT().String("Type comment and press enter").Return("@pe and @mar look")
+	suite.mockOutput.EXPECT().Println("@mar could be mariah or mario")
+	suite.Equal("@peach and @mar look", suite.composer.Line())
+}
+
+func (suite *ComposerTestSuite) TestQuoteReply_quotes_and_mentions_author() {
+	suite.mockPrompt.EXPECT().String("Type reply to the quote and press enter").Return("Done")
+	suite.Equal("> Rename this\n\n@bowser Done", suite.composer.QuoteReply("Rename this", "bowser"))
+}
+
+func (suite *ComposerTestSuite) TestQuoteReply_empty_reply() {
+	suite.mockPrompt.EXPECT().String("Type reply to the quote and press enter").Return("")
+	suite.Equal("", suite.composer.QuoteReply("Rename this", "bowser"))
+}
+
+func (suite *ComposerTestSuite) TestQuote_quotes_each_line() {
+	suite.Equal("> first\n>\n> second\n\n", Quote("first\n\nsecond\n"))
+}
+
+func (suite *ComposerTestSuite) TestQuote_empty() {
+	suite.Equal("", Quote("  "))
+}
+
+func (suite *ComposerTestSuite) TestWriter_uses_text_after_c() {
+	write, ok := suite.composer.Writer("c", "LGTM", git.Comment{}, "mario")
+	suite.True(ok)
+	suite.Equal("LGTM", write())
+}
+
+func (suite *ComposerTestSuite) TestWriter_quote_does_not_mention_viewer() {
+	suite.mockPrompt.EXPECT().String("Type reply to the quote and press enter").Return("Done").Times(2)
+	current := git.Comment{Body: "Rename this", Author: git.Author{Login: "mario"}}
+
+	write, ok := suite.composer.Writer("cq", "", current, "mario")
+	suite.True(ok)
+	suite.Equal("> Rename this\n\nDone", write())
+
+	write, _ = suite.composer.Writer("cq", "", current, "luigi")
+	suite.Equal("> Rename this\n\n@mario Done", write())
+}
+
+func (suite *ComposerTestSuite) TestWriter_other_commands() {
+	_, ok := suite.composer.Writer("emoji", "", git.Comment{}, "mario")
+	suite.False(ok)
+}
+
+func (suite *ComposerTestSuite) TestCompose_without_storage_keeps_no_draft() {
+	suite.mockOutput.EXPECT().Println("Your comment:")
+	suite.mockOutput.EXPECT().Println("LGTM")
+	suite.mockPrompt.EXPECT().String("p to post, e to edit in your editor, w to write it again or c to cancel").Return("p")
+
+	var posted string
+	err := suite.composer.Compose(func() string { return "LGTM" }, true, Draft{}, func(body string) error {
+		posted = body
+		return nil
+	})
+	suite.NoError(err)
+	suite.Equal("LGTM", posted)
+}
+
+func (suite *ComposerTestSuite) TestCompose_without_confirm_posts_as_written() {
+	err := suite.composer.Compose(func() string { return "LGTM" }, false, Draft{}, func(body string) error {
+		return errors.New("offline")
+	})
+	suite.EqualError(err, "offline")
+}
+
+func TestComposerSuite(t *testing.T) {
+	suite.Run(t, new(ComposerTestSuite))
+}
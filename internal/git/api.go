@@ -4,6 +4,21 @@ import (
 	"time"
 )
 
+type CommentKind string
+
+const (
+	PullRequestKind   CommentKind = "pull request"
+	IssueCommentKind  CommentKind = "issue comment"
+	ReviewKind        CommentKind = "review"
+	ReviewCommentKind CommentKind = "review comment"
+	CommitCommentKind CommentKind = "commit comment"
+	EventKind         CommentKind = "event"
+	IssueKind         CommentKind = "issue"
+	DiscussionKind    CommentKind = "discussion"
+	// DiscussionCommentKind is a top level comment or reply in a discussion, replies have a Thread.ID of the comment they reply to
+	DiscussionCommentKind CommentKind = "discussion comment"
+)
+
 type (
 	Error struct {
 		Message string
@@ -16,8 +31,9 @@ type (
 	}
 
 	Commit struct {
-		Comments Comments
-		Oid      string
+		Comments          Comments
+		Oid               string
+		StatusCheckRollup StatusCheckRollup
 	}
 
 	CommitNode struct {
@@ -25,21 +41,73 @@ type (
 	}
 
 	Repo struct {
+		Host     string
 		Owner    string
 		Name     string
 		PRNumber int
 	}
 
+	// PRFilter chooses which open PRs to list, Scope is mine, review or all
+	PRFilter struct {
+		Scope  string
+		Label  string
+		Author string
+		Base   string
+	}
+
+	// PRSummary is what is read out about a PR when listing them
+	PRSummary struct {
+		Number            int
+		Title             string
+		Author            string
+		IsDraft           bool
+		BaseRefName       string
+		UnresolvedThreads int
+		CheckState        string
+		State             string
+		HeadOwner         string
+	}
+
 	PR struct {
 		Comments []Comment
 		State    State
 		Title    string
 		Id       string
+		Viewer   string
+		HeadOid  string
+		URL      string
 	}
 
+	// Status is a check run or, when Context is set, a commit status
 	Status struct {
-		Name       string
-		Conclusion string
+		Name        string
+		Conclusion  string
+		Status      string
+		DatabaseId  int
+		DetailsUrl  string
+		Title       string
+		Summary     string
+		Annotations Annotations
+		Context     string
+		State       string
+		Description string
+		TargetUrl   string
+	}
+	Annotations struct {
+		Nodes []Annotation
+	}
+	Annotation struct {
+		Path            string
+		Location        AnnotationLocation
+		Message         string
+		Title           string
+		AnnotationLevel string
+	}
+	AnnotationLocation struct {
+		Start AnnotationPosition
+	}
+	AnnotationPosition struct {
+		Line int
 	}
 	Thread struct {
 		IsResolved bool
@@ -64,14 +132,28 @@ type (
 		State          string
 		Outdated       bool
 		Thread         Thread
-		Id             interface{}
+		Id             string
+		Kind           CommentKind
 		MergeStatus    string
 		ConflictStatus string
 		Reviews        []string
 		Statuses       []Status
+		Reactions      []Reaction `json:"reactionGroups"`
+		IsAnswer       bool
+		Replies        Comments
+		URL            string
+	}
+	Reaction struct {
+		Content          string
+		Users            ReactionUsers
+		ViewerHasReacted bool
+	}
+	ReactionUsers struct {
+		TotalCount int
 	}
 	Author struct {
 		Login string
+		Id    string
 	}
 	ReviewThreads struct {
 		Nodes []ThreadNode
@@ -84,12 +166,105 @@ type (
 	}
 
 	Comments struct {
-		Nodes []Comment
+		PageInfo PageInfo
+		Nodes    []Comment
+	}
+
+	PageInfo struct {
+		HasNextPage bool
+		EndCursor   string
 	}
 
 	Repository struct {
-		PullRequest  PullRequest
-		PullRequests PullRequests
+		Id                   string
+		PullRequest          PullRequest
+		PullRequests         PullRequests
+		Issue                Issue
+		Discussion           Discussion
+		Label                Label
+		DefaultBranchRef     Ref
+		PullRequestTemplates []PRTemplate
+	}
+
+	PRTemplate struct {
+		Filename string
+		Body     string
+	}
+
+	// NewPR is what a PR is created from, NewPR on the client fills in defaults from the repo and current branch.
+	// Head is the branch, or owner:branch when it was pushed to a fork.
+	NewPR struct {
+		RepositoryId string
+		Base         string
+		Head         string
+		Branch       string
+		Pushed       bool
+		Template     string
+		Title        string
+		Body         string
+		Draft        bool
+		Reviewers    []string
+		Labels       []string
+	}
+
+	CreatePRResponse struct {
+		CreatePullRequest CreatePullRequest
+	}
+
+	CreatePullRequest struct {
+		PullRequest PullRequest
+	}
+
+	RepliesResponse struct {
+		Node Comment
+	}
+
+	Discussion struct {
+		Id        string
+		Number    int
+		Title     string
+		Body      string
+		Author    Author
+		CreatedAt time.Time
+		Category  DiscussionCategory
+		Reactions []Reaction `json:"reactionGroups"`
+		Comments  Comments
+	}
+
+	DiscussionCategory struct {
+		Name         string
+		IsAnswerable bool
+	}
+
+	// DiscussionDetails is a discussion ready to read, the body followed by each comment and then its replies
+	DiscussionDetails struct {
+		Comments   []Comment
+		Title      string
+		Id         string
+		Viewer     string
+		Category   string
+		Answerable bool
+	}
+
+	Issue struct {
+		Id        string
+		Number    int
+		Title     string
+		Body      string
+		State     string
+		Author    Author
+		CreatedAt time.Time
+		Reactions []Reaction `json:"reactionGroups"`
+		Comments  Comments
+	}
+
+	// IssueDetails is an issue ready to read, the body followed by every comment in the order they were written
+	IssueDetails struct {
+		Comments []Comment
+		Title    string
+		Id       string
+		Viewer   string
+		Closed   bool
 	}
 
 	PullRequests struct {
@@ -116,23 +291,129 @@ type (
 		Nodes []CommitNode
 	}
 	PullRequest struct {
-		ReviewThreads     ReviewThreads
-		Title             string
-		Body              string
-		Author            Author
+		ReviewThreads       ReviewThreads
+		Title               string
+		Body                string
+		Author              Author
+		CreatedAt           time.Time
+		StatusCheckRollup   StatusCheckRollup
+		Mergeable           string
+		MergeStateStatus    string
+		Comments            Comments
+		Reviews             Reviews
+		Commits             Commits
+		Id                  string
+		Number              int
+		Reactions           []Reaction `json:"reactionGroups"`
+		TimelineItems       TimelineItems
+		HeadRef             Ref
+		IsDraft             bool
+		BaseRefName         string
+		HeadRefName         string
+		State               string
+		HeadRepositoryOwner Author
+		HeadRefOid          string
+		IsCrossRepository   bool
+		URL                 string
+		Labels              Labels
+		Assignees           Assignees
+		ReviewRequests      ReviewRequests
+	}
+
+	Labels struct {
+		Nodes []Label
+	}
+
+	Assignees struct {
+		Nodes []Author
+	}
+
+	ReviewRequests struct {
+		Nodes []ReviewRequest
+	}
+
+	ReviewRequest struct {
+		RequestedReviewer Reviewer
+	}
+
+	// PRMetadata is what can be changed about a PR apart from its comments, reviewers are logins or org/team
+	PRMetadata struct {
+		Id        string
+		Title     string
+		Body      string
+		IsDraft   bool
+		Labels    []string
+		Reviewers []string
+		Assignees []string
+	}
+
+	Ref struct {
+		Id   string
+		Name string
+	}
+
+	// MergeState says whether a PR can be merged and which branch it would merge from
+	MergeState struct {
+		MergeStateStatus string
+		MergeStatus      string
+		ConflictStatus   string
+		HeadRef          Ref
+	}
+
+	TimelineItems struct {
+		PageInfo PageInfo
+		Nodes    []TimelineItem
+	}
+
+	// TimelineItem holds the fields of every kind of event in a PR's timeline, Typename says which are set
+	TimelineItem struct {
+		Typename          string `json:"__typename"`
 		CreatedAt         time.Time
-		StatusCheckRollup StatusCheckRollup
-		Mergeable         string
-		MergeStateStatus  string
-		Comments          Comments
-		Reviews           Reviews
-		Commits           Commits
-		Id                string
-		Number            int
+		Actor             Author
+		Commit            TimelineCommit
+		BeforeCommit      TimelineCommit
+		AfterCommit       TimelineCommit
+		Label             Label
+		RequestedReviewer Reviewer
+		MergeRefName      string
+	}
+
+	TimelineCommit struct {
+		AbbreviatedOid  string
+		MessageHeadline string
+		CommittedDate   time.Time
+		Author          CommitAuthor
+		URL             string
+	}
+
+	CommitAuthor struct {
+		Name string
+		User Author
+	}
+
+	Label struct {
+		Id   string
+		Name string
+	}
+
+	// Reviewer is a user, or a team when Slug is set
+	Reviewer struct {
+		Id           string
+		Login        string
+		Name         string
+		Slug         string
+		Organization Author
+	}
+
+	Organization struct {
+		Team Reviewer
 	}
 
 	GitHubData struct {
-		Repository Repository
+		Repository   Repository
+		Viewer       Author
+		User         Author
+		Organization Organization
 	}
 
 	GithubPREdge struct {
@@ -140,6 +421,7 @@ type (
 	}
 	GithubSearch struct {
 		Edges []GithubPREdge
+		Nodes []PullRequest
 	}
 	GithubQuery struct {
 		Search GithubSearch
@@ -0,0 +1,80 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReactionNames maps what can be typed to react to the GraphQL reaction content
+var ReactionNames = []struct {
+	Input   string
+	Content string
+	Spoken  string
+}{
+	{"+1", "THUMBS_UP", "thumbs up"},
+	{"-1", "THUMBS_DOWN", "thumbs down"},
+	{"laugh", "LAUGH", "laugh"},
+	{"hooray", "HOORAY", "hooray"},
+	{"confused", "CONFUSED", "confused"},
+	{"heart", "HEART", "heart"},
+	{"rocket", "ROCKET", "rocket"},
+	{"eyes", "EYES", "eyes"},
+}
+
+func ReactionContent(input string) (string, error) {
+	for _, name := range ReactionNames {
+		if strings.EqualFold(input, name.Input) || strings.EqualFold(input, name.Content) {
+			return name.Content, nil
+		}
+	}
+	return "", fmt.Errorf("unknown reaction %s", input)
+}
+
+func SpokenReaction(content string) string {
+	for _, name := range ReactionNames {
+		if name.Content == content {
+			return name.Spoken
+		}
+	}
+	return strings.ToLower(strings.ReplaceAll(content, "_", " "))
+}
+
+// ReactionSummary describes the reactions to a comment e.g. "3 thumbs up, 1 eyes"
+func (comment *Comment) ReactionSummary() string {
+	var summary []string
+	for _, reaction := range comment.Reactions {
+		if reaction.Users.TotalCount > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", reaction.Users.TotalCount, SpokenReaction(reaction.Content)))
+		}
+	}
+	return strings.Join(summary, ", ")
+}
+
+func (comment *Comment) HasReacted(content string) bool {
+	for _, reaction := range comment.Reactions {
+		if reaction.Content == content {
+			return reaction.ViewerHasReacted
+		}
+	}
+	return false
+}
+
+// ToggleReaction records locally that the viewer has added or removed a reaction
+func (comment *Comment) ToggleReaction(content string) {
+	for i, reaction := range comment.Reactions {
+		if reaction.Content == content {
+			if reaction.ViewerHasReacted {
+				comment.Reactions[i].Users.TotalCount--
+			} else {
+				comment.Reactions[i].Users.TotalCount++
+			}
+			comment.Reactions[i].ViewerHasReacted = !reaction.ViewerHasReacted
+			return
+		}
+	}
+	comment.Reactions = append(comment.Reactions, Reaction{
+		Content:          content,
+		Users:            ReactionUsers{TotalCount: 1},
+		ViewerHasReacted: true,
+	})
+}
@@ -0,0 +1,52 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReactionsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ReactionsTestSuite) TestReactionContent() {
+	content, err := ReactionContent("+1")
+	suite.NoError(err)
+	suite.Equal("THUMBS_UP", content)
+
+	content, err = ReactionContent("Rocket")
+	suite.NoError(err)
+	suite.Equal("ROCKET", content)
+}
+
+func (suite *ReactionsTestSuite) TestReactionContent_unknown() {
+	_, err := ReactionContent("clap")
+	suite.ErrorContains(err, "unknown reaction clap")
+}
+
+func (suite *ReactionsTestSuite) TestReactionSummary_skips_empty_reactions() {
+	comment := Comment{Reactions: []Reaction{
+		{Content: "THUMBS_UP", Users: ReactionUsers{TotalCount: 3}},
+		{Content: "LAUGH", Users: ReactionUsers{TotalCount: 0}},
+		{Content: "EYES", Users: ReactionUsers{TotalCount: 1}},
+	}}
+	suite.Equal("3 thumbs up, 1 eyes", comment.ReactionSummary())
+}
+
+func (suite *ReactionsTestSuite) TestToggleReaction() {
+	comment := Comment{Reactions: []Reaction{
+		{Content: "HEART", Users: ReactionUsers{TotalCount: 2}, ViewerHasReacted: true},
+	}}
+	comment.ToggleReaction("HEART")
+	suite.Equal("1 heart", comment.ReactionSummary())
+	suite.False(comment.HasReacted("HEART"))
+
+	comment.ToggleReaction("ROCKET")
+	suite.Equal("1 heart, 1 rocket", comment.ReactionSummary())
+	suite.True(comment.HasReacted("ROCKET"))
+}
+
+func TestReactionsSuite(t *testing.T) {
+	suite.Run(t, new(ReactionsTestSuite))
+}
@@ -0,0 +1,63 @@
+package git
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	shorthand    = regexp.MustCompile(`^([\w.-]+)/([\w.-]+)#(\d+)$`)
+	number       = regexp.MustCompile(`^#?(\d+)$`)
+	invalidRef   = regexp.MustCompile(`\s|\.\.|[~^:?*\[\\]|^-|^/|/$|\.lock$`)
+	errInvalidPR = errors.New("please provide a valid PR number, URL, owner/repo#number or branch name")
+)
+
+// PRReference is what a PR argument points to, any of the fields may be empty
+type PRReference struct {
+	Host   string
+	Owner  string
+	Name   string
+	Number int
+	Branch string
+}
+
+// ParsePRReference reads a PR number, a PR URL from github.com or GitHub Enterprise, owner/repo#number or a branch name
+func ParsePRReference(value string) (PRReference, error) {
+	value = strings.TrimSpace(value)
+	if match := number.FindStringSubmatch(value); match != nil {
+		prNumber, _ := strconv.Atoi(match[1])
+		return PRReference{Number: prNumber}, nil
+	}
+	if match := shorthand.FindStringSubmatch(value); match != nil {
+		prNumber, _ := strconv.Atoi(match[3])
+		return PRReference{Owner: match[1], Name: match[2], Number: prNumber}, nil
+	}
+	if strings.HasPrefix(value, "https://") || strings.HasPrefix(value, "http://") {
+		return parsePRURL(value)
+	}
+	if value == "" || invalidRef.MatchString(value) {
+		return PRReference{}, errInvalidPR
+	}
+	return PRReference{Branch: value}, nil
+}
+
+// parsePRURL reads links like https://github.com/owner/repo/pull/12/files#r123
+func parsePRURL(value string) (PRReference, error) {
+	link, err := url.Parse(value)
+	if err != nil {
+		return PRReference{}, errInvalidPR
+	}
+	parts := strings.Split(strings.Trim(link.Path, "/"), "/")
+	if len(parts) < 4 || parts[2] != "pull" {
+		return PRReference{}, errInvalidPR
+	}
+	prNumber, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return PRReference{}, errInvalidPR
+	}
+	host := strings.TrimPrefix(strings.ToLower(link.Hostname()), "www.")
+	return PRReference{Host: host, Owner: parts[0], Name: strings.TrimSuffix(parts[1], ".git"), Number: prNumber}, nil
+}
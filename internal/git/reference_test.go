@@ -0,0 +1,56 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReferenceTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ReferenceTestSuite) TestParsePRReference_number() {
+	reference, err := ParsePRReference("#12")
+	suite.NoError(err)
+	suite.Equal(PRReference{Number: 12}, reference)
+
+	reference, err = ParsePRReference("34")
+	suite.NoError(err)
+	suite.Equal(PRReference{Number: 34}, reference)
+}
+
+func (suite *ReferenceTestSuite) TestParsePRReference_shorthand() {
+	reference, err := ParsePRReference("hbk619/gh-peruse#7")
+	suite.NoError(err)
+	suite.Equal(PRReference{Owner: "hbk619", Name: "gh-peruse", Number: 7}, reference)
+}
+
+func (suite *ReferenceTestSuite) TestParsePRReference_url() {
+	reference, err := ParsePRReference("https://www.github.com/hbk619/gh-peruse/pull/7/files#r123")
+	suite.NoError(err)
+	suite.Equal(PRReference{Host: "github.com", Owner: "hbk619", Name: "gh-peruse", Number: 7}, reference)
+}
+
+func (suite *ReferenceTestSuite) TestParsePRReference_enterprise_url() {
+	reference, err := ParsePRReference("https://GitHub.Example.com/platform/api/pull/301")
+	suite.NoError(err)
+	suite.Equal(PRReference{Host: "github.example.com", Owner: "platform", Name: "api", Number: 301}, reference)
+}
+
+func (suite *ReferenceTestSuite) TestParsePRReference_branch() {
+	reference, err := ParsePRReference("feature/read-checks")
+	suite.NoError(err)
+	suite.Equal(PRReference{Branch: "feature/read-checks"}, reference)
+}
+
+func (suite *ReferenceTestSuite) TestParsePRReference_invalid() {
+	for _, value := range []string{"", "not a branch", "feature..main", "https://github.com/hbk619/gh-peruse/issues/7", "https://github.com/hbk619/gh-peruse/pull/seven"} {
+		_, err := ParsePRReference(value)
+		suite.EqualError(err, "please provide a valid PR number, URL, owner/repo#number or branch name", value)
+	}
+}
+
+func TestReferenceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReferenceTestSuite))
+}
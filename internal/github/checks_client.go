@@ -0,0 +1,80 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	githubql "github.com/cli/shurcooL-graphql"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github/graphql"
+	"github.com/hbk619/gh-peruse/internal/requests"
+)
+
+// ChecksClient reads checks apart from PullRequestClient. GetPRDetails fetches every comment and thread of a PR with
+// only the name and conclusion of each check, too much to poll while watching and too little to say why one failed.
+// Statuses are normalised the same way for both.
+type ChecksClient interface {
+	GetChecks(repo *git.Repo) ([]git.Status, error)
+	GetJobLog(repo *git.Repo, jobId int) (string, error)
+}
+
+type CheckClient struct {
+	graphQLClient requests.GraphQLClient
+	restClient    requests.RESTClient
+}
+
+func NewChecksClient(graphQLClient requests.GraphQLClient, restClient requests.RESTClient) *CheckClient {
+	return &CheckClient{
+		graphQLClient: graphQLClient,
+		restClient:    restClient,
+	}
+}
+
+func (checks *CheckClient) GetChecks(repo *git.Repo) ([]git.Status, error) {
+	variables := map[string]interface{}{
+		"PullRequestId": githubql.Int(repo.PRNumber),
+		"Owner":         githubql.String(repo.Owner),
+		"RepoName":      githubql.String(repo.Name),
+	}
+	var response git.GitHubData
+	err := checks.graphQLClient.Do(graphql.ChecksQuery, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch checks %w", err)
+	}
+
+	return normaliseStatuses(response.Repository.PullRequest.StatusCheckRollup.Contexts.Nodes), nil
+}
+
+// GetJobLog gets the whole log of a GitHub Actions job, the job id is the check run's database id
+func (checks *CheckClient) GetJobLog(repo *git.Repo, jobId int) (string, error) {
+	path := fmt.Sprintf("repos/%s/%s/actions/jobs/%d/logs", repo.Owner, repo.Name, jobId)
+	response, err := checks.restClient.Request(http.MethodGet, path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch log %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return "", fmt.Errorf("failed to fetch log %s", response.Status)
+	}
+	log, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read log %w", err)
+	}
+	return string(log), nil
+}
+
+// normaliseStatuses gives commit statuses the same name, conclusion, summary and link fields as check runs
+func normaliseStatuses(statuses []git.Status) []git.Status {
+	for i, status := range statuses {
+		if status.Context == "" {
+			continue
+		}
+		statuses[i].Name = status.Context
+		statuses[i].Conclusion = status.State
+		statuses[i].Summary = status.Description
+		statuses[i].DetailsUrl = status.TargetUrl
+	}
+	return statuses
+}
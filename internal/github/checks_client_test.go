@@ -0,0 +1,148 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	githubql "github.com/cli/shurcooL-graphql"
+	"github.com/golang/mock/gomock"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github/graphql"
+	mock_requests "github.com/hbk619/gh-peruse/internal/requests/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChecksClientTestSuite struct {
+	suite.Suite
+	mockGraphQL *mock_requests.MockGraphQLClient
+	mockREST    *mock_requests.MockRESTClient
+	ctrl        *gomock.Controller
+	repo        *git.Repo
+	checks      *CheckClient
+}
+
+func (suite *ChecksClientTestSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockGraphQL = mock_requests.NewMockGraphQLClient(suite.ctrl)
+	suite.mockREST = mock_requests.NewMockRESTClient(suite.ctrl)
+	suite.repo = &git.Repo{
+		Owner:    "luigi",
+		Name:     "castle",
+		PRNumber: 123,
+	}
+	suite.checks = NewChecksClient(suite.mockGraphQL, suite.mockREST)
+}
+
+func (suite *ChecksClientTestSuite) TestGetChecks_includes_commit_statuses() {
+	checks := `{
+  "data": {
+    "repository": {
+      "pullRequest": {
+        "statusCheckRollup": {
+          "state": "FAILURE",
+          "contexts": {
+            "nodes": [
+              {
+                "databaseId": 42,
+                "name": "lint",
+                "status": "COMPLETED",
+                "conclusion": "FAILURE",
+                "detailsUrl": "https://github.com/luigi/castle/actions/runs/1/job/42",
+                "title": "1 error",
+                "summary": "Lint failed",
+                "annotations": {
+                  "nodes": [
+                    {"path": "main.go", "location": {"start": {"line": 12}}, "message": "unused variable", "annotationLevel": "FAILURE"}
+                  ]
+                }
+              },
+              {
+                "context": "ci/jenkins",
+                "state": "ERROR",
+                "description": "Build broke",
+                "targetUrl": "https://jenkins.example.com/1"
+              }
+            ]
+          }
+        }
+      }
+    }
+  }
+}`
+	variables := map[string]interface{}{
+		"PullRequestId": githubql.Int(suite.repo.PRNumber),
+		"Owner":         githubql.String(suite.repo.Owner),
+		"RepoName":      githubql.String(suite.repo.Name),
+	}
+	suite.mockGraphQL.EXPECT().
+		Do(graphql.ChecksQuery, variables, gomock.Any()).
+		DoAndReturn(func(query string, variables map[string]interface{}, response interface{}) error {
+			gr := GraphQLResponse{Data: response}
+			suite.NoError(json.Unmarshal([]byte(checks), &gr))
+			return nil
+		})
+
+	statuses, err := suite.checks.GetChecks(suite.repo)
+	suite.NoError(err)
+	suite.Equal([]git.Status{{
+		DatabaseId: 42,
+		Name:       "lint",
+		Status:     "COMPLETED",
+		Conclusion: "FAILURE",
+		DetailsUrl: "https://github.com/luigi/castle/actions/runs/1/job/42",
+		Title:      "1 error",
+		Summary:    "Lint failed",
+		Annotations: git.Annotations{Nodes: []git.Annotation{{
+			Path:            "main.go",
+			Location:        git.AnnotationLocation{Start: git.AnnotationPosition{Line: 12}},
+			Message:         "unused variable",
+			AnnotationLevel: "FAILURE",
+		}}},
+	}, {
+		Name:        "ci/jenkins",
+		Conclusion:  "ERROR",
+		Summary:     "Build broke",
+		DetailsUrl:  "https://jenkins.example.com/1",
+		Context:     "ci/jenkins",
+		State:       "ERROR",
+		Description: "Build broke",
+		TargetUrl:   "https://jenkins.example.com/1",
+	}}, statuses)
+}
+
+func (suite *ChecksClientTestSuite) TestGetChecks_error() {
+	suite.mockGraphQL.EXPECT().Do(graphql.ChecksQuery, gomock.Any(), gomock.Any()).Return(errors.New("bad"))
+
+	_, err := suite.checks.GetChecks(suite.repo)
+	suite.EqualError(err, "failed to fetch checks bad")
+}
+
+func (suite *ChecksClientTestSuite) TestGetJobLog() {
+	suite.mockREST.EXPECT().Request(http.MethodGet, "repos/luigi/castle/actions/jobs/42/logs", nil).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader("line 1\nline 2\n")),
+	}, nil)
+
+	log, err := suite.checks.GetJobLog(suite.repo, 42)
+	suite.NoError(err)
+	suite.Equal("line 1\nline 2\n", log)
+}
+
+func (suite *ChecksClientTestSuite) TestGetJobLog_not_found() {
+	suite.mockREST.EXPECT().Request(http.MethodGet, gomock.Any(), nil).Return(&http.Response{
+		StatusCode: http.StatusNotFound,
+		Status:     "404 Not Found",
+		Body:       io.NopCloser(strings.NewReader("")),
+	}, nil)
+
+	_, err := suite.checks.GetJobLog(suite.repo, 42)
+	suite.EqualError(err, "failed to fetch log 404 Not Found")
+}
+
+func TestChecksClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ChecksClientTestSuite))
+}
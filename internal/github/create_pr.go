@@ -0,0 +1,104 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	githubql "github.com/cli/shurcooL-graphql"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github/graphql"
+)
+
+// NewPR fills in what it can for a PR from the current branch, the default branch as the base, the title of
+// the last commit and the repo's PR template
+func (gh *PRClient) NewPR(repo *git.Repo) (*git.NewPR, error) {
+	ctx := context.Background()
+	branch, err := gh.gitClient.CurrentBranch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the current branch %w", err)
+	}
+	variables := map[string]interface{}{
+		"Owner":    githubql.String(repo.Owner),
+		"RepoName": githubql.String(repo.Name),
+	}
+	var response git.GitHubData
+	err = gh.graphQLClient.Do(graphql.NewPRQuery, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch repository %w", err)
+	}
+	repository := response.Repository
+	if branch == repository.DefaultBranchRef.Name {
+		return nil, fmt.Errorf("%s is the default branch, switch to the branch with your changes first", branch)
+	}
+
+	newPR := &git.NewPR{
+		RepositoryId: repository.Id,
+		Base:         repository.DefaultBranchRef.Name,
+		Head:         branch,
+		Branch:       branch,
+	}
+	if owner := gh.headOwner(ctx, branch); owner != "" {
+		newPR.Pushed = gh.isPushed(ctx, branch)
+		newPR.Head = headRef(repo, owner, branch)
+	}
+	if len(repository.PullRequestTemplates) > 0 {
+		newPR.Template = repository.PullRequestTemplates[0].Body
+	}
+	if commit, err := gh.gitClient.LastCommit(ctx); err == nil {
+		newPR.Title = commit.Title
+	}
+	return newPR, nil
+}
+
+// isPushed reports whether the remote branch the branch pushes to has the same commit as the branch, as of the
+// last fetch
+func (gh *PRClient) isPushed(ctx context.Context, branch string) bool {
+	pushRef, err := gh.gitClient.PushRevision(ctx, branch)
+	if err != nil {
+		return false
+	}
+	refs, err := gh.gitClient.ShowRefs(ctx, []string{"refs/heads/" + branch, pushRef.String()})
+	return err == nil && len(refs) == 2 && refs[0].Hash == refs[1].Hash
+}
+
+// PushBranch pushes the branch to the remote it already pushes to, or else the remote for the repo, and tracks it.
+// It gives the head to open the PR from, owner:branch when the remote it pushed to is a fork.
+func (gh *PRClient) PushBranch(repo *git.Repo, branch string) (string, error) {
+	ctx := context.Background()
+	remote := gh.remoteFor(ctx, repo)
+	if pushRef, err := gh.gitClient.PushRevision(ctx, branch); err == nil {
+		remote = pushRef.Remote
+	}
+	err := gh.gitClient.Push(ctx, remote, branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to push %s %w", branch, err)
+	}
+	return headRef(repo, gh.remoteOwner(ctx, remote), branch), nil
+}
+
+// headRef is the branch as createPullRequest wants it, prefixed with its owner when it is not in the repo
+func headRef(repo *git.Repo, owner string, branch string) string {
+	if owner == "" || strings.EqualFold(owner, repo.Owner) {
+		return branch
+	}
+	return fmt.Sprintf("%s:%s", owner, branch)
+}
+
+// CreatePR opens the PR, reviewers and labels are added separately once it exists
+func (gh *PRClient) CreatePR(newPR *git.NewPR) (*git.PullRequest, error) {
+	variables := map[string]interface{}{
+		"repositoryId": newPR.RepositoryId,
+		"baseRefName":  newPR.Base,
+		"headRefName":  newPR.Head,
+		"title":        newPR.Title,
+		"body":         newPR.Body,
+		"draft":        newPR.Draft,
+	}
+	var response git.CreatePRResponse
+	err := gh.graphQLClient.Do(graphql.CreatePRMutation, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request %w", err)
+	}
+	return &response.CreatePullRequest.PullRequest, nil
+}
@@ -0,0 +1,176 @@
+package github
+
+import (
+	"errors"
+	"net/url"
+
+	cligit "github.com/cli/cli/v2/git"
+	githubql "github.com/cli/shurcooL-graphql"
+	"github.com/golang/mock/gomock"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github/graphql"
+)
+
+var newPRResponse = `{"data": {"repository": {
+	"id": "R_1",
+	"defaultBranchRef": {"name": "main"},
+	"pullRequestTemplates": [{"filename": "PULL_REQUEST_TEMPLATE.md", "body": "## Why"}]
+}}}`
+
+func (suite *PRServiceTestSuite) newPRVariables() map[string]interface{} {
+	return map[string]interface{}{
+		"Owner":    githubql.String("luigi"),
+		"RepoName": githubql.String("castle"),
+	}
+}
+
+func (suite *PRServiceTestSuite) TestNewPR() {
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("pipes", nil)
+	suite.respondWith(graphql.NewPRQuery, suite.newPRVariables(), newPRResponse)
+	suite.mockGitClient.EXPECT().ReadBranchConfig(gomock.Any(), "pipes").Return(cligit.BranchConfig{}, nil)
+	suite.mockGitClient.EXPECT().LastCommit(gomock.Any()).Return(&cligit.Commit{Title: "Faster pipes"}, nil)
+
+	newPR, err := suite.prService.NewPR(suite.repo)
+	suite.NoError(err)
+	suite.Equal(&git.NewPR{
+		RepositoryId: "R_1",
+		Base:         "main",
+		Head:         "pipes",
+		Branch:       "pipes",
+		Template:     "## Why",
+		Title:        "Faster pipes",
+	}, newPR)
+}
+
+func (suite *PRServiceTestSuite) TestNewPR_pushed_to_fork() {
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("pipes", nil)
+	suite.respondWith(graphql.NewPRQuery, suite.newPRVariables(), `{"data": {"repository": {"id": "R_1", "defaultBranchRef": {"name": "main"}}}}`)
+	pushURL, _ := url.Parse("ssh://git@github.com/toad/castle.git")
+	suite.mockGitClient.EXPECT().ReadBranchConfig(gomock.Any(), "pipes").Return(cligit.BranchConfig{PushRemoteURL: pushURL}, nil)
+	suite.mockGitClient.EXPECT().PushRevision(gomock.Any(), "pipes").Return(cligit.RemoteTrackingRef{Remote: "fork", Branch: "pipes"}, nil)
+	suite.mockGitClient.EXPECT().ShowRefs(gomock.Any(), []string{"refs/heads/pipes", "refs/remotes/fork/pipes"}).Return([]cligit.Ref{
+		{Hash: "abc123", Name: "refs/heads/pipes"},
+		{Hash: "abc123", Name: "refs/remotes/fork/pipes"},
+	}, nil)
+	suite.mockGitClient.EXPECT().LastCommit(gomock.Any()).Return(nil, errors.New("no commits"))
+
+	newPR, err := suite.prService.NewPR(suite.repo)
+	suite.NoError(err)
+	suite.Equal(&git.NewPR{
+		RepositoryId: "R_1",
+		Base:         "main",
+		Head:         "toad:pipes",
+		Branch:       "pipes",
+		Pushed:       true,
+	}, newPR)
+}
+
+func (suite *PRServiceTestSuite) TestNewPR_remote_branch_behind() {
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("pipes", nil)
+	suite.respondWith(graphql.NewPRQuery, suite.newPRVariables(), newPRResponse)
+	suite.mockGitClient.EXPECT().ReadBranchConfig(gomock.Any(), "pipes").Return(cligit.BranchConfig{RemoteName: "origin"}, nil)
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(cligit.RemoteSet{
+		cligit.NewRemote("origin", "https://github.com/luigi/castle.git"),
+	}, nil)
+	suite.mockGitClient.EXPECT().PushRevision(gomock.Any(), "pipes").Return(cligit.RemoteTrackingRef{Remote: "origin", Branch: "pipes"}, nil)
+	suite.mockGitClient.EXPECT().ShowRefs(gomock.Any(), gomock.Any()).Return([]cligit.Ref{
+		{Hash: "def456", Name: "refs/heads/pipes"},
+		{Hash: "abc123", Name: "refs/remotes/origin/pipes"},
+	}, nil)
+	suite.mockGitClient.EXPECT().LastCommit(gomock.Any()).Return(&cligit.Commit{Title: "Faster pipes"}, nil)
+
+	newPR, err := suite.prService.NewPR(suite.repo)
+	suite.NoError(err)
+	suite.False(newPR.Pushed)
+	suite.Equal("pipes", newPR.Head)
+}
+
+func (suite *PRServiceTestSuite) TestNewPR_on_default_branch() {
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("main", nil)
+	suite.respondWith(graphql.NewPRQuery, suite.newPRVariables(), newPRResponse)
+
+	_, err := suite.prService.NewPR(suite.repo)
+	suite.EqualError(err, "main is the default branch, switch to the branch with your changes first")
+}
+
+func (suite *PRServiceTestSuite) TestNewPR_no_branch() {
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("", errors.New("detached HEAD"))
+
+	_, err := suite.prService.NewPR(suite.repo)
+	suite.EqualError(err, "failed to find the current branch detached HEAD")
+}
+
+func (suite *PRServiceTestSuite) TestPushBranch() {
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(cligit.RemoteSet{
+		cligit.NewRemote("origin", "https://github.com/luigi/castle.git"),
+	}, nil).Times(2)
+	suite.mockGitClient.EXPECT().PushRevision(gomock.Any(), "pipes").Return(cligit.RemoteTrackingRef{}, errors.New("no upstream configured"))
+	suite.mockGitClient.EXPECT().Push(gomock.Any(), "origin", "pipes").Return(nil)
+
+	head, err := suite.prService.PushBranch(suite.repo, "pipes")
+	suite.NoError(err)
+	suite.Equal("pipes", head)
+}
+
+func (suite *PRServiceTestSuite) TestPushBranch_to_fork() {
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(cligit.RemoteSet{
+		cligit.NewRemote("origin", "https://github.com/luigi/castle.git"),
+		cligit.NewRemote("fork", "ssh://git@github.com/toad/castle.git"),
+	}, nil).Times(2)
+	suite.mockGitClient.EXPECT().PushRevision(gomock.Any(), "pipes").Return(cligit.RemoteTrackingRef{Remote: "fork", Branch: "pipes"}, nil)
+	suite.mockGitClient.EXPECT().Push(gomock.Any(), "fork", "pipes").Return(nil)
+
+	head, err := suite.prService.PushBranch(suite.repo, "pipes")
+	suite.NoError(err)
+	suite.Equal("toad:pipes", head)
+}
+
+func (suite *PRServiceTestSuite) TestPushBranch_to_repo_url() {
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(nil, nil)
+	suite.mockGitClient.EXPECT().PushRevision(gomock.Any(), "pipes").Return(cligit.RemoteTrackingRef{}, errors.New("no upstream configured"))
+	suite.mockGitClient.EXPECT().Push(gomock.Any(), "https://github.com/luigi/castle.git", "pipes").Return(nil)
+
+	head, err := suite.prService.PushBranch(suite.repo, "pipes")
+	suite.NoError(err)
+	suite.Equal("pipes", head)
+}
+
+func (suite *PRServiceTestSuite) TestPushBranch_has_error() {
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(nil, nil)
+	suite.mockGitClient.EXPECT().PushRevision(gomock.Any(), "pipes").Return(cligit.RemoteTrackingRef{}, errors.New("no upstream configured"))
+	suite.mockGitClient.EXPECT().Push(gomock.Any(), "https://github.com/luigi/castle.git", "pipes").Return(errors.New("rejected"))
+
+	_, err := suite.prService.PushBranch(suite.repo, "pipes")
+	suite.EqualError(err, "failed to push pipes rejected")
+}
+
+func (suite *PRServiceTestSuite) TestCreatePR() {
+	suite.respondWith(graphql.CreatePRMutation, map[string]interface{}{
+		"repositoryId": "R_1",
+		"baseRefName":  "main",
+		"headRefName":  "toad:pipes",
+		"title":        "Faster pipes",
+		"body":         "## Why",
+		"draft":        true,
+	}, `{"data": {"createPullRequest": {"pullRequest": {"id": "PR_kwDOA", "number": 7, "url": "https://github.com/luigi/castle/pull/7"}}}}`)
+
+	pr, err := suite.prService.CreatePR(&git.NewPR{
+		RepositoryId: "R_1",
+		Base:         "main",
+		Head:         "toad:pipes",
+		Title:        "Faster pipes",
+		Body:         "## Why",
+		Draft:        true,
+	})
+	suite.NoError(err)
+	suite.Equal("PR_kwDOA", pr.Id)
+	suite.Equal(7, pr.Number)
+	suite.Equal("https://github.com/luigi/castle/pull/7", pr.URL)
+}
+
+func (suite *PRServiceTestSuite) TestCreatePR_has_error() {
+	suite.mockGraphQL.EXPECT().Do(graphql.CreatePRMutation, gomock.Any(), gomock.Any()).Return(errors.New("head sha can't be blank"))
+
+	_, err := suite.prService.CreatePR(&git.NewPR{})
+	suite.EqualError(err, "failed to create pull request head sha can't be blank")
+}
@@ -0,0 +1,169 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	githubql "github.com/cli/shurcooL-graphql"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github/graphql"
+	"github.com/hbk619/gh-peruse/internal/requests"
+)
+
+type DiscussionsClient interface {
+	GetRepoDetails() (repository.Repository, error)
+	GetDiscussion(repo *git.Repo, number int) (*git.DiscussionDetails, error)
+	Reply(contents string, comment *git.Comment, discussionId string) error
+	React(comment *git.Comment, content string) error
+	RemoveReaction(comment *git.Comment, content string) error
+	MarkAnswer(comment *git.Comment) error
+	UnmarkAnswer(comment *git.Comment) error
+}
+
+type DiscussionClient struct {
+	graphQLClient requests.GraphQLClient
+}
+
+func NewDiscussionClient(apiClient requests.GraphQLClient) *DiscussionClient {
+	return &DiscussionClient{
+		graphQLClient: apiClient,
+	}
+}
+
+func (gh *DiscussionClient) GetRepoDetails() (repository.Repository, error) {
+	return repository.Current()
+}
+
+// GetDiscussion fetches the discussion and its comments a page at a time, each comment is followed by its replies
+func (gh *DiscussionClient) GetDiscussion(repo *git.Repo, number int) (*git.DiscussionDetails, error) {
+	var cursor *githubql.String
+	var discussion git.Discussion
+	var viewer string
+	var comments []git.Comment
+	for {
+		variables := map[string]interface{}{
+			"Number":   githubql.Int(number),
+			"Owner":    githubql.String(repo.Owner),
+			"RepoName": githubql.String(repo.Name),
+			"Cursor":   cursor,
+		}
+		var response git.GitHubData
+		err := gh.graphQLClient.Do(graphql.DiscussionQuery, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch discussion %w", err)
+		}
+		page := response.Repository.Discussion.Comments
+		if cursor == nil {
+			discussion = response.Repository.Discussion
+			viewer = response.Viewer.Login
+		}
+		comments = append(comments, page.Nodes...)
+
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		cursor = githubql.NewString(githubql.String(page.PageInfo.EndCursor))
+	}
+	if discussion.Id == "" {
+		return nil, fmt.Errorf("discussion %d not found", number)
+	}
+
+	mainThread := git.File{FullPath: MainThread, FileName: MainThread}
+	commentList := []git.Comment{{
+		Author:    discussion.Author,
+		Body:      discussion.Body,
+		File:      mainThread,
+		CreatedAt: discussion.CreatedAt,
+		Id:        discussion.Id,
+		Kind:      git.DiscussionKind,
+		Reactions: discussion.Reactions,
+	}}
+	for _, comment := range comments {
+		thread := git.Thread{ID: comment.Id}
+		replies, err := gh.allReplies(comment)
+		if err != nil {
+			return nil, err
+		}
+		comment.Replies = git.Comments{}
+		comment.File = mainThread
+		comment.Kind = git.DiscussionCommentKind
+		comment.Thread = thread
+		commentList = append(commentList, comment)
+		for _, reply := range replies {
+			reply.File = mainThread
+			reply.Kind = git.DiscussionCommentKind
+			reply.Thread = thread
+			commentList = append(commentList, reply)
+		}
+	}
+
+	return &git.DiscussionDetails{
+		Comments:   commentList,
+		Title:      discussion.Title,
+		Id:         discussion.Id,
+		Viewer:     viewer,
+		Category:   discussion.Category.Name,
+		Answerable: discussion.Category.IsAnswerable,
+	}, nil
+}
+
+// allReplies gives the replies read with the comment followed by those on later pages
+func (gh *DiscussionClient) allReplies(comment git.Comment) ([]git.Comment, error) {
+	replies := comment.Replies.Nodes
+	page := comment.Replies.PageInfo
+	for page.HasNextPage {
+		variables := map[string]interface{}{
+			"Id":     comment.Id,
+			"Cursor": githubql.NewString(githubql.String(page.EndCursor)),
+		}
+		var response git.RepliesResponse
+		err := gh.graphQLClient.Do(graphql.DiscussionRepliesQuery, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch replies %w", err)
+		}
+		replies = append(replies, response.Node.Replies.Nodes...)
+		page = response.Node.Replies.PageInfo
+	}
+	return replies, nil
+}
+
+// Reply adds a reply to the thread of the comment, or a new top level comment when replying to the discussion itself
+func (gh *DiscussionClient) Reply(contents string, comment *git.Comment, discussionId string) error {
+	var replyToId *string
+	if comment.Thread.ID != "" {
+		replyToId = &comment.Thread.ID
+	}
+	variables := map[string]interface{}{
+		"discussionId": discussionId,
+		"body":         contents,
+		"replyToId":    replyToId,
+	}
+	return gh.graphQLClient.Do(graphql.AddDiscussionCommentMutation, variables, nil)
+}
+
+func (gh *DiscussionClient) React(comment *git.Comment, content string) error {
+	return react(gh.graphQLClient, graphql.AddReactionMutation, comment, content)
+}
+
+func (gh *DiscussionClient) RemoveReaction(comment *git.Comment, content string) error {
+	return react(gh.graphQLClient, graphql.RemoveReactionMutation, comment, content)
+}
+
+func (gh *DiscussionClient) MarkAnswer(comment *git.Comment) error {
+	return gh.answer(graphql.MarkAnswerMutation, comment)
+}
+
+func (gh *DiscussionClient) UnmarkAnswer(comment *git.Comment) error {
+	return gh.answer(graphql.UnmarkAnswerMutation, comment)
+}
+
+func (gh *DiscussionClient) answer(mutation string, comment *git.Comment) error {
+	if comment.Kind != git.DiscussionCommentKind {
+		return errors.New("only a comment can be the answer")
+	}
+	variables := map[string]interface{}{
+		"id": comment.Id,
+	}
+	return gh.graphQLClient.Do(mutation, variables, nil)
+}
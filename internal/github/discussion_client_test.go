@@ -0,0 +1,269 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	githubql "github.com/cli/shurcooL-graphql"
+	"github.com/golang/mock/gomock"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github/graphql"
+	mock_requests "github.com/hbk619/gh-peruse/internal/requests/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type DiscussionClientTestSuite struct {
+	suite.Suite
+	mockGraphQL *mock_requests.MockGraphQLClient
+	ctrl        *gomock.Controller
+	repo        *git.Repo
+	discussions *DiscussionClient
+}
+
+func (suite *DiscussionClientTestSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockGraphQL = mock_requests.NewMockGraphQLClient(suite.ctrl)
+	suite.repo = &git.Repo{
+		Owner: "luigi",
+		Name:  "castle",
+	}
+	suite.discussions = NewDiscussionClient(suite.mockGraphQL)
+}
+
+func (suite *DiscussionClientTestSuite) expectPage(cursor *githubql.String, page string) {
+	variables := map[string]interface{}{
+		"Number":   githubql.Int(3),
+		"Owner":    githubql.String("luigi"),
+		"RepoName": githubql.String("castle"),
+		"Cursor":   cursor,
+	}
+	suite.mockGraphQL.EXPECT().
+		Do(graphql.DiscussionQuery, variables, gomock.Any()).
+		DoAndReturn(func(query string, variables map[string]interface{}, response interface{}) error {
+			gr := GraphQLResponse{Data: response}
+			err := json.Unmarshal([]byte(page), &gr)
+			suite.NoError(err)
+			return nil
+		})
+}
+
+func (suite *DiscussionClientTestSuite) TestGetDiscussion_puts_replies_after_their_comment() {
+	suite.expectPage(nil, `{
+  "data": {
+    "viewer": {"login": "mario"},
+    "repository": {
+      "discussion": {
+        "id": "D_kwDOA",
+        "title": "RFC: faster pipes",
+        "body": "What if pipes were faster?",
+        "author": {"login": "toad"},
+        "category": {"name": "Q&A", "isAnswerable": true},
+        "comments": {
+          "pageInfo": {"hasNextPage": true, "endCursor": "Y3Vyc29y"},
+          "nodes": [{
+            "id": "DC_1",
+            "body": "Use a star",
+            "author": {"login": "mario"},
+            "replies": {"nodes": [{"id": "DC_2", "body": "Too expensive", "author": {"login": "toad"}}]}
+          }]
+        }
+      }
+    }
+  }
+}`)
+	suite.expectPage(githubql.NewString("Y3Vyc29y"), `{
+  "data": {
+    "repository": {
+      "discussion": {
+        "id": "D_kwDOA",
+        "comments": {
+          "pageInfo": {"hasNextPage": false},
+          "nodes": [{"id": "DC_3", "body": "Warp zones", "author": {"login": "luigi"}, "isAnswer": true}]
+        }
+      }
+    }
+  }
+}`)
+
+	discussion, err := suite.discussions.GetDiscussion(suite.repo, 3)
+	suite.NoError(err)
+	mainThread := git.File{FullPath: MainThread, FileName: MainThread}
+	suite.Equal(&git.DiscussionDetails{
+		Comments: []git.Comment{
+			{Id: "D_kwDOA", Body: "What if pipes were faster?", Author: git.Author{Login: "toad"}, File: mainThread, Kind: git.DiscussionKind},
+			{Id: "DC_1", Body: "Use a star", Author: git.Author{Login: "mario"}, File: mainThread, Kind: git.DiscussionCommentKind, Thread: git.Thread{ID: "DC_1"}},
+			{Id: "DC_2", Body: "Too expensive", Author: git.Author{Login: "toad"}, File: mainThread, Kind: git.DiscussionCommentKind, Thread: git.Thread{ID: "DC_1"}},
+			{Id: "DC_3", Body: "Warp zones", Author: git.Author{Login: "luigi"}, File: mainThread, Kind: git.DiscussionCommentKind, Thread: git.Thread{ID: "DC_3"}, IsAnswer: true},
+		},
+		Title:      "RFC: faster pipes",
+		Id:         "D_kwDOA",
+		Viewer:     "mario",
+		Category:   "Q&A",
+		Answerable: true,
+	}, discussion)
+}
+
+func (suite *DiscussionClientTestSuite) expectReplies(cursor string, page string) *gomock.Call {
+	variables := map[string]interface{}{
+		"Id":     "DC_1",
+		"Cursor": githubql.NewString(githubql.String(cursor)),
+	}
+	return suite.mockGraphQL.EXPECT().
+		Do(graphql.DiscussionRepliesQuery, variables, gomock.Any()).
+		DoAndReturn(func(query string, variables map[string]interface{}, response interface{}) error {
+			gr := GraphQLResponse{Data: response}
+			err := json.Unmarshal([]byte(page), &gr)
+			suite.NoError(err)
+			return nil
+		})
+}
+
+func (suite *DiscussionClientTestSuite) TestGetDiscussion_reads_every_page_of_replies() {
+	suite.expectPage(nil, `{
+  "data": {
+    "viewer": {"login": "mario"},
+    "repository": {
+      "discussion": {
+        "id": "D_kwDOA",
+        "title": "RFC: faster pipes",
+        "body": "What if pipes were faster?",
+        "author": {"login": "toad"},
+        "category": {"name": "Ideas"},
+        "comments": {
+          "pageInfo": {"hasNextPage": false},
+          "nodes": [{
+            "id": "DC_1",
+            "body": "Use a star",
+            "author": {"login": "mario"},
+            "replies": {
+              "pageInfo": {"hasNextPage": true, "endCursor": "cmVwbHkx"},
+              "nodes": [{"id": "DC_2", "body": "Too expensive", "author": {"login": "toad"}}]
+            }
+          }, {"id": "DC_5", "body": "Warp zones", "author": {"login": "luigi"}}]
+        }
+      }
+    }
+  }
+}`)
+	gomock.InOrder(
+		suite.expectReplies("cmVwbHkx", `{
+  "data": {
+    "node": {
+      "replies": {
+        "pageInfo": {"hasNextPage": true, "endCursor": "cmVwbHky"},
+        "nodes": [{"id": "DC_3", "body": "Not for a star", "author": {"login": "mario"}}]
+      }
+    }
+  }
+}`),
+		suite.expectReplies("cmVwbHky", `{
+  "data": {
+    "node": {
+      "replies": {
+        "pageInfo": {"hasNextPage": false},
+        "nodes": [{"id": "DC_4", "body": "Fine", "author": {"login": "toad"}}]
+      }
+    }
+  }
+}`),
+	)
+
+	discussion, err := suite.discussions.GetDiscussion(suite.repo, 3)
+	suite.NoError(err)
+	var ids []string
+	for _, comment := range discussion.Comments {
+		ids = append(ids, comment.Id+" "+comment.Thread.ID)
+	}
+	suite.Equal([]string{"D_kwDOA ", "DC_1 DC_1", "DC_2 DC_1", "DC_3 DC_1", "DC_4 DC_1", "DC_5 DC_5"}, ids)
+}
+
+func (suite *DiscussionClientTestSuite) TestGetDiscussion_error_getting_replies() {
+	suite.expectPage(nil, `{
+  "data": {
+    "repository": {
+      "discussion": {
+        "id": "D_kwDOA",
+        "comments": {
+          "nodes": [{"id": "DC_1", "replies": {"pageInfo": {"hasNextPage": true, "endCursor": "cmVwbHkx"}}}]
+        }
+      }
+    }
+  }
+}`)
+	suite.mockGraphQL.EXPECT().Do(graphql.DiscussionRepliesQuery, gomock.Any(), gomock.Any()).Return(errors.New("bad gateway"))
+
+	discussion, err := suite.discussions.GetDiscussion(suite.repo, 3)
+	suite.EqualError(err, "failed to fetch replies bad gateway")
+	suite.Nil(discussion)
+}
+
+func (suite *DiscussionClientTestSuite) TestGetDiscussion_not_found() {
+	suite.expectPage(nil, `{"data": {"repository": {}}}`)
+
+	discussion, err := suite.discussions.GetDiscussion(suite.repo, 3)
+	suite.EqualError(err, "discussion 3 not found")
+	suite.Nil(discussion)
+}
+
+func (suite *DiscussionClientTestSuite) TestGetDiscussion_has_error() {
+	suite.mockGraphQL.EXPECT().Do(graphql.DiscussionQuery, gomock.Any(), gomock.Any()).Return(errors.New("bad gateway"))
+
+	discussion, err := suite.discussions.GetDiscussion(suite.repo, 3)
+	suite.EqualError(err, "failed to fetch discussion bad gateway")
+	suite.Nil(discussion)
+}
+
+func (suite *DiscussionClientTestSuite) TestReply_in_thread() {
+	threadId := "DC_1"
+	variables := map[string]interface{}{
+		"discussionId": "D_kwDOA",
+		"body":         "Agreed",
+		"replyToId":    &threadId,
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.AddDiscussionCommentMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.discussions.Reply("Agreed", &git.Comment{Id: "DC_2", Thread: git.Thread{ID: "DC_1"}}, "D_kwDOA")
+	suite.NoError(err)
+}
+
+func (suite *DiscussionClientTestSuite) TestReply_to_discussion_starts_thread() {
+	variables := map[string]interface{}{
+		"discussionId": "D_kwDOA",
+		"body":         "New idea",
+		"replyToId":    (*string)(nil),
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.AddDiscussionCommentMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.discussions.Reply("New idea", &git.Comment{Id: "D_kwDOA"}, "D_kwDOA")
+	suite.NoError(err)
+}
+
+func (suite *DiscussionClientTestSuite) TestMarkAnswer() {
+	variables := map[string]interface{}{
+		"id": "DC_3",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.MarkAnswerMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.discussions.MarkAnswer(&git.Comment{Id: "DC_3", Kind: git.DiscussionCommentKind})
+	suite.NoError(err)
+}
+
+func (suite *DiscussionClientTestSuite) TestUnmarkAnswer() {
+	variables := map[string]interface{}{
+		"id": "DC_3",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.UnmarkAnswerMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.discussions.UnmarkAnswer(&git.Comment{Id: "DC_3", Kind: git.DiscussionCommentKind})
+	suite.NoError(err)
+}
+
+func (suite *DiscussionClientTestSuite) TestMarkAnswer_discussion() {
+	err := suite.discussions.MarkAnswer(&git.Comment{Id: "D_kwDOA", Kind: git.DiscussionKind})
+	suite.EqualError(err, "only a comment can be the answer")
+}
+
+func TestDiscussionClientTestSuite(t *testing.T) {
+	suite.Run(t, new(DiscussionClientTestSuite))
+}
@@ -1,7 +1,54 @@
 package github
 
-import "context"
+import (
+	"context"
+	"strings"
+
+	"github.com/cli/cli/v2/git"
+)
 
 type GitClient interface {
 	CurrentBranch(ctx context.Context) (string, error)
+	ReadBranchConfig(ctx context.Context, branch string) (git.BranchConfig, error)
+	Remotes(ctx context.Context) (git.RemoteSet, error)
+	TrackedChangeCount(ctx context.Context) (int, error)
+	Fetch(ctx context.Context, remote string, refspec string, mods ...git.CommandModifier) error
+	Pull(ctx context.Context, remote, branch string, mods ...git.CommandModifier) error
+	CheckoutBranch(ctx context.Context, branch string) error
+	HasLocalBranch(ctx context.Context, branch string) bool
+	SetBranchConfig(ctx context.Context, branch, name, value string) error
+	ToplevelDir(ctx context.Context) (string, error)
+	LastCommit(ctx context.Context) (*git.Commit, error)
+	Push(ctx context.Context, remote string, ref string, mods ...git.CommandModifier) error
+	PushRevision(ctx context.Context, branch string) (git.RemoteTrackingRef, error)
+	ShowRefs(ctx context.Context, refs []string) ([]git.Ref, error)
+}
+
+// LocalGit runs git in the current directory
+type LocalGit struct {
+	*git.Client
+}
+
+func NewGitClient() *LocalGit {
+	return &LocalGit{Client: &git.Client{}}
+}
+
+// TrackedChangeCount counts files with uncommitted changes, untracked files are not touched by switching branches so
+// they are not counted
+func (local *LocalGit) TrackedChangeCount(ctx context.Context) (int, error) {
+	cmd, err := local.Command(ctx, "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return 0, err
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, line := range strings.Split(string(out), "\n") {
+		if line != "" {
+			count++
+		}
+	}
+	return count, nil
 }
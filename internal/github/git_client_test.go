@@ -0,0 +1,40 @@
+package github
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/cli/cli/v2/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackedChangeCount_ignores_untracked_files(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	local := &LocalGit{Client: &git.Client{RepoDir: dir}}
+	ctx := context.Background()
+	run := func(args ...string) {
+		cmd, err := local.Command(ctx, args...)
+		assert.NoError(t, err)
+		assert.NoError(t, cmd.Run())
+	}
+	run("init", "-q")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "castle.go"), []byte("package castle\n"), 0o644))
+	run("add", "castle.go")
+	run("-c", "user.name=Mario", "-c", "user.email=mario@example.com", "commit", "-q", "-m", "Add castle")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("pipes\n"), 0o644))
+	count, err := local.TrackedChangeCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "castle.go"), []byte("package castle\n\nvar Princess string\n"), 0o644))
+	count, err = local.TrackedChangeCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,13 @@
+package graphql
+
+var PRHeadQuery = `query PullRequestHead($PullRequestId: Int!, $Owner: String!, $RepoName: String!) {
+  repository(owner: $Owner, name: $RepoName) {
+    pullRequest(number: $PullRequestId) {
+      baseRefName
+      headRefName
+      headRefOid
+      isCrossRepository
+      headRepositoryOwner {login}
+    }
+  }
+}`
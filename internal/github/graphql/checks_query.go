@@ -0,0 +1,39 @@
+package graphql
+
+var ChecksQuery = `query PullRequestChecks($PullRequestId: Int!, $Owner: String!, $RepoName: String!) {
+  repository(owner: $Owner, name: $RepoName) {
+    pullRequest(number: $PullRequestId) {
+      statusCheckRollup {
+        state
+        contexts(first: 100) {
+          nodes {
+            ... on CheckRun {
+              databaseId
+              name
+              status
+              conclusion
+              detailsUrl
+              title
+              summary
+              annotations(first: 50) {
+                nodes {
+                  path
+                  location {start {line}}
+                  message
+                  title
+                  annotationLevel
+                }
+              }
+            }
+            ... on StatusContext {
+              context
+              state
+              description
+              targetUrl
+            }
+          }
+        }
+      }
+    }
+  }
+}`
@@ -16,3 +16,39 @@ var AddPRCommentMutation = `mutation AddComment($pullRequestId: ID!, $body: Stri
     clientMutationId
   }
 }`
+
+var UpdatePRBodyMutation = `mutation UpdatePullRequestBody($id: ID!, $body: String!) {
+  updatePullRequest(input: {pullRequestId: $id, body: $body}) {
+    clientMutationId
+  }
+}`
+
+var UpdateIssueCommentMutation = `mutation UpdateIssueComment($id: ID!, $body: String!) {
+  updateIssueComment(input: {id: $id, body: $body}) {
+    clientMutationId
+  }
+}`
+
+var UpdateReviewMutation = `mutation UpdatePullRequestReview($id: ID!, $body: String!) {
+  updatePullRequestReview(input: {pullRequestReviewId: $id, body: $body}) {
+    clientMutationId
+  }
+}`
+
+var UpdateReviewCommentMutation = `mutation UpdatePullRequestReviewComment($id: ID!, $body: String!) {
+  updatePullRequestReviewComment(input: {pullRequestReviewCommentId: $id, body: $body}) {
+    clientMutationId
+  }
+}`
+
+var DeleteIssueCommentMutation = `mutation DeleteIssueComment($id: ID!) {
+  deleteIssueComment(input: {id: $id}) {
+    clientMutationId
+  }
+}`
+
+var DeleteReviewCommentMutation = `mutation DeletePullRequestReviewComment($id: ID!) {
+  deletePullRequestReviewComment(input: {id: $id}) {
+    clientMutationId
+  }
+}`
@@ -0,0 +1,15 @@
+package graphql
+
+var NewPRQuery = `query NewPullRequest($Owner: String!, $RepoName: String!) {
+  repository(owner: $Owner, name: $RepoName) {
+    id
+    defaultBranchRef {name}
+    pullRequestTemplates {filename body}
+  }
+}`
+
+var CreatePRMutation = `mutation CreatePullRequest($repositoryId: ID!, $baseRefName: String!, $headRefName: String!, $title: String!, $body: String, $draft: Boolean) {
+  createPullRequest(input: {repositoryId: $repositoryId, baseRefName: $baseRefName, headRefName: $headRefName, title: $title, body: $body, draft: $draft}) {
+    pullRequest {id number url}
+  }
+}`
@@ -0,0 +1,76 @@
+package graphql
+
+var DiscussionQuery = `query DiscussionComments($Number: Int!, $Owner: String!, $RepoName: String!, $Cursor: String) {
+  viewer {login}
+  repository(owner: $Owner, name: $RepoName) {
+    discussion(number: $Number) {
+      id
+      number
+      title
+      body
+      author {login}
+      createdAt
+      category {name isAnswerable}
+      reactionGroups {content users {totalCount} viewerHasReacted}
+      comments(first: 50, after: $Cursor) {
+        pageInfo {hasNextPage endCursor}
+        nodes {
+          id
+          body
+          author {login}
+          createdAt
+          isAnswer
+          reactionGroups {content users {totalCount} viewerHasReacted}
+          replies(first: 100) {
+            pageInfo {hasNextPage endCursor}
+            nodes {
+              id
+              body
+              author {login}
+              createdAt
+              isAnswer
+              reactionGroups {content users {totalCount} viewerHasReacted}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// DiscussionRepliesQuery gets the replies to a comment after the first page read with DiscussionQuery
+var DiscussionRepliesQuery = `query DiscussionReplies($Id: ID!, $Cursor: String) {
+  node(id: $Id) {
+    ... on DiscussionComment {
+      replies(first: 100, after: $Cursor) {
+        pageInfo {hasNextPage endCursor}
+        nodes {
+          id
+          body
+          author {login}
+          createdAt
+          isAnswer
+          reactionGroups {content users {totalCount} viewerHasReacted}
+        }
+      }
+    }
+  }
+}`
+
+var AddDiscussionCommentMutation = `mutation AddDiscussionComment($discussionId: ID!, $body: String!, $replyToId: ID) {
+  addDiscussionComment(input: {discussionId: $discussionId, body: $body, replyToId: $replyToId}) {
+    clientMutationId
+  }
+}`
+
+var MarkAnswerMutation = `mutation MarkDiscussionCommentAsAnswer($id: ID!) {
+  markDiscussionCommentAsAnswer(input: {id: $id}) {
+    clientMutationId
+  }
+}`
+
+var UnmarkAnswerMutation = `mutation UnmarkDiscussionCommentAsAnswer($id: ID!) {
+  unmarkDiscussionCommentAsAnswer(input: {id: $id}) {
+    clientMutationId
+  }
+}`
@@ -0,0 +1,45 @@
+package graphql
+
+var IssueQuery = `query IssueComments($Number: Int!, $Owner: String!, $RepoName: String!, $Cursor: String) {
+  viewer {login}
+  repository(owner: $Owner, name: $RepoName) {
+    issue(number: $Number) {
+      id
+      number
+      title
+      body
+      state
+      author {login}
+      createdAt
+      reactionGroups {content users {totalCount} viewerHasReacted}
+      comments(first: 100, after: $Cursor) {
+        pageInfo {hasNextPage endCursor}
+        nodes {
+          id
+          body
+          author {login}
+          createdAt
+          reactionGroups {content users {totalCount} viewerHasReacted}
+        }
+      }
+    }
+  }
+}`
+
+var AddIssueCommentMutation = `mutation AddIssueComment($subjectId: ID!, $body: String!) {
+  addComment(input: {subjectId: $subjectId, body: $body}) {
+    clientMutationId
+  }
+}`
+
+var CloseIssueMutation = `mutation CloseIssue($issueId: ID!, $stateReason: IssueClosedStateReason!) {
+  closeIssue(input: {issueId: $issueId, stateReason: $stateReason}) {
+    clientMutationId
+  }
+}`
+
+var ReopenIssueMutation = `mutation ReopenIssue($issueId: ID!) {
+  reopenIssue(input: {issueId: $issueId}) {
+    clientMutationId
+  }
+}`
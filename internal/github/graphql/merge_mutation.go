@@ -0,0 +1,29 @@
+package graphql
+
+var MergeStateQuery = `query PullRequestMergeState($PullRequestId: Int!, $Owner: String!, $RepoName: String!) {
+  repository(owner: $Owner, name: $RepoName) {
+    pullRequest(number: $PullRequestId) {
+      mergeable
+      mergeStateStatus
+      headRef {id name}
+    }
+  }
+}`
+
+var MergePRMutation = `mutation MergePullRequest($pullRequestId: ID!, $mergeMethod: PullRequestMergeMethod!) {
+  mergePullRequest(input: {pullRequestId: $pullRequestId, mergeMethod: $mergeMethod}) {
+    clientMutationId
+  }
+}`
+
+var EnableAutoMergeMutation = `mutation EnableAutoMerge($pullRequestId: ID!, $mergeMethod: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $pullRequestId, mergeMethod: $mergeMethod}) {
+    clientMutationId
+  }
+}`
+
+var DeleteRefMutation = `mutation DeleteBranch($refId: ID!) {
+  deleteRef(input: {refId: $refId}) {
+    clientMutationId
+  }
+}`
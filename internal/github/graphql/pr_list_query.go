@@ -0,0 +1,26 @@
+package graphql
+
+var PRListQuery = `query ListPullRequests($Query: String!) {
+  search(type: ISSUE, query: $Query, first: 50) {
+    nodes {
+      ... on PullRequest {
+        number
+        title
+        isDraft
+        author {login}
+        baseRefName
+        headRefName
+        reviewThreads(first: 100) {
+          nodes {isResolved}
+        }
+        commits(last: 1) {
+          nodes {
+            commit {
+              statusCheckRollup {state}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
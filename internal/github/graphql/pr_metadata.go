@@ -0,0 +1,86 @@
+package graphql
+
+var PRMetadataQuery = `query PullRequestMetadata($PullRequestId: Int!, $Owner: String!, $RepoName: String!) {
+  repository(owner: $Owner, name: $RepoName) {
+    pullRequest(number: $PullRequestId) {
+      id
+      title
+      body
+      isDraft
+      labels(first: 100) {nodes {id name}}
+      assignees(first: 100) {nodes {id login}}
+      reviewRequests(first: 100) {
+        nodes {
+          requestedReviewer {
+            ... on User {id login}
+            ... on Team {id slug organization {login}}
+          }
+        }
+      }
+    }
+  }
+}`
+
+var LabelQuery = `query Label($Owner: String!, $RepoName: String!, $Name: String!) {
+  repository(owner: $Owner, name: $RepoName) {
+    label(name: $Name) {id name}
+  }
+}`
+
+var UserQuery = `query User($Login: String!) {
+  user(login: $Login) {id login}
+}`
+
+var TeamQuery = `query Team($Org: String!, $Slug: String!) {
+  organization(login: $Org) {
+    team(slug: $Slug) {id slug}
+  }
+}`
+
+var UpdatePRTitleMutation = `mutation UpdatePullRequestTitle($id: ID!, $title: String!) {
+  updatePullRequest(input: {pullRequestId: $id, title: $title}) {
+    clientMutationId
+  }
+}`
+
+var AddLabelsMutation = `mutation AddLabels($id: ID!, $labelIds: [ID!]!) {
+  addLabelsToLabelable(input: {labelableId: $id, labelIds: $labelIds}) {
+    clientMutationId
+  }
+}`
+
+var RemoveLabelsMutation = `mutation RemoveLabels($id: ID!, $labelIds: [ID!]!) {
+  removeLabelsFromLabelable(input: {labelableId: $id, labelIds: $labelIds}) {
+    clientMutationId
+  }
+}`
+
+var RequestReviewsMutation = `mutation RequestReviews($id: ID!, $userIds: [ID!], $teamIds: [ID!], $union: Boolean) {
+  requestReviews(input: {pullRequestId: $id, userIds: $userIds, teamIds: $teamIds, union: $union}) {
+    clientMutationId
+  }
+}`
+
+var AddAssigneesMutation = `mutation AddAssignees($id: ID!, $assigneeIds: [ID!]!) {
+  addAssigneesToAssignable(input: {assignableId: $id, assigneeIds: $assigneeIds}) {
+    clientMutationId
+  }
+}`
+
+var RemoveAssigneesMutation = `mutation RemoveAssignees($id: ID!, $assigneeIds: [ID!]!) {
+  removeAssigneesFromAssignable(input: {assignableId: $id, assigneeIds: $assigneeIds}) {
+    clientMutationId
+  }
+}`
+
+var MarkReadyForReviewMutation = `mutation MarkReadyForReview($id: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $id}) {
+    clientMutationId
+  }
+}`
+
+var ConvertToDraftMutation = `mutation ConvertToDraft($id: ID!) {
+  convertPullRequestToDraft(input: {pullRequestId: $id}) {
+    clientMutationId
+  }
+}`
@@ -7,17 +7,30 @@ import (
 )
 
 func PRDetailsQuery(verbose bool) string {
-	verboseFields := ""
-	if verbose {
-		verboseFields = `commits(first: 100) {
+	return pullRequestQuery("PullRequestComments", verboseFields(verbose))
+}
+
+// PRTimelineQuery also fetches the events of the PR, comments and reviews come from the same fields as PRDetailsQuery
+func PRTimelineQuery(verbose bool) string {
+	return pullRequestQuery("PullRequestTimeline", verboseFields(verbose)+timelineFields)
+}
+
+func verboseFields(verbose bool) string {
+	if !verbose {
+		return ""
+	}
+	return `commits(first: 100) {
 			nodes {
 			commit {
 				oid 
 				comments(first: 100) {
 				nodes {
+				  id
 				  body
+				  url
 				  author {login}
 				  createdAt
+				  reactionGroups {content users {totalCount} viewerHasReacted}
 				}
 			  }
 			}
@@ -32,6 +45,10 @@ func PRDetailsQuery(verbose bool) string {
 			  name
 			  conclusion
 			}
+			... on StatusContext {
+			  context
+			  state
+			}
 		  }
 
 		}
@@ -39,9 +56,12 @@ func PRDetailsQuery(verbose bool) string {
 	  mergeable
 	  mergeStateStatus
 `
-	}
+}
+
+func pullRequestQuery(name string, fields string) string {
 	return fmt.Sprintf(`
-query PullRequestComments($PullRequestId: Int!, $Owner: String!,$RepoName: String!) {
+query %s($PullRequestId: Int!, $Owner: String!,$RepoName: String!) {
+  viewer {login}
   repository(owner: $Owner, name:$RepoName) {
     pullRequest(number: $PullRequestId) {
 		%s
@@ -49,16 +69,22 @@ query PullRequestComments($PullRequestId: Int!, $Owner: String!,$RepoName: Strin
       reviews(first: 100) {
 			pageInfo {hasNextPage}
 			nodes {
+			  id
 			  author {login}
 			  state
 			  body
+			  url
 			  createdAt
+			  reactionGroups {content users {totalCount} viewerHasReacted}
 			}
 		  }
       body
       author{login}
       title
+      url
+      headRefOid
       createdAt
+      reactionGroups {content users {totalCount} viewerHasReacted}
       reviewThreads(first: 100) {
             nodes {
                 id,
@@ -76,7 +102,9 @@ query PullRequestComments($PullRequestId: Int!, $Owner: String!,$RepoName: Strin
                       line,
                       diffHunk,
                       outdated,
-                      createdAt
+                      url,
+                      createdAt,
+                      reactionGroups {content users {totalCount} viewerHasReacted}
                     }
                     pageInfo {
                       hasNextPage
@@ -90,21 +118,28 @@ query PullRequestComments($PullRequestId: Int!, $Owner: String!,$RepoName: Strin
           id,
           createdAt,
           body,
+          url,
           author {
             login
-          }
+          },
+          reactionGroups {content users {totalCount} viewerHasReacted}
         }
       }
     }
   }
-}`, verboseFields)
+}`, name, fields)
 }
 
 var GetPRForBranch = `query GetPRForBranch($BranchName: String!, $Owner: String!, $RepoName: String!) {
   repository(owner:$Owner, name:$RepoName) {
-    pullRequests(first: 10, headRefName:$BranchName) {
+    pullRequests(first: 10, headRefName:$BranchName, orderBy: {field: UPDATED_AT, direction: DESC}) {
       nodes {
         number
+        title
+        state
+        author {login}
+        baseRefName
+        headRepositoryOwner {login}
       }
     }
   }
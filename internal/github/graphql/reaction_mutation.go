@@ -0,0 +1,13 @@
+package graphql
+
+var AddReactionMutation = `mutation AddReaction($subjectId: ID!, $content: ReactionContent!) {
+  addReaction(input: {subjectId: $subjectId, content: $content}) {
+    clientMutationId
+  }
+}`
+
+var RemoveReactionMutation = `mutation RemoveReaction($subjectId: ID!, $content: ReactionContent!) {
+  removeReaction(input: {subjectId: $subjectId, content: $content}) {
+    clientMutationId
+  }
+}`
@@ -0,0 +1,79 @@
+package graphql
+
+import "fmt"
+
+var timelineFields = timelineItems("")
+
+// PRTimelineItemsQuery fetches the events after the first 100, a page at a time
+var PRTimelineItemsQuery = fmt.Sprintf(`query PullRequestTimelineItems($PullRequestId: Int!, $Owner: String!, $RepoName: String!, $Cursor: String) {
+  repository(owner: $Owner, name: $RepoName) {
+    pullRequest(number: $PullRequestId) {
+      %s
+    }
+  }
+}`, timelineItems(", after: $Cursor"))
+
+func timelineItems(after string) string {
+	return `timelineItems(first: 100` + after + `, itemTypes: [PULL_REQUEST_COMMIT, HEAD_REF_FORCE_PUSHED_EVENT, LABELED_EVENT, UNLABELED_EVENT, REVIEW_REQUESTED_EVENT, READY_FOR_REVIEW_EVENT, CONVERT_TO_DRAFT_EVENT, CLOSED_EVENT, REOPENED_EVENT, MERGED_EVENT]) {
+		pageInfo {hasNextPage endCursor}
+		nodes {
+		  __typename
+		  ... on PullRequestCommit {
+			commit {
+			  abbreviatedOid
+			  messageHeadline
+			  committedDate
+			  url
+			  author {name user {login}}
+			}
+		  }
+		  ... on HeadRefForcePushedEvent {
+			createdAt
+			actor {login}
+			beforeCommit {abbreviatedOid}
+			afterCommit {abbreviatedOid}
+		  }
+		  ... on LabeledEvent {
+			createdAt
+			actor {login}
+			label {name}
+		  }
+		  ... on UnlabeledEvent {
+			createdAt
+			actor {login}
+			label {name}
+		  }
+		  ... on ReviewRequestedEvent {
+			createdAt
+			actor {login}
+			requestedReviewer {
+			  ... on User {login}
+			  ... on Team {name}
+			}
+		  }
+		  ... on ReadyForReviewEvent {
+			createdAt
+			actor {login}
+		  }
+		  ... on ConvertToDraftEvent {
+			createdAt
+			actor {login}
+		  }
+		  ... on ClosedEvent {
+			createdAt
+			actor {login}
+		  }
+		  ... on ReopenedEvent {
+			createdAt
+			actor {login}
+		  }
+		  ... on MergedEvent {
+			createdAt
+			actor {login}
+			mergeRefName
+			commit {abbreviatedOid url}
+		  }
+		}
+	  }
+`
+}
@@ -0,0 +1,130 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	githubql "github.com/cli/shurcooL-graphql"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github/graphql"
+	"github.com/hbk619/gh-peruse/internal/requests"
+)
+
+type IssuesClient interface {
+	GetRepoDetails() (repository.Repository, error)
+	GetIssue(repo *git.Repo, number int) (*git.IssueDetails, error)
+	Comment(contents string, issueId string) error
+	React(comment *git.Comment, content string) error
+	RemoveReaction(comment *git.Comment, content string) error
+	Close(issueId string, reason string) error
+	Reopen(issueId string) error
+}
+
+type IssueClient struct {
+	graphQLClient requests.GraphQLClient
+}
+
+func NewIssueClient(apiClient requests.GraphQLClient) *IssueClient {
+	return &IssueClient{
+		graphQLClient: apiClient,
+	}
+}
+
+func (gh *IssueClient) GetRepoDetails() (repository.Repository, error) {
+	return repository.Current()
+}
+
+// GetIssue fetches the issue and all of its comments, a page of 100 at a time
+func (gh *IssueClient) GetIssue(repo *git.Repo, number int) (*git.IssueDetails, error) {
+	var cursor *githubql.String
+	var issue git.Issue
+	var viewer string
+	var comments []git.Comment
+	for {
+		variables := map[string]interface{}{
+			"Number":   githubql.Int(number),
+			"Owner":    githubql.String(repo.Owner),
+			"RepoName": githubql.String(repo.Name),
+			"Cursor":   cursor,
+		}
+		var response git.GitHubData
+		err := gh.graphQLClient.Do(graphql.IssueQuery, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch issue %w", err)
+		}
+		page := response.Repository.Issue.Comments
+		if cursor == nil {
+			issue = response.Repository.Issue
+			viewer = response.Viewer.Login
+		}
+		comments = append(comments, page.Nodes...)
+
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		cursor = githubql.NewString(githubql.String(page.PageInfo.EndCursor))
+	}
+	if issue.Id == "" {
+		return nil, fmt.Errorf("issue %d not found", number)
+	}
+
+	mainThread := git.File{FullPath: MainThread, FileName: MainThread}
+	commentList := []git.Comment{{
+		Author:    issue.Author,
+		Body:      issue.Body,
+		File:      mainThread,
+		CreatedAt: issue.CreatedAt,
+		Id:        issue.Id,
+		Kind:      git.IssueKind,
+		Reactions: issue.Reactions,
+	}}
+	for _, comment := range comments {
+		comment.File = mainThread
+		comment.Kind = git.IssueCommentKind
+		commentList = append(commentList, comment)
+	}
+
+	return &git.IssueDetails{
+		Comments: commentList,
+		Title:    issue.Title,
+		Id:       issue.Id,
+		Viewer:   viewer,
+		Closed:   issue.State == "CLOSED",
+	}, nil
+}
+
+func (gh *IssueClient) Comment(contents string, issueId string) error {
+	variables := map[string]interface{}{
+		"subjectId": issueId,
+		"body":      contents,
+	}
+	return gh.graphQLClient.Do(graphql.AddIssueCommentMutation, variables, nil)
+}
+
+func (gh *IssueClient) React(comment *git.Comment, content string) error {
+	return react(gh.graphQLClient, graphql.AddReactionMutation, comment, content)
+}
+
+func (gh *IssueClient) RemoveReaction(comment *git.Comment, content string) error {
+	return react(gh.graphQLClient, graphql.RemoveReactionMutation, comment, content)
+}
+
+// Close closes the issue, reason is COMPLETED or NOT_PLANNED
+func (gh *IssueClient) Close(issueId string, reason string) error {
+	if reason != "COMPLETED" && reason != "NOT_PLANNED" {
+		return errors.New("an issue can only be closed as completed or not planned")
+	}
+	variables := map[string]interface{}{
+		"issueId":     issueId,
+		"stateReason": reason,
+	}
+	return gh.graphQLClient.Do(graphql.CloseIssueMutation, variables, nil)
+}
+
+func (gh *IssueClient) Reopen(issueId string) error {
+	variables := map[string]interface{}{
+		"issueId": issueId,
+	}
+	return gh.graphQLClient.Do(graphql.ReopenIssueMutation, variables, nil)
+}
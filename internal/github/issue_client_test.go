@@ -0,0 +1,202 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	githubql "github.com/cli/shurcooL-graphql"
+	"github.com/golang/mock/gomock"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github/graphql"
+	mock_requests "github.com/hbk619/gh-peruse/internal/requests/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type IssueClientTestSuite struct {
+	suite.Suite
+	mockGraphQL *mock_requests.MockGraphQLClient
+	ctrl        *gomock.Controller
+	repo        *git.Repo
+	issues      *IssueClient
+}
+
+func (suite *IssueClientTestSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockGraphQL = mock_requests.NewMockGraphQLClient(suite.ctrl)
+	suite.repo = &git.Repo{
+		Owner: "luigi",
+		Name:  "castle",
+	}
+	suite.issues = NewIssueClient(suite.mockGraphQL)
+}
+
+func (suite *IssueClientTestSuite) expectPage(cursor *githubql.String, page string) {
+	variables := map[string]interface{}{
+		"Number":   githubql.Int(7),
+		"Owner":    githubql.String("luigi"),
+		"RepoName": githubql.String("castle"),
+		"Cursor":   cursor,
+	}
+	suite.mockGraphQL.EXPECT().
+		Do(graphql.IssueQuery, variables, gomock.Any()).
+		DoAndReturn(func(query string, variables map[string]interface{}, response interface{}) error {
+			gr := GraphQLResponse{Data: response}
+			err := json.Unmarshal([]byte(page), &gr)
+			suite.NoError(err)
+			return nil
+		})
+}
+
+func (suite *IssueClientTestSuite) TestGetIssue_reads_every_page() {
+	suite.expectPage(nil, `{
+  "data": {
+    "viewer": {"login": "mario"},
+    "repository": {
+      "issue": {
+        "id": "I_kwDOA",
+        "number": 7,
+        "title": "The princess is in another castle",
+        "body": "She is not here",
+        "state": "OPEN",
+        "author": {"login": "toad"},
+        "createdAt": "2024-01-01T10:00:00Z",
+        "reactionGroups": [{"content": "EYES", "users": {"totalCount": 2}, "viewerHasReacted": false}],
+        "comments": {
+          "pageInfo": {"hasNextPage": true, "endCursor": "Y3Vyc29y"},
+          "nodes": [{"id": "IC_1", "body": "Which castle?", "author": {"login": "mario"}, "createdAt": "2024-01-02T10:00:00Z"}]
+        }
+      }
+    }
+  }
+}`)
+	suite.expectPage(githubql.NewString("Y3Vyc29y"), `{
+  "data": {
+    "viewer": {"login": "mario"},
+    "repository": {
+      "issue": {
+        "id": "I_kwDOA",
+        "number": 7,
+        "title": "The princess is in another castle",
+        "body": "She is not here",
+        "state": "OPEN",
+        "author": {"login": "toad"},
+        "createdAt": "2024-01-01T10:00:00Z",
+        "comments": {
+          "pageInfo": {"hasNextPage": false, "endCursor": "ZW5k"},
+          "nodes": [{"id": "IC_2", "body": "The next one", "author": {"login": "toad"}, "createdAt": "2024-01-03T10:00:00Z"}]
+        }
+      }
+    }
+  }
+}`)
+
+	issue, err := suite.issues.GetIssue(suite.repo, 7)
+	suite.NoError(err)
+	mainThread := git.File{FullPath: MainThread, FileName: MainThread}
+	suite.Equal(&git.IssueDetails{
+		Comments: []git.Comment{
+			{
+				Id:        "I_kwDOA",
+				Body:      "She is not here",
+				Author:    git.Author{Login: "toad"},
+				CreatedAt: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+				File:      mainThread,
+				Kind:      git.IssueKind,
+				Reactions: []git.Reaction{{Content: "EYES", Users: git.ReactionUsers{TotalCount: 2}}},
+			},
+			{
+				Id:        "IC_1",
+				Body:      "Which castle?",
+				Author:    git.Author{Login: "mario"},
+				CreatedAt: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+				File:      mainThread,
+				Kind:      git.IssueCommentKind,
+			},
+			{
+				Id:        "IC_2",
+				Body:      "The next one",
+				Author:    git.Author{Login: "toad"},
+				CreatedAt: time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC),
+				File:      mainThread,
+				Kind:      git.IssueCommentKind,
+			},
+		},
+		Title:  "The princess is in another castle",
+		Id:     "I_kwDOA",
+		Viewer: "mario",
+	}, issue)
+}
+
+func (suite *IssueClientTestSuite) TestGetIssue_closed() {
+	suite.expectPage(nil, `{
+  "data": {
+    "repository": {
+      "issue": {"id": "I_kwDOA", "title": "Fixed", "state": "CLOSED", "comments": {"nodes": []}}
+    }
+  }
+}`)
+
+	issue, err := suite.issues.GetIssue(suite.repo, 7)
+	suite.NoError(err)
+	suite.True(issue.Closed)
+	suite.Len(issue.Comments, 1)
+}
+
+func (suite *IssueClientTestSuite) TestGetIssue_not_found() {
+	suite.expectPage(nil, `{"data": {"repository": {}}}`)
+
+	issue, err := suite.issues.GetIssue(suite.repo, 7)
+	suite.EqualError(err, "issue 7 not found")
+	suite.Nil(issue)
+}
+
+func (suite *IssueClientTestSuite) TestGetIssue_has_error() {
+	suite.mockGraphQL.EXPECT().Do(graphql.IssueQuery, gomock.Any(), gomock.Any()).Return(errors.New("bad gateway"))
+
+	issue, err := suite.issues.GetIssue(suite.repo, 7)
+	suite.EqualError(err, "failed to fetch issue bad gateway")
+	suite.Nil(issue)
+}
+
+func (suite *IssueClientTestSuite) TestComment() {
+	variables := map[string]interface{}{
+		"subjectId": "I_kwDOA",
+		"body":      "On it",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.AddIssueCommentMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.issues.Comment("On it", "I_kwDOA")
+	suite.NoError(err)
+}
+
+func (suite *IssueClientTestSuite) TestClose() {
+	variables := map[string]interface{}{
+		"issueId":     "I_kwDOA",
+		"stateReason": "NOT_PLANNED",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.CloseIssueMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.issues.Close("I_kwDOA", "NOT_PLANNED")
+	suite.NoError(err)
+}
+
+func (suite *IssueClientTestSuite) TestClose_unknown_reason() {
+	err := suite.issues.Close("I_kwDOA", "DUPLICATE")
+	suite.EqualError(err, "an issue can only be closed as completed or not planned")
+}
+
+func (suite *IssueClientTestSuite) TestReopen() {
+	variables := map[string]interface{}{
+		"issueId": "I_kwDOA",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.ReopenIssueMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.issues.Reopen("I_kwDOA")
+	suite.NoError(err)
+}
+
+func TestIssueClientTestSuite(t *testing.T) {
+	suite.Run(t, new(IssueClientTestSuite))
+}
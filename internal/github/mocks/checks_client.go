@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/github/checks_client.go
+
+// Package mock_github is a generated GoMock package.
+package mock_github
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	git "github.com/hbk619/gh-peruse/internal/git"
+)
+
+// MockChecksClient is a mock of ChecksClient interface.
+type MockChecksClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockChecksClientMockRecorder
+}
+
+// MockChecksClientMockRecorder is the mock recorder for MockChecksClient.
+type MockChecksClientMockRecorder struct {
+	mock *MockChecksClient
+}
+
+// NewMockChecksClient creates a new mock instance.
+func NewMockChecksClient(ctrl *gomock.Controller) *MockChecksClient {
+	mock := &MockChecksClient{ctrl: ctrl}
+	mock.recorder = &MockChecksClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecksClient) EXPECT() *MockChecksClientMockRecorder {
+	return m.recorder
+}
+
+// GetChecks mocks base method.
+func (m *MockChecksClient) GetChecks(repo *git.Repo) ([]git.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecks", repo)
+	ret0, _ := ret[0].([]git.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChecks indicates an expected call of GetChecks.
+func (mr *MockChecksClientMockRecorder) GetChecks(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecks", reflect.TypeOf((*MockChecksClient)(nil).GetChecks), repo)
+}
+
+// GetJobLog mocks base method.
+func (m *MockChecksClient) GetJobLog(repo *git.Repo, jobId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobLog", repo, jobId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobLog indicates an expected call of GetJobLog.
+func (mr *MockChecksClientMockRecorder) GetJobLog(repo, jobId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobLog", reflect.TypeOf((*MockChecksClient)(nil).GetJobLog), repo, jobId)
+}
@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/github/discussion_client.go
+
+// Package mock_github is a generated GoMock package.
+package mock_github
+
+import (
+	reflect "reflect"
+
+	repository "github.com/cli/go-gh/v2/pkg/repository"
+	gomock "github.com/golang/mock/gomock"
+	git "github.com/hbk619/gh-peruse/internal/git"
+)
+
+// MockDiscussionsClient is a mock of DiscussionsClient interface.
+type MockDiscussionsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiscussionsClientMockRecorder
+}
+
+// MockDiscussionsClientMockRecorder is the mock recorder for MockDiscussionsClient.
+type MockDiscussionsClientMockRecorder struct {
+	mock *MockDiscussionsClient
+}
+
+// NewMockDiscussionsClient creates a new mock instance.
+func NewMockDiscussionsClient(ctrl *gomock.Controller) *MockDiscussionsClient {
+	mock := &MockDiscussionsClient{ctrl: ctrl}
+	mock.recorder = &MockDiscussionsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiscussionsClient) EXPECT() *MockDiscussionsClientMockRecorder {
+	return m.recorder
+}
+
+// GetDiscussion mocks base method.
+func (m *MockDiscussionsClient) GetDiscussion(repo *git.Repo, number int) (*git.DiscussionDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscussion", repo, number)
+	ret0, _ := ret[0].(*git.DiscussionDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiscussion indicates an expected call of GetDiscussion.
+func (mr *MockDiscussionsClientMockRecorder) GetDiscussion(repo, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscussion", reflect.TypeOf((*MockDiscussionsClient)(nil).GetDiscussion), repo, number)
+}
+
+// GetRepoDetails mocks base method.
+func (m *MockDiscussionsClient) GetRepoDetails() (repository.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoDetails")
+	ret0, _ := ret[0].(repository.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepoDetails indicates an expected call of GetRepoDetails.
+func (mr *MockDiscussionsClientMockRecorder) GetRepoDetails() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoDetails", reflect.TypeOf((*MockDiscussionsClient)(nil).GetRepoDetails))
+}
+
+// MarkAnswer mocks base method.
+func (m *MockDiscussionsClient) MarkAnswer(comment *git.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAnswer", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAnswer indicates an expected call of MarkAnswer.
+func (mr *MockDiscussionsClientMockRecorder) MarkAnswer(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAnswer", reflect.TypeOf((*MockDiscussionsClient)(nil).MarkAnswer), comment)
+}
+
+// React mocks base method.
+func (m *MockDiscussionsClient) React(comment *git.Comment, content string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "React", comment, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// React indicates an expected call of React.
+func (mr *MockDiscussionsClientMockRecorder) React(comment, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "React", reflect.TypeOf((*MockDiscussionsClient)(nil).React), comment, content)
+}
+
+// RemoveReaction mocks base method.
+func (m *MockDiscussionsClient) RemoveReaction(comment *git.Comment, content string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", comment, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockDiscussionsClientMockRecorder) RemoveReaction(comment, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockDiscussionsClient)(nil).RemoveReaction), comment, content)
+}
+
+// Reply mocks base method.
+func (m *MockDiscussionsClient) Reply(contents string, comment *git.Comment, discussionId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reply", contents, comment, discussionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reply indicates an expected call of Reply.
+func (mr *MockDiscussionsClientMockRecorder) Reply(contents, comment, discussionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reply", reflect.TypeOf((*MockDiscussionsClient)(nil).Reply), contents, comment, discussionId)
+}
+
+// UnmarkAnswer mocks base method.
+func (m *MockDiscussionsClient) UnmarkAnswer(comment *git.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmarkAnswer", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmarkAnswer indicates an expected call of UnmarkAnswer.
+func (mr *MockDiscussionsClientMockRecorder) UnmarkAnswer(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarkAnswer", reflect.TypeOf((*MockDiscussionsClient)(nil).UnmarkAnswer), comment)
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	git "github.com/cli/cli/v2/git"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -34,6 +35,20 @@ func (m *MockGitClient) EXPECT() *MockGitClientMockRecorder {
 	return m.recorder
 }
 
+// CheckoutBranch mocks base method.
+func (m *MockGitClient) CheckoutBranch(ctx context.Context, branch string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckoutBranch", ctx, branch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckoutBranch indicates an expected call of CheckoutBranch.
+func (mr *MockGitClientMockRecorder) CheckoutBranch(ctx, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckoutBranch", reflect.TypeOf((*MockGitClient)(nil).CheckoutBranch), ctx, branch)
+}
+
 // CurrentBranch mocks base method.
 func (m *MockGitClient) CurrentBranch(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -48,3 +63,193 @@ func (mr *MockGitClientMockRecorder) CurrentBranch(ctx interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentBranch", reflect.TypeOf((*MockGitClient)(nil).CurrentBranch), ctx)
 }
+
+// Fetch mocks base method.
+func (m *MockGitClient) Fetch(ctx context.Context, remote, refspec string, mods ...git.CommandModifier) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, remote, refspec}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Fetch", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fetch indicates an expected call of Fetch.
+func (mr *MockGitClientMockRecorder) Fetch(ctx, remote, refspec interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, remote, refspec}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockGitClient)(nil).Fetch), varargs...)
+}
+
+// HasLocalBranch mocks base method.
+func (m *MockGitClient) HasLocalBranch(ctx context.Context, branch string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasLocalBranch", ctx, branch)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasLocalBranch indicates an expected call of HasLocalBranch.
+func (mr *MockGitClientMockRecorder) HasLocalBranch(ctx, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasLocalBranch", reflect.TypeOf((*MockGitClient)(nil).HasLocalBranch), ctx, branch)
+}
+
+// LastCommit mocks base method.
+func (m *MockGitClient) LastCommit(ctx context.Context) (*git.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastCommit", ctx)
+	ret0, _ := ret[0].(*git.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastCommit indicates an expected call of LastCommit.
+func (mr *MockGitClientMockRecorder) LastCommit(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastCommit", reflect.TypeOf((*MockGitClient)(nil).LastCommit), ctx)
+}
+
+// Pull mocks base method.
+func (m *MockGitClient) Pull(ctx context.Context, remote, branch string, mods ...git.CommandModifier) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, remote, branch}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Pull", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pull indicates an expected call of Pull.
+func (mr *MockGitClientMockRecorder) Pull(ctx, remote, branch interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, remote, branch}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockGitClient)(nil).Pull), varargs...)
+}
+
+// Push mocks base method.
+func (m *MockGitClient) Push(ctx context.Context, remote, ref string, mods ...git.CommandModifier) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, remote, ref}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Push", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockGitClientMockRecorder) Push(ctx, remote, ref interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, remote, ref}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockGitClient)(nil).Push), varargs...)
+}
+
+// PushRevision mocks base method.
+func (m *MockGitClient) PushRevision(ctx context.Context, branch string) (git.RemoteTrackingRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushRevision", ctx, branch)
+	ret0, _ := ret[0].(git.RemoteTrackingRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PushRevision indicates an expected call of PushRevision.
+func (mr *MockGitClientMockRecorder) PushRevision(ctx, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushRevision", reflect.TypeOf((*MockGitClient)(nil).PushRevision), ctx, branch)
+}
+
+// ReadBranchConfig mocks base method.
+func (m *MockGitClient) ReadBranchConfig(ctx context.Context, branch string) (git.BranchConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadBranchConfig", ctx, branch)
+	ret0, _ := ret[0].(git.BranchConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadBranchConfig indicates an expected call of ReadBranchConfig.
+func (mr *MockGitClientMockRecorder) ReadBranchConfig(ctx, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadBranchConfig", reflect.TypeOf((*MockGitClient)(nil).ReadBranchConfig), ctx, branch)
+}
+
+// Remotes mocks base method.
+func (m *MockGitClient) Remotes(ctx context.Context) (git.RemoteSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remotes", ctx)
+	ret0, _ := ret[0].(git.RemoteSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remotes indicates an expected call of Remotes.
+func (mr *MockGitClientMockRecorder) Remotes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remotes", reflect.TypeOf((*MockGitClient)(nil).Remotes), ctx)
+}
+
+// SetBranchConfig mocks base method.
+func (m *MockGitClient) SetBranchConfig(ctx context.Context, branch, name, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBranchConfig", ctx, branch, name, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBranchConfig indicates an expected call of SetBranchConfig.
+func (mr *MockGitClientMockRecorder) SetBranchConfig(ctx, branch, name, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBranchConfig", reflect.TypeOf((*MockGitClient)(nil).SetBranchConfig), ctx, branch, name, value)
+}
+
+// ShowRefs mocks base method.
+func (m *MockGitClient) ShowRefs(ctx context.Context, refs []string) ([]git.Ref, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShowRefs", ctx, refs)
+	ret0, _ := ret[0].([]git.Ref)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShowRefs indicates an expected call of ShowRefs.
+func (mr *MockGitClientMockRecorder) ShowRefs(ctx, refs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShowRefs", reflect.TypeOf((*MockGitClient)(nil).ShowRefs), ctx, refs)
+}
+
+// ToplevelDir mocks base method.
+func (m *MockGitClient) ToplevelDir(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToplevelDir", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToplevelDir indicates an expected call of ToplevelDir.
+func (mr *MockGitClientMockRecorder) ToplevelDir(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToplevelDir", reflect.TypeOf((*MockGitClient)(nil).ToplevelDir), ctx)
+}
+
+// TrackedChangeCount mocks base method.
+func (m *MockGitClient) TrackedChangeCount(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackedChangeCount", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrackedChangeCount indicates an expected call of TrackedChangeCount.
+func (mr *MockGitClientMockRecorder) TrackedChangeCount(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackedChangeCount", reflect.TypeOf((*MockGitClient)(nil).TrackedChangeCount), ctx)
+}
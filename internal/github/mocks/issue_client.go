@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/github/issue_client.go
+
+// Package mock_github is a generated GoMock package.
+package mock_github
+
+import (
+	reflect "reflect"
+
+	repository "github.com/cli/go-gh/v2/pkg/repository"
+	gomock "github.com/golang/mock/gomock"
+	git "github.com/hbk619/gh-peruse/internal/git"
+)
+
+// MockIssuesClient is a mock of IssuesClient interface.
+type MockIssuesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssuesClientMockRecorder
+}
+
+// MockIssuesClientMockRecorder is the mock recorder for MockIssuesClient.
+type MockIssuesClientMockRecorder struct {
+	mock *MockIssuesClient
+}
+
+// NewMockIssuesClient creates a new mock instance.
+func NewMockIssuesClient(ctrl *gomock.Controller) *MockIssuesClient {
+	mock := &MockIssuesClient{ctrl: ctrl}
+	mock.recorder = &MockIssuesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssuesClient) EXPECT() *MockIssuesClientMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockIssuesClient) Close(issueId, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", issueId, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIssuesClientMockRecorder) Close(issueId, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIssuesClient)(nil).Close), issueId, reason)
+}
+
+// Comment mocks base method.
+func (m *MockIssuesClient) Comment(contents, issueId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Comment", contents, issueId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Comment indicates an expected call of Comment.
+func (mr *MockIssuesClientMockRecorder) Comment(contents, issueId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Comment", reflect.TypeOf((*MockIssuesClient)(nil).Comment), contents, issueId)
+}
+
+// GetIssue mocks base method.
+func (m *MockIssuesClient) GetIssue(repo *git.Repo, number int) (*git.IssueDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIssue", repo, number)
+	ret0, _ := ret[0].(*git.IssueDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIssue indicates an expected call of GetIssue.
+func (mr *MockIssuesClientMockRecorder) GetIssue(repo, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssue", reflect.TypeOf((*MockIssuesClient)(nil).GetIssue), repo, number)
+}
+
+// GetRepoDetails mocks base method.
+func (m *MockIssuesClient) GetRepoDetails() (repository.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoDetails")
+	ret0, _ := ret[0].(repository.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepoDetails indicates an expected call of GetRepoDetails.
+func (mr *MockIssuesClientMockRecorder) GetRepoDetails() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoDetails", reflect.TypeOf((*MockIssuesClient)(nil).GetRepoDetails))
+}
+
+// React mocks base method.
+func (m *MockIssuesClient) React(comment *git.Comment, content string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "React", comment, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// React indicates an expected call of React.
+func (mr *MockIssuesClientMockRecorder) React(comment, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "React", reflect.TypeOf((*MockIssuesClient)(nil).React), comment, content)
+}
+
+// RemoveReaction mocks base method.
+func (m *MockIssuesClient) RemoveReaction(comment *git.Comment, content string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", comment, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockIssuesClientMockRecorder) RemoveReaction(comment, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockIssuesClient)(nil).RemoveReaction), comment, content)
+}
+
+// Reopen mocks base method.
+func (m *MockIssuesClient) Reopen(issueId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reopen", issueId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reopen indicates an expected call of Reopen.
+func (mr *MockIssuesClientMockRecorder) Reopen(issueId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reopen", reflect.TypeOf((*MockIssuesClient)(nil).Reopen), issueId)
+}
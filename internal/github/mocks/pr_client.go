@@ -35,6 +35,92 @@ func (m *MockPullRequestClient) EXPECT() *MockPullRequestClientMockRecorder {
 	return m.recorder
 }
 
+// AddAssignees mocks base method.
+func (m *MockPullRequestClient) AddAssignees(prId string, logins []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAssignees", prId, logins)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAssignees indicates an expected call of AddAssignees.
+func (mr *MockPullRequestClientMockRecorder) AddAssignees(prId, logins interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAssignees", reflect.TypeOf((*MockPullRequestClient)(nil).AddAssignees), prId, logins)
+}
+
+// AddLabels mocks base method.
+func (m *MockPullRequestClient) AddLabels(repo *git.Repo, prId string, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLabels", repo, prId, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLabels indicates an expected call of AddLabels.
+func (mr *MockPullRequestClientMockRecorder) AddLabels(repo, prId, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLabels", reflect.TypeOf((*MockPullRequestClient)(nil).AddLabels), repo, prId, names)
+}
+
+// Checkout mocks base method.
+func (m *MockPullRequestClient) Checkout(repo *git.Repo, force bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", repo, force)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockPullRequestClientMockRecorder) Checkout(repo, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockPullRequestClient)(nil).Checkout), repo, force)
+}
+
+// CreatePR mocks base method.
+func (m *MockPullRequestClient) CreatePR(newPR *git.NewPR) (*git.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePR", newPR)
+	ret0, _ := ret[0].(*git.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePR indicates an expected call of CreatePR.
+func (mr *MockPullRequestClientMockRecorder) CreatePR(newPR interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePR", reflect.TypeOf((*MockPullRequestClient)(nil).CreatePR), newPR)
+}
+
+// Delete mocks base method.
+func (m *MockPullRequestClient) Delete(comment *git.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPullRequestClientMockRecorder) Delete(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPullRequestClient)(nil).Delete), comment)
+}
+
+// DeleteBranch mocks base method.
+func (m *MockPullRequestClient) DeleteBranch(ref git.Ref) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBranch", ref)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBranch indicates an expected call of DeleteBranch.
+func (mr *MockPullRequestClientMockRecorder) DeleteBranch(ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBranch", reflect.TypeOf((*MockPullRequestClient)(nil).DeleteBranch), ref)
+}
+
 // DetectCurrentPR mocks base method.
 func (m *MockPullRequestClient) DetectCurrentPR(repo *git.Repo) (int, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +136,63 @@ func (mr *MockPullRequestClientMockRecorder) DetectCurrentPR(repo interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectCurrentPR", reflect.TypeOf((*MockPullRequestClient)(nil).DetectCurrentPR), repo)
 }
 
+// Edit mocks base method.
+func (m *MockPullRequestClient) Edit(contents string, comment *git.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Edit", contents, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Edit indicates an expected call of Edit.
+func (mr *MockPullRequestClientMockRecorder) Edit(contents, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Edit", reflect.TypeOf((*MockPullRequestClient)(nil).Edit), contents, comment)
+}
+
+// EditTitle mocks base method.
+func (m *MockPullRequestClient) EditTitle(prId, title string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditTitle", prId, title)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditTitle indicates an expected call of EditTitle.
+func (mr *MockPullRequestClientMockRecorder) EditTitle(prId, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditTitle", reflect.TypeOf((*MockPullRequestClient)(nil).EditTitle), prId, title)
+}
+
+// EnableAutoMerge mocks base method.
+func (m *MockPullRequestClient) EnableAutoMerge(prId, method string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableAutoMerge", prId, method)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableAutoMerge indicates an expected call of EnableAutoMerge.
+func (mr *MockPullRequestClientMockRecorder) EnableAutoMerge(prId, method interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableAutoMerge", reflect.TypeOf((*MockPullRequestClient)(nil).EnableAutoMerge), prId, method)
+}
+
+// FindPRForBranch mocks base method.
+func (m *MockPullRequestClient) FindPRForBranch(repo *git.Repo, branch string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPRForBranch", repo, branch)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPRForBranch indicates an expected call of FindPRForBranch.
+func (mr *MockPullRequestClientMockRecorder) FindPRForBranch(repo, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPRForBranch", reflect.TypeOf((*MockPullRequestClient)(nil).FindPRForBranch), repo, branch)
+}
+
 // GetCommentCountForOwnedPRs mocks base method.
 func (m *MockPullRequestClient) GetCommentCountForOwnedPRs(repo *git.Repo) (map[int]int, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +208,36 @@ func (mr *MockPullRequestClientMockRecorder) GetCommentCountForOwnedPRs(repo int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentCountForOwnedPRs", reflect.TypeOf((*MockPullRequestClient)(nil).GetCommentCountForOwnedPRs), repo)
 }
 
+// GetMergeState mocks base method.
+func (m *MockPullRequestClient) GetMergeState(repo *git.Repo) (*git.MergeState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMergeState", repo)
+	ret0, _ := ret[0].(*git.MergeState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMergeState indicates an expected call of GetMergeState.
+func (mr *MockPullRequestClientMockRecorder) GetMergeState(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMergeState", reflect.TypeOf((*MockPullRequestClient)(nil).GetMergeState), repo)
+}
+
+// GetMetadata mocks base method.
+func (m *MockPullRequestClient) GetMetadata(repo *git.Repo) (*git.PRMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadata", repo)
+	ret0, _ := ret[0].(*git.PRMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetadata indicates an expected call of GetMetadata.
+func (mr *MockPullRequestClientMockRecorder) GetMetadata(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockPullRequestClient)(nil).GetMetadata), repo)
+}
+
 // GetPRDetails mocks base method.
 func (m *MockPullRequestClient) GetPRDetails(repo *git.Repo, verbose bool) (*git.PR, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +253,21 @@ func (mr *MockPullRequestClientMockRecorder) GetPRDetails(repo, verbose interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPRDetails", reflect.TypeOf((*MockPullRequestClient)(nil).GetPRDetails), repo, verbose)
 }
 
+// GetPRTimeline mocks base method.
+func (m *MockPullRequestClient) GetPRTimeline(repo *git.Repo, verbose bool) (*git.PR, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPRTimeline", repo, verbose)
+	ret0, _ := ret[0].(*git.PR)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPRTimeline indicates an expected call of GetPRTimeline.
+func (mr *MockPullRequestClientMockRecorder) GetPRTimeline(repo, verbose interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPRTimeline", reflect.TypeOf((*MockPullRequestClient)(nil).GetPRTimeline), repo, verbose)
+}
+
 // GetRepoDetails mocks base method.
 func (m *MockPullRequestClient) GetRepoDetails() (repository.Repository, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +283,165 @@ func (mr *MockPullRequestClientMockRecorder) GetRepoDetails() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoDetails", reflect.TypeOf((*MockPullRequestClient)(nil).GetRepoDetails))
 }
 
+// ListPRs mocks base method.
+func (m *MockPullRequestClient) ListPRs(repo *git.Repo, filter git.PRFilter) ([]git.PRSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPRs", repo, filter)
+	ret0, _ := ret[0].([]git.PRSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPRs indicates an expected call of ListPRs.
+func (mr *MockPullRequestClientMockRecorder) ListPRs(repo, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPRs", reflect.TypeOf((*MockPullRequestClient)(nil).ListPRs), repo, filter)
+}
+
+// LocalHead mocks base method.
+func (m *MockPullRequestClient) LocalHead() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LocalHead")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LocalHead indicates an expected call of LocalHead.
+func (mr *MockPullRequestClientMockRecorder) LocalHead() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalHead", reflect.TypeOf((*MockPullRequestClient)(nil).LocalHead))
+}
+
+// LocalPath mocks base method.
+func (m *MockPullRequestClient) LocalPath(repo *git.Repo, path string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LocalPath", repo, path)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LocalPath indicates an expected call of LocalPath.
+func (mr *MockPullRequestClientMockRecorder) LocalPath(repo, path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalPath", reflect.TypeOf((*MockPullRequestClient)(nil).LocalPath), repo, path)
+}
+
+// Merge mocks base method.
+func (m *MockPullRequestClient) Merge(prId, method string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", prId, method)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockPullRequestClientMockRecorder) Merge(prId, method interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockPullRequestClient)(nil).Merge), prId, method)
+}
+
+// NewPR mocks base method.
+func (m *MockPullRequestClient) NewPR(repo *git.Repo) (*git.NewPR, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewPR", repo)
+	ret0, _ := ret[0].(*git.NewPR)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewPR indicates an expected call of NewPR.
+func (mr *MockPullRequestClientMockRecorder) NewPR(repo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewPR", reflect.TypeOf((*MockPullRequestClient)(nil).NewPR), repo)
+}
+
+// PushBranch mocks base method.
+func (m *MockPullRequestClient) PushBranch(repo *git.Repo, branch string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushBranch", repo, branch)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PushBranch indicates an expected call of PushBranch.
+func (mr *MockPullRequestClientMockRecorder) PushBranch(repo, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushBranch", reflect.TypeOf((*MockPullRequestClient)(nil).PushBranch), repo, branch)
+}
+
+// React mocks base method.
+func (m *MockPullRequestClient) React(comment *git.Comment, content string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "React", comment, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// React indicates an expected call of React.
+func (mr *MockPullRequestClientMockRecorder) React(comment, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "React", reflect.TypeOf((*MockPullRequestClient)(nil).React), comment, content)
+}
+
+// RemoveAssignees mocks base method.
+func (m *MockPullRequestClient) RemoveAssignees(prId string, logins []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAssignees", prId, logins)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAssignees indicates an expected call of RemoveAssignees.
+func (mr *MockPullRequestClientMockRecorder) RemoveAssignees(prId, logins interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAssignees", reflect.TypeOf((*MockPullRequestClient)(nil).RemoveAssignees), prId, logins)
+}
+
+// RemoveLabels mocks base method.
+func (m *MockPullRequestClient) RemoveLabels(repo *git.Repo, prId string, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLabels", repo, prId, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveLabels indicates an expected call of RemoveLabels.
+func (mr *MockPullRequestClientMockRecorder) RemoveLabels(repo, prId, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLabels", reflect.TypeOf((*MockPullRequestClient)(nil).RemoveLabels), repo, prId, names)
+}
+
+// RemoveReaction mocks base method.
+func (m *MockPullRequestClient) RemoveReaction(comment *git.Comment, content string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", comment, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockPullRequestClientMockRecorder) RemoveReaction(comment, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockPullRequestClient)(nil).RemoveReaction), comment, content)
+}
+
+// RemoveReviewers mocks base method.
+func (m *MockPullRequestClient) RemoveReviewers(repo *git.Repo, reviewers []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReviewers", repo, reviewers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReviewers indicates an expected call of RemoveReviewers.
+func (mr *MockPullRequestClientMockRecorder) RemoveReviewers(repo, reviewers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReviewers", reflect.TypeOf((*MockPullRequestClient)(nil).RemoveReviewers), repo, reviewers)
+}
+
 // Reply mocks base method.
 func (m *MockPullRequestClient) Reply(contents string, comment *git.Comment, prId string) error {
 	m.ctrl.T.Helper()
@@ -109,6 +456,20 @@ func (mr *MockPullRequestClientMockRecorder) Reply(contents, comment, prId inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reply", reflect.TypeOf((*MockPullRequestClient)(nil).Reply), contents, comment, prId)
 }
 
+// RequestReviewers mocks base method.
+func (m *MockPullRequestClient) RequestReviewers(prId string, reviewers []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestReviewers", prId, reviewers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestReviewers indicates an expected call of RequestReviewers.
+func (mr *MockPullRequestClientMockRecorder) RequestReviewers(prId, reviewers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestReviewers", reflect.TypeOf((*MockPullRequestClient)(nil).RequestReviewers), prId, reviewers)
+}
+
 // Resolve mocks base method.
 func (m *MockPullRequestClient) Resolve(comment *git.Comment) error {
 	m.ctrl.T.Helper()
@@ -122,3 +483,17 @@ func (mr *MockPullRequestClientMockRecorder) Resolve(comment interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockPullRequestClient)(nil).Resolve), comment)
 }
+
+// SetDraft mocks base method.
+func (m *MockPullRequestClient) SetDraft(prId string, draft bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDraft", prId, draft)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDraft indicates an expected call of SetDraft.
+func (mr *MockPullRequestClientMockRecorder) SetDraft(prId, draft interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDraft", reflect.TypeOf((*MockPullRequestClient)(nil).SetDraft), prId, draft)
+}
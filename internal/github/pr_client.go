@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -19,11 +20,37 @@ import (
 
 type PullRequestClient interface {
 	DetectCurrentPR(repo *git.Repo) (int, error)
+	FindPRForBranch(repo *git.Repo, branch string) (int, error)
 	GetPRDetails(repo *git.Repo, verbose bool) (*git.PR, error)
+	GetPRTimeline(repo *git.Repo, verbose bool) (*git.PR, error)
 	GetRepoDetails() (repository.Repository, error)
 	Resolve(comment *git.Comment) error
 	Reply(contents string, comment *git.Comment, prId string) error
 	GetCommentCountForOwnedPRs(repo *git.Repo) (map[int]int, error)
+	Edit(contents string, comment *git.Comment) error
+	Delete(comment *git.Comment) error
+	React(comment *git.Comment, content string) error
+	RemoveReaction(comment *git.Comment, content string) error
+	GetMergeState(repo *git.Repo) (*git.MergeState, error)
+	Merge(prId string, method string) error
+	EnableAutoMerge(prId string, method string) error
+	DeleteBranch(ref git.Ref) error
+	ListPRs(repo *git.Repo, filter git.PRFilter) ([]git.PRSummary, error)
+	Checkout(repo *git.Repo, force bool) (string, error)
+	LocalPath(repo *git.Repo, path string) (string, error)
+	LocalHead() (string, error)
+	GetMetadata(repo *git.Repo) (*git.PRMetadata, error)
+	EditTitle(prId string, title string) error
+	AddLabels(repo *git.Repo, prId string, names []string) error
+	RemoveLabels(repo *git.Repo, prId string, names []string) error
+	RequestReviewers(prId string, reviewers []string) error
+	RemoveReviewers(repo *git.Repo, reviewers []string) error
+	AddAssignees(prId string, logins []string) error
+	RemoveAssignees(prId string, logins []string) error
+	SetDraft(prId string, draft bool) error
+	NewPR(repo *git.Repo) (*git.NewPR, error)
+	PushBranch(repo *git.Repo, branch string) (string, error)
+	CreatePR(newPR *git.NewPR) (*git.PullRequest, error)
 }
 
 type GetReviewCommentsQuery struct {
@@ -34,18 +61,22 @@ type GetReviewCommentsQuery struct {
 
 type PRClient struct {
 	graphQLClient requests.GraphQLClient
+	restClient    requests.RESTClient
 	gitClient     GitClient
 }
 
-func NewPRClient(apiClient requests.GraphQLClient, gitClient GitClient) *PRClient {
+func NewPRClient(apiClient requests.GraphQLClient, restClient requests.RESTClient, gitClient GitClient) *PRClient {
 	return &PRClient{
 		graphQLClient: apiClient,
+		restClient:    restClient,
 		gitClient:     gitClient,
 	}
 }
 
 const MainThread = "main thread"
 
+var ErrUncommittedChanges = errors.New("the working tree has uncommitted changes, commit or stash them or check out with force")
+
 var mergeStatuses = map[string]string{
 	"DIRTY":     "The merge commit cannot be cleanly created, try updating",
 	"UNKNOWN":   "The state cannot currently be determined",
@@ -133,6 +164,9 @@ func (gh *PRClient) GetPRDetails(repo *git.Repo, verbose bool) (*git.PR, error)
 		State:    gh.createState(verbose, &prDetails),
 		Title:    prDetails.Title,
 		Id:       prDetails.Id,
+		Viewer:   response.Viewer.Login,
+		HeadOid:  prDetails.HeadRefOid,
+		URL:      prDetails.URL,
 	}, nil
 }
 
@@ -148,6 +182,10 @@ func (gh *PRClient) createComments(response *git.PullRequest, verbose bool) []gi
 				FileName: MainThread,
 			},
 			CreatedAt: response.CreatedAt,
+			Id:        response.Id,
+			Kind:      git.PullRequestKind,
+			Reactions: response.Reactions,
+			URL:       response.URL,
 		})
 	}
 
@@ -157,6 +195,7 @@ func (gh *PRClient) createComments(response *git.PullRequest, verbose bool) []gi
 				FullPath: MainThread,
 				FileName: MainThread,
 			}
+			comment.Kind = git.IssueCommentKind
 			commentList = append(commentList, comment)
 		}
 	}
@@ -166,6 +205,7 @@ func (gh *PRClient) createComments(response *git.PullRequest, verbose bool) []gi
 				FullPath: MainThread,
 				FileName: MainThread,
 			}
+			comment.Kind = git.ReviewKind
 			commentList = append(commentList, comment)
 		}
 	}
@@ -191,7 +231,7 @@ func (gh *PRClient) createState(verbose bool, prDetails *git.PullRequest) git.St
 	if verbose {
 		reviewStatus := gh.getReviewStatuses(&prDetails.Reviews)
 		state = git.State{
-			Statuses:       prDetails.StatusCheckRollup.Contexts.Nodes,
+			Statuses:       normaliseStatuses(prDetails.StatusCheckRollup.Contexts.Nodes),
 			ConflictStatus: mergeStates[prDetails.Mergeable],
 			MergeStatus:    mergeStatuses[prDetails.MergeStateStatus],
 			Reviews:        reviewStatus,
@@ -213,6 +253,10 @@ func (gh *PRClient) getCommitDetails(commits *git.Commits) []git.Comment {
 				Body:      comment.Body,
 				Author:    comment.Author,
 				CreatedAt: comment.CreatedAt,
+				Id:        comment.Id,
+				Kind:      git.CommitCommentKind,
+				Reactions: comment.Reactions,
+				URL:       comment.URL,
 			}
 			allComments = append(allComments, localComment)
 		}
@@ -251,6 +295,7 @@ func (gh *PRClient) getThreadComments(graphQLData *git.PullRequest) []git.Commen
 			lines := strings.Split(comment.DiffHunk, "\n")
 			comment.LineContents = lines[len(lines)-1]
 			comment.Thread = git.Thread{ID: thread.ID, IsResolved: thread.IsResolved}
+			comment.Kind = git.ReviewCommentKind
 
 			threadComments = append(threadComments, comment)
 		}
@@ -261,32 +306,192 @@ func (gh *PRClient) getThreadComments(graphQLData *git.PullRequest) []git.Commen
 	return comments
 }
 
+// MultiplePRsError is returned when a branch has more than one PR and none is clearly the right one, or when none
+// come from Owner, the owner of the repository the branch is pushed to
+type MultiplePRsError struct {
+	Branch     string
+	Owner      string
+	Candidates []git.PRSummary
+}
+
+func (err *MultiplePRsError) Error() string {
+	return fmt.Sprintf("too many pull request found for %s", err.Branch)
+}
+
+// DetectCurrentPR finds the PR for the current branch
 func (gh *PRClient) DetectCurrentPR(repo *git.Repo) (int, error) {
 	branch, err := gh.gitClient.CurrentBranch(context.Background())
 	if err != nil {
 		return 0, fmt.Errorf("failed to get branch %w", err)
 	}
+	return gh.FindPRForBranch(repo, branch)
+}
 
+// FindPRForBranch finds the PR for a branch, preferring the open PR from the repository the branch is pushed to.
+// When that repository is known but none of the PRs come from it, or there are several to choose from, a
+// MultiplePRsError lists the candidates rather than guessing, as a branch name like fix is likely to be shared by
+// someone else's fork.
+func (gh *PRClient) FindPRForBranch(repo *git.Repo, branch string) (int, error) {
+	ctx := context.Background()
 	variables := map[string]interface{}{
 		"BranchName": branch,
 		"Owner":      repo.Owner,
 		"RepoName":   repo.Name,
 	}
 	var prList git.GitHubData
-	err = gh.graphQLClient.Do(graphql.GetPRForBranch, variables, &prList)
+	err := gh.graphQLClient.Do(graphql.GetPRForBranch, variables, &prList)
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch pr %w", err)
 	}
 
-	if len(prList.Repository.PullRequests.Nodes) == 0 {
+	prs := prList.Repository.PullRequests.Nodes
+	if len(prs) == 0 {
 		return 0, fmt.Errorf("no pull request found for %s", branch)
 	}
 
-	if len(prList.Repository.PullRequests.Nodes) > 1 {
-		return 0, fmt.Errorf("too many pull request found for %s", branch)
+	var all, open, fromRemote []git.PRSummary
+	owner := gh.headOwner(ctx, branch)
+	for _, pr := range prs {
+		summary := git.PRSummary{
+			Number:      pr.Number,
+			Title:       pr.Title,
+			Author:      pr.Author.Login,
+			BaseRefName: pr.BaseRefName,
+			State:       pr.State,
+			HeadOwner:   pr.HeadRepositoryOwner.Login,
+		}
+		all = append(all, summary)
+		if pr.State != "OPEN" {
+			continue
+		}
+		open = append(open, summary)
+		if owner != "" && strings.EqualFold(owner, summary.HeadOwner) {
+			fromRemote = append(fromRemote, summary)
+		}
 	}
 
-	return prList.Repository.PullRequests.Nodes[0].Number, nil
+	candidates := fromRemote
+	if len(candidates) == 0 {
+		candidates = open
+	}
+	if len(candidates) == 0 {
+		candidates = all
+	}
+	if len(fromRemote) == 0 && owner != "" {
+		return 0, &MultiplePRsError{Branch: branch, Owner: owner, Candidates: candidates}
+	}
+	if len(candidates) == 1 {
+		return candidates[0].Number, nil
+	}
+	return 0, &MultiplePRsError{Branch: branch, Candidates: candidates}
+}
+
+// headOwner works out who owns the repository the branch is pushed to from its remote, or "" if it cannot tell
+func (gh *PRClient) headOwner(ctx context.Context, branch string) string {
+	config, err := gh.gitClient.ReadBranchConfig(ctx, branch)
+	if err != nil {
+		return ""
+	}
+	remoteURL := config.PushRemoteURL
+	if remoteURL == nil {
+		remoteURL = config.RemoteURL
+	}
+	remoteName := config.PushRemoteName
+	if remoteName == "" {
+		remoteName = config.RemoteName
+	}
+	if remoteURL != nil {
+		return urlOwner(remoteURL)
+	}
+	if remoteName == "" {
+		return ""
+	}
+	return gh.remoteOwner(ctx, remoteName)
+}
+
+// remoteOwner works out who owns the repository of a remote, given by its name or URL, or "" if it cannot tell
+func (gh *PRClient) remoteOwner(ctx context.Context, remote string) string {
+	if remoteURL, err := url.Parse(remote); err == nil && remoteURL.Host != "" {
+		return urlOwner(remoteURL)
+	}
+	remotes, err := gh.gitClient.Remotes(ctx)
+	if err != nil {
+		return ""
+	}
+	for _, candidate := range remotes {
+		if candidate.Name == remote && candidate.FetchURL != nil {
+			return urlOwner(candidate.FetchURL)
+		}
+	}
+	return ""
+}
+
+func urlOwner(remoteURL *url.URL) string {
+	owner, _, _ := strings.Cut(strings.TrimPrefix(remoteURL.Path, "/"), "/")
+	return owner
+}
+
+// ListPRs finds open PRs in the repo matching the filter, most recently updated first
+func (gh *PRClient) ListPRs(repo *git.Repo, filter git.PRFilter) ([]git.PRSummary, error) {
+	query, err := searchQuery(repo, filter)
+	if err != nil {
+		return nil, err
+	}
+	variables := map[string]interface{}{
+		"Query": query,
+	}
+	var response git.GithubQuery
+	err = gh.graphQLClient.Do(graphql.PRListQuery, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prs %w", err)
+	}
+
+	var summaries []git.PRSummary
+	for _, pr := range response.Search.Nodes {
+		unresolved := 0
+		for _, thread := range pr.ReviewThreads.Nodes {
+			if !thread.IsResolved {
+				unresolved++
+			}
+		}
+		checkState := ""
+		if len(pr.Commits.Nodes) > 0 {
+			checkState = pr.Commits.Nodes[0].Commit.StatusCheckRollup.State
+		}
+		summaries = append(summaries, git.PRSummary{
+			Number:            pr.Number,
+			Title:             pr.Title,
+			Author:            pr.Author.Login,
+			IsDraft:           pr.IsDraft,
+			BaseRefName:       pr.BaseRefName,
+			UnresolvedThreads: unresolved,
+			CheckState:        checkState,
+		})
+	}
+	return summaries, nil
+}
+
+func searchQuery(repo *git.Repo, filter git.PRFilter) (string, error) {
+	terms := []string{fmt.Sprintf("repo:%s/%s", repo.Owner, repo.Name), "is:pr", "is:open"}
+	switch filter.Scope {
+	case "", "all":
+	case "mine":
+		terms = append(terms, "author:@me")
+	case "review":
+		terms = append(terms, "review-requested:@me")
+	default:
+		return "", fmt.Errorf("unknown scope %s, use mine, review or all", filter.Scope)
+	}
+	if filter.Label != "" {
+		terms = append(terms, fmt.Sprintf("label:%q", filter.Label))
+	}
+	if filter.Author != "" {
+		terms = append(terms, "author:"+filter.Author)
+	}
+	if filter.Base != "" {
+		terms = append(terms, "base:"+filter.Base)
+	}
+	return strings.Join(append(terms, "sort:updated-desc"), " "), nil
 }
 
 func (gh *PRClient) GetRepoDetails() (repository.Repository, error) {
@@ -322,3 +527,253 @@ func (gh *PRClient) Resolve(comment *git.Comment) error {
 	}
 	return errors.New("cannot resolve a main or commit comment")
 }
+
+func (gh *PRClient) Edit(contents string, comment *git.Comment) error {
+	var query string
+	switch comment.Kind {
+	case git.PullRequestKind:
+		query = graphql.UpdatePRBodyMutation
+	case git.IssueCommentKind:
+		query = graphql.UpdateIssueCommentMutation
+	case git.ReviewKind:
+		query = graphql.UpdateReviewMutation
+	case git.ReviewCommentKind:
+		query = graphql.UpdateReviewCommentMutation
+	default:
+		return fmt.Errorf("cannot edit %s", kindName(comment.Kind))
+	}
+	variables := map[string]interface{}{
+		"id":   comment.Id,
+		"body": contents,
+	}
+
+	return gh.graphQLClient.Do(query, variables, nil)
+}
+
+func (gh *PRClient) Delete(comment *git.Comment) error {
+	var query string
+	switch comment.Kind {
+	case git.IssueCommentKind:
+		query = graphql.DeleteIssueCommentMutation
+	case git.ReviewCommentKind:
+		query = graphql.DeleteReviewCommentMutation
+	case git.ReviewKind:
+		return errors.New("cannot delete a submitted review, edit it instead")
+	default:
+		return fmt.Errorf("cannot delete %s", kindName(comment.Kind))
+	}
+	variables := map[string]interface{}{
+		"id": comment.Id,
+	}
+
+	return gh.graphQLClient.Do(query, variables, nil)
+}
+
+func kindName(kind git.CommentKind) string {
+	switch kind {
+	case "":
+		return "a comment"
+	case git.EventKind:
+		return "an event"
+	default:
+		return "a " + string(kind)
+	}
+}
+
+func (gh *PRClient) React(comment *git.Comment, content string) error {
+	return react(gh.graphQLClient, graphql.AddReactionMutation, comment, content)
+}
+
+func (gh *PRClient) RemoveReaction(comment *git.Comment, content string) error {
+	return react(gh.graphQLClient, graphql.RemoveReactionMutation, comment, content)
+}
+
+func react(client requests.GraphQLClient, mutation string, comment *git.Comment, content string) error {
+	if comment.Id == "" {
+		return errors.New("cannot react to this comment")
+	}
+	variables := map[string]interface{}{
+		"subjectId": comment.Id,
+		"content":   content,
+	}
+
+	return client.Do(mutation, variables, nil)
+}
+
+func (gh *PRClient) GetMergeState(repo *git.Repo) (*git.MergeState, error) {
+	variables := map[string]interface{}{
+		"PullRequestId": githubql.Int(repo.PRNumber),
+		"Owner":         githubql.String(repo.Owner),
+		"RepoName":      githubql.String(repo.Name),
+	}
+	var response git.GitHubData
+	err := gh.graphQLClient.Do(graphql.MergeStateQuery, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch merge state %w", err)
+	}
+
+	prDetails := response.Repository.PullRequest
+	return &git.MergeState{
+		MergeStateStatus: prDetails.MergeStateStatus,
+		MergeStatus:      mergeStatuses[prDetails.MergeStateStatus],
+		ConflictStatus:   mergeStates[prDetails.Mergeable],
+		HeadRef:          prDetails.HeadRef,
+	}, nil
+}
+
+// Merge merges the PR now, method is MERGE, SQUASH or REBASE
+func (gh *PRClient) Merge(prId string, method string) error {
+	variables := map[string]interface{}{
+		"pullRequestId": prId,
+		"mergeMethod":   method,
+	}
+	return gh.graphQLClient.Do(graphql.MergePRMutation, variables, nil)
+}
+
+// EnableAutoMerge merges the PR with method once its requirements are met
+func (gh *PRClient) EnableAutoMerge(prId string, method string) error {
+	variables := map[string]interface{}{
+		"pullRequestId": prId,
+		"mergeMethod":   method,
+	}
+	return gh.graphQLClient.Do(graphql.EnableAutoMergeMutation, variables, nil)
+}
+
+func (gh *PRClient) DeleteBranch(ref git.Ref) error {
+	if ref.Id == "" {
+		return errors.New("the branch has already been deleted")
+	}
+	variables := map[string]interface{}{
+		"refId": ref.Id,
+	}
+	return gh.graphQLClient.Do(graphql.DeleteRefMutation, variables, nil)
+}
+
+// Checkout fetches the head of the PR from the repository it was opened against, so branches from forks work too,
+// and switches to it. A local branch of the same name is only fast-forwarded. It returns the name of the local branch.
+func (gh *PRClient) Checkout(repo *git.Repo, force bool) (string, error) {
+	ctx := context.Background()
+	if !force {
+		changes, err := gh.gitClient.TrackedChangeCount(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the working tree %w", err)
+		}
+		if changes > 0 {
+			return "", ErrUncommittedChanges
+		}
+	}
+
+	variables := map[string]interface{}{
+		"PullRequestId": githubql.Int(repo.PRNumber),
+		"Owner":         githubql.String(repo.Owner),
+		"RepoName":      githubql.String(repo.Name),
+	}
+	var response git.GitHubData
+	err := gh.graphQLClient.Do(graphql.PRHeadQuery, variables, &response)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch pull request head %w", err)
+	}
+	head := response.Repository.PullRequest
+	branch := head.HeadRefName
+	if head.IsCrossRepository && branch == head.BaseRefName {
+		branch = fmt.Sprintf("%s/%s", head.HeadRepositoryOwner.Login, branch)
+	}
+
+	remote := gh.remoteFor(ctx, repo)
+	pullRef := fmt.Sprintf("refs/pull/%d/head", repo.PRNumber)
+	current, _ := gh.gitClient.CurrentBranch(ctx)
+	if current == branch {
+		err = gh.gitClient.Pull(ctx, remote, pullRef)
+		if err != nil {
+			return "", fmt.Errorf("failed to update %s %w", branch, err)
+		}
+		return branch, nil
+	}
+	if gh.gitClient.HasLocalBranch(ctx, branch) {
+		err = gh.gitClient.Fetch(ctx, remote, pullRef)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch %s %w", branch, err)
+		}
+		// without + git only fast-forwards the branch, so it is left alone and not switched to when it has commits
+		// that are not in the pull request, such as an unrelated branch with the same name as a fork's
+		err = gh.gitClient.Fetch(ctx, remote, fmt.Sprintf("%s:%s", pullRef, branch))
+		if err != nil {
+			return "", fmt.Errorf("failed to update %s, it has commits that are not in the pull request %w", branch, err)
+		}
+		err = gh.gitClient.CheckoutBranch(ctx, branch)
+		if err != nil {
+			return "", fmt.Errorf("failed to switch to %s %w", branch, err)
+		}
+		return branch, nil
+	}
+
+	err = gh.gitClient.Fetch(ctx, remote, fmt.Sprintf("+%s:%s", pullRef, branch))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s %w", branch, err)
+	}
+	err = gh.gitClient.CheckoutBranch(ctx, branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to switch to %s %w", branch, err)
+	}
+	if !head.IsCrossRepository {
+		// branches in the same repository track the PR's branch so commits can be pushed back to it
+		err = gh.gitClient.SetBranchConfig(ctx, branch, "remote", remote)
+		if err == nil {
+			err = gh.gitClient.SetBranchConfig(ctx, branch, "merge", "refs/heads/"+head.HeadRefName)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to set the upstream of %s %w", branch, err)
+		}
+	}
+	return branch, nil
+}
+
+// remoteFor finds the name of the remote pointing at the repo, or its URL when there is no such remote
+func (gh *PRClient) remoteFor(ctx context.Context, repo *git.Repo) string {
+	if name := gh.repoRemote(ctx, repo); name != "" {
+		return name
+	}
+	host := repo.Host
+	if host == "" {
+		host = "github.com"
+	}
+	return fmt.Sprintf("https://%s/%s/%s.git", host, repo.Owner, repo.Name)
+}
+
+// repoRemote finds the name of the local remote pointing at the repo, or "" when there is none
+func (gh *PRClient) repoRemote(ctx context.Context, repo *git.Repo) string {
+	remotes, _ := gh.gitClient.Remotes(ctx)
+	for _, remote := range remotes {
+		if remote.FetchURL == nil {
+			continue
+		}
+		path := strings.TrimSuffix(strings.Trim(remote.FetchURL.Path, "/"), ".git")
+		if strings.EqualFold(path, repo.Owner+"/"+repo.Name) {
+			return remote.Name
+		}
+	}
+	return ""
+}
+
+// LocalPath gives where the file at path in the repo is in the local checkout. The checkout must have a remote
+// for the repo, a PR opened with --repo or a link can be from somewhere else entirely.
+func (gh *PRClient) LocalPath(repo *git.Repo, path string) (string, error) {
+	ctx := context.Background()
+	if gh.repoRemote(ctx, repo) == "" {
+		return "", fmt.Errorf("%s/%s is not checked out here", repo.Owner, repo.Name)
+	}
+	root, err := gh.gitClient.ToplevelDir(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to find the repository root %w", err)
+	}
+	return filepath.Join(root, filepath.FromSlash(path)), nil
+}
+
+// LocalHead gives the commit checked out locally
+func (gh *PRClient) LocalHead() (string, error) {
+	commit, err := gh.gitClient.LastCommit(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("failed to read the local commit %w", err)
+	}
+	return commit.Sha, nil
+}
@@ -3,10 +3,14 @@ package github
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
 	"testing"
 	"time"
 	stdtime "time"
 
+	cligit "github.com/cli/cli/v2/git"
 	githubql "github.com/cli/shurcooL-graphql"
 	"github.com/golang/mock/gomock"
 	"github.com/hbk619/gh-peruse/internal/git"
@@ -20,6 +24,7 @@ import (
 type PRServiceTestSuite struct {
 	suite.Suite
 	mockGraphQL   *mock_requests.MockGraphQLClient
+	mockREST      *mock_requests.MockRESTClient
 	mockGitClient *mock_github.MockGitClient
 	ctrl          *gomock.Controller
 	repo          *git.Repo
@@ -29,6 +34,7 @@ type PRServiceTestSuite struct {
 func (suite *PRServiceTestSuite) BeforeTest(string, string) {
 	suite.ctrl = gomock.NewController(suite.T())
 	suite.mockGraphQL = mock_requests.NewMockGraphQLClient(suite.ctrl)
+	suite.mockREST = mock_requests.NewMockRESTClient(suite.ctrl)
 	suite.mockGitClient = mock_github.NewMockGitClient(suite.ctrl)
 	suite.repo = &git.Repo{
 		Owner:    "luigi",
@@ -37,6 +43,7 @@ func (suite *PRServiceTestSuite) BeforeTest(string, string) {
 	}
 	suite.prService = PRClient{
 		graphQLClient: suite.mockGraphQL,
+		restClient:    suite.mockREST,
 		gitClient:     suite.mockGitClient,
 	}
 }
@@ -52,6 +59,8 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_no_comments() {
           "login": "Mario"
         },
         "title": "Test pr",
+        "url": "https://github.com/luigi/castle/pull/123",
+        "headRefOid": "abc123",
         "createdAt": "2025-02-20T22:38:47Z",
         "reviewThreads": null,
         "comments": null
@@ -64,6 +73,8 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_no_comments() {
 		Comments: nil,
 		State:    git.State{},
 		Title:    "Test pr",
+		HeadOid:  "abc123",
+		URL:      "https://github.com/luigi/castle/pull/123",
 	}
 
 	variables := map[string]interface{}{
@@ -88,6 +99,9 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_no_comments() {
 func (suite *PRServiceTestSuite) TestPRService_getPrDetails_with_verbose() {
 	prDetails := `{
   "data": {
+    "viewer": {
+      "login": "Peach"
+    },
     "repository": {
       "pullRequest": {
         "commits": {
@@ -178,6 +192,10 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_with_verbose() {
                 "status": "COMPLETED",
                 "name": "Test1",
                 "conclusion": "FAILURE"
+              },
+              {
+                "context": "ci/jenkins",
+                "state": "PENDING"
               }
             ]
           }
@@ -230,6 +248,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_with_verbose() {
 				FullPath: MainThread,
 				FileName: MainThread,
 			},
+			Kind: git.PullRequestKind,
 		}, {
 			Id: "awdasdadad",
 			Author: git.Author{
@@ -241,6 +260,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_with_verbose() {
 				FullPath: MainThread,
 				FileName: MainThread,
 			},
+			Kind: git.IssueCommentKind,
 		}, {
 			Author: git.Author{
 				Login: "Peach",
@@ -252,6 +272,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_with_verbose() {
 				FileName: MainThread,
 			},
 			State: "COMMENTED",
+			Kind:  git.ReviewKind,
 		}, {
 			Id: "lkmoimiom",
 			Author: git.Author{
@@ -263,6 +284,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_with_verbose() {
 				FullPath: MainThread,
 				FileName: MainThread,
 			},
+			Kind: git.IssueCommentKind,
 		}, {
 			Author: git.Author{
 				Login: "Peach",
@@ -274,6 +296,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_with_verbose() {
 				FullPath: MainThread,
 				FileName: MainThread,
 			},
+			Kind: git.ReviewKind,
 		}, {
 			Author: git.Author{
 				Login: "Bowser",
@@ -285,6 +308,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_with_verbose() {
 				FullPath: MainThread,
 				FileName: MainThread,
 			},
+			Kind: git.ReviewKind,
 		}, {
 			Author: git.Author{
 				Login: "Bowser",
@@ -296,6 +320,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_with_verbose() {
 				FullPath: MainThread,
 				FileName: MainThread,
 			},
+			Kind: git.ReviewKind,
 		}, {
 			Author: git.Author{
 				Login: "Mario",
@@ -306,6 +331,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_with_verbose() {
 				FullPath: "b45facb711478f4eae1f9c83fb6cfeaea24fr224",
 				FileName: "commit hash b45facb711478f4eae1f9c83fb6cfeaea24fr224",
 			},
+			Kind: git.CommitCommentKind,
 		}},
 		State: git.State{
 			Reviews:        map[string][]string{"APPROVED": {"Peach"}, "COMMENTED": {"Peach", "Bowser"}},
@@ -314,12 +340,20 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_with_verbose() {
 			Statuses: []git.Status{{
 				Name:       "Test",
 				Conclusion: "SUCCESS",
+				Status:     "COMPLETED",
 			}, {
 				Name:       "Test1",
 				Conclusion: "FAILURE",
+				Status:     "COMPLETED",
+			}, {
+				Name:       "ci/jenkins",
+				Conclusion: "PENDING",
+				Context:    "ci/jenkins",
+				State:      "PENDING",
 			}},
 		},
-		Title: "Test pr",
+		Title:  "Test pr",
+		Viewer: "Peach",
 	}
 	variables := map[string]interface{}{
 		"PullRequestId": githubql.Int(suite.repo.PRNumber),
@@ -410,7 +444,14 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_comments_with_thread
                 "line" : 2,
                 "diffHunk" : "@@ -0,0 +1,8 @@\n+name: things\n+on: [push]",
                 "outdated" : false,
-                "createdAt" : "2024-07-31T09:34:11Z"
+                "createdAt" : "2024-07-31T09:34:11Z",
+                "reactionGroups" : [ {
+                  "content" : "ROCKET",
+                  "users" : {
+                    "totalCount" : 2
+                  },
+                  "viewerHasReacted" : true
+                } ]
               } ],
               "pageInfo" : {
                 "hasNextPage" : false
@@ -495,6 +536,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_comments_with_thread
 				FullPath: MainThread,
 				FileName: MainThread,
 			},
+			Kind: git.IssueCommentKind,
 		}, {
 			Author: git.Author{
 				Login: "Peach",
@@ -506,6 +548,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_comments_with_thread
 				FileName: MainThread,
 			},
 			State: "COMMENTED",
+			Kind:  git.ReviewKind,
 		}, {
 			Id: "lkmoimiom",
 			Author: git.Author{
@@ -517,6 +560,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_comments_with_thread
 				FullPath: MainThread,
 				FileName: MainThread,
 			},
+			Kind: git.IssueCommentKind,
 		}, {
 			Author: git.Author{
 				Login: "Peach",
@@ -528,6 +572,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_comments_with_thread
 				FullPath: MainThread,
 				FileName: MainThread,
 			},
+			Kind: git.ReviewKind,
 		}, {
 			Id: "ABCD_kDOOLvWJM5lOKjk",
 			Author: git.Author{
@@ -548,6 +593,12 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_comments_with_thread
 				IsResolved: false,
 				ID:         "ABCD_kdER4tvWJM5AsoQq",
 			},
+			Reactions: []git.Reaction{{
+				Content:          "ROCKET",
+				Users:            git.ReactionUsers{TotalCount: 2},
+				ViewerHasReacted: true,
+			}},
+			Kind: git.ReviewCommentKind,
 		}, {
 			Id: "PDDD_kwDOKtvW309DSOXr_",
 			Author: git.Author{
@@ -568,6 +619,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_comments_with_thread
 				IsResolved: true,
 				ID:         "ABCD_kwDOKtvOWM5Aswyn",
 			},
+			Kind: git.ReviewCommentKind,
 		}, {
 			Id: "PRRD_kwDOKtvW3900DSOXr_",
 			Author: git.Author{
@@ -588,6 +640,7 @@ func (suite *PRServiceTestSuite) TestPRService_getPrDetails_comments_with_thread
 				IsResolved: true,
 				ID:         "ABCD_kwDOKtvOWM5Aswyn",
 			},
+			Kind: git.ReviewCommentKind,
 		}},
 		State: git.State{},
 		Title: "Test pr",
@@ -689,6 +742,247 @@ func (suite *PRServiceTestSuite) TestResolve_has_error() {
 	suite.ErrorIs(err, expected)
 }
 
+func (suite *PRServiceTestSuite) TestEdit_uses_mutation_for_kind() {
+	mutations := map[git.CommentKind]string{
+		git.PullRequestKind:   graphql.UpdatePRBodyMutation,
+		git.IssueCommentKind:  graphql.UpdateIssueCommentMutation,
+		git.ReviewKind:        graphql.UpdateReviewMutation,
+		git.ReviewCommentKind: graphql.UpdateReviewCommentMutation,
+	}
+	for kind, mutation := range mutations {
+		variables := map[string]interface{}{
+			"id":   "PDDD_e43oidmdm",
+			"body": "Fixed typo",
+		}
+		suite.mockGraphQL.EXPECT().Do(mutation, variables, gomock.Any()).Return(nil)
+
+		err := suite.prService.Edit("Fixed typo", &git.Comment{Id: "PDDD_e43oidmdm", Kind: kind})
+		suite.NoError(err)
+	}
+}
+
+func (suite *PRServiceTestSuite) TestEdit_commit_comment() {
+	err := suite.prService.Edit("Fixed typo", &git.Comment{Id: "PDDD_e43oidmdm", Kind: git.CommitCommentKind})
+	suite.ErrorContains(err, "cannot edit a commit comment")
+}
+
+func (suite *PRServiceTestSuite) TestEdit_has_error() {
+	expected := errors.New("error")
+	suite.mockGraphQL.EXPECT().Do(graphql.UpdateIssueCommentMutation, gomock.Any(), gomock.Any()).Return(expected)
+
+	err := suite.prService.Edit("Fixed typo", &git.Comment{Id: "PDDD_e43oidmdm", Kind: git.IssueCommentKind})
+	suite.ErrorIs(err, expected)
+}
+
+func (suite *PRServiceTestSuite) TestDelete_issue_comment() {
+	variables := map[string]interface{}{
+		"id": "PDDD_e43oidmdm",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.DeleteIssueCommentMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.prService.Delete(&git.Comment{Id: "PDDD_e43oidmdm", Kind: git.IssueCommentKind})
+	suite.NoError(err)
+}
+
+func (suite *PRServiceTestSuite) TestDelete_review_comment() {
+	variables := map[string]interface{}{
+		"id": "PDDD_e43oidmdm",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.DeleteReviewCommentMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.prService.Delete(&git.Comment{Id: "PDDD_e43oidmdm", Kind: git.ReviewCommentKind})
+	suite.NoError(err)
+}
+
+func (suite *PRServiceTestSuite) TestDelete_review() {
+	err := suite.prService.Delete(&git.Comment{Id: "PDDD_e43oidmdm", Kind: git.ReviewKind})
+	suite.ErrorContains(err, "cannot delete a submitted review")
+}
+
+func (suite *PRServiceTestSuite) TestDelete_pull_request() {
+	err := suite.prService.Delete(&git.Comment{Id: "PDDD_e43oidmdm", Kind: git.PullRequestKind})
+	suite.ErrorContains(err, "cannot delete a pull request")
+}
+
+func (suite *PRServiceTestSuite) TestReact() {
+	variables := map[string]interface{}{
+		"subjectId": "PDDD_e43oidmdm",
+		"content":   "HEART",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.AddReactionMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.prService.React(&git.Comment{Id: "PDDD_e43oidmdm"}, "HEART")
+	suite.NoError(err)
+}
+
+func (suite *PRServiceTestSuite) TestRemoveReaction() {
+	variables := map[string]interface{}{
+		"subjectId": "PDDD_e43oidmdm",
+		"content":   "HEART",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.RemoveReactionMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.prService.RemoveReaction(&git.Comment{Id: "PDDD_e43oidmdm"}, "HEART")
+	suite.NoError(err)
+}
+
+func (suite *PRServiceTestSuite) TestReact_without_id() {
+	err := suite.prService.React(&git.Comment{}, "HEART")
+	suite.ErrorContains(err, "cannot react to this comment")
+}
+
+func (suite *PRServiceTestSuite) TestGetMergeState() {
+	response := `{
+  "data": {
+    "repository": {
+      "pullRequest": {
+        "mergeable": "MERGEABLE",
+        "mergeStateStatus": "CLEAN",
+        "headRef": {"id": "REF_kwDOA", "name": "feature/pipes"}
+      }
+    }
+  }
+}`
+	variables := map[string]interface{}{
+		"PullRequestId": githubql.Int(suite.repo.PRNumber),
+		"Owner":         githubql.String(suite.repo.Owner),
+		"RepoName":      githubql.String(suite.repo.Name),
+	}
+	suite.mockGraphQL.EXPECT().
+		Do(graphql.MergeStateQuery, variables, gomock.Any()).
+		DoAndReturn(func(query string, variables map[string]interface{}, data interface{}) error {
+			gr := GraphQLResponse{Data: data}
+			err := json.Unmarshal([]byte(response), &gr)
+			suite.NoError(err)
+			return nil
+		})
+
+	state, err := suite.prService.GetMergeState(suite.repo)
+	suite.NoError(err)
+	suite.Equal(&git.MergeState{
+		MergeStateStatus: "CLEAN",
+		MergeStatus:      "Mergeable and passing checks",
+		ConflictStatus:   "No conflicts",
+		HeadRef:          git.Ref{Id: "REF_kwDOA", Name: "feature/pipes"},
+	}, state)
+}
+
+func (suite *PRServiceTestSuite) TestGetMergeState_has_error() {
+	suite.mockGraphQL.EXPECT().Do(graphql.MergeStateQuery, gomock.Any(), gomock.Any()).Return(errors.New("bad gateway"))
+
+	state, err := suite.prService.GetMergeState(suite.repo)
+	suite.EqualError(err, "failed to fetch merge state bad gateway")
+	suite.Nil(state)
+}
+
+func (suite *PRServiceTestSuite) TestMerge() {
+	variables := map[string]interface{}{
+		"pullRequestId": "PR_kwDOA",
+		"mergeMethod":   "SQUASH",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.MergePRMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.prService.Merge("PR_kwDOA", "SQUASH")
+	suite.NoError(err)
+}
+
+func (suite *PRServiceTestSuite) TestEnableAutoMerge() {
+	variables := map[string]interface{}{
+		"pullRequestId": "PR_kwDOA",
+		"mergeMethod":   "REBASE",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.EnableAutoMergeMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.prService.EnableAutoMerge("PR_kwDOA", "REBASE")
+	suite.NoError(err)
+}
+
+func (suite *PRServiceTestSuite) TestDeleteBranch() {
+	variables := map[string]interface{}{
+		"refId": "REF_kwDOA",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.DeleteRefMutation, variables, gomock.Any()).Return(nil)
+
+	err := suite.prService.DeleteBranch(git.Ref{Id: "REF_kwDOA", Name: "feature/pipes"})
+	suite.NoError(err)
+}
+
+func (suite *PRServiceTestSuite) TestDeleteBranch_already_deleted() {
+	err := suite.prService.DeleteBranch(git.Ref{Name: "feature/pipes"})
+	suite.EqualError(err, "the branch has already been deleted")
+}
+
+func (suite *PRServiceTestSuite) TestListPRs() {
+	response := `{
+  "data": {
+    "search": {
+      "nodes": [
+        {
+          "number": 12,
+          "title": "Faster pipes",
+          "isDraft": true,
+          "author": {"login": "mario"},
+          "baseRefName": "main",
+          "reviewThreads": {"nodes": [{"isResolved": false}, {"isResolved": true}, {"isResolved": false}]},
+          "commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "FAILURE"}}}]}
+        },
+        {
+          "number": 9,
+          "title": "Docs",
+          "author": {"login": "toad"},
+          "baseRefName": "main",
+          "reviewThreads": {"nodes": []},
+          "commits": {"nodes": [{"commit": {"statusCheckRollup": null}}]}
+        }
+      ]
+    }
+  }
+}`
+	variables := map[string]interface{}{
+		"Query": "repo:luigi/castle is:pr is:open review-requested:@me label:\"good first issue\" base:main sort:updated-desc",
+	}
+	suite.mockGraphQL.EXPECT().
+		Do(graphql.PRListQuery, variables, gomock.Any()).
+		DoAndReturn(func(query string, variables map[string]interface{}, data interface{}) error {
+			gr := GraphQLResponse{Data: data}
+			err := json.Unmarshal([]byte(response), &gr)
+			suite.NoError(err)
+			return nil
+		})
+
+	prs, err := suite.prService.ListPRs(suite.repo, git.PRFilter{Scope: "review", Label: "good first issue", Base: "main"})
+	suite.NoError(err)
+	suite.Equal([]git.PRSummary{
+		{Number: 12, Title: "Faster pipes", Author: "mario", IsDraft: true, BaseRefName: "main", UnresolvedThreads: 2, CheckState: "FAILURE"},
+		{Number: 9, Title: "Docs", Author: "toad", BaseRefName: "main"},
+	}, prs)
+}
+
+func (suite *PRServiceTestSuite) TestListPRs_mine_by_author() {
+	variables := map[string]interface{}{
+		"Query": "repo:luigi/castle is:pr is:open author:@me author:toad sort:updated-desc",
+	}
+	suite.mockGraphQL.EXPECT().Do(graphql.PRListQuery, variables, gomock.Any()).Return(nil)
+
+	prs, err := suite.prService.ListPRs(suite.repo, git.PRFilter{Scope: "mine", Author: "toad"})
+	suite.NoError(err)
+	suite.Empty(prs)
+}
+
+func (suite *PRServiceTestSuite) TestListPRs_unknown_scope() {
+	prs, err := suite.prService.ListPRs(suite.repo, git.PRFilter{Scope: "theirs"})
+	suite.EqualError(err, "unknown scope theirs, use mine, review or all")
+	suite.Nil(prs)
+}
+
+func (suite *PRServiceTestSuite) TestListPRs_has_error() {
+	suite.mockGraphQL.EXPECT().Do(graphql.PRListQuery, gomock.Any(), gomock.Any()).Return(errors.New("bad gateway"))
+
+	prs, err := suite.prService.ListPRs(suite.repo, git.PRFilter{})
+	suite.EqualError(err, "failed to list prs bad gateway")
+	suite.Nil(prs)
+}
+
 func (suite *PRServiceTestSuite) TestDetectCurrentPR_has_error_getting_branch() {
 	expected := errors.New("error")
 
@@ -736,7 +1030,9 @@ func (suite *PRServiceTestSuite) TestDetectCurrentPR_returns_pr_when_one_found()
 				"pullRequests": {
 					"nodes": [
 						{
-							"number": 2
+							"number": 2,
+							"state": "OPEN",
+							"headRepositoryOwner": {"login": "mario"}
 						}
 					]
 				}
@@ -746,6 +1042,7 @@ func (suite *PRServiceTestSuite) TestDetectCurrentPR_returns_pr_when_one_found()
 	suite.mockGitClient.EXPECT().
 		CurrentBranch(gomock.Any()).
 		Return("branchy", nil)
+	suite.mockGitClient.EXPECT().ReadBranchConfig(gomock.Any(), "branchy").Return(cligit.BranchConfig{}, nil)
 	suite.mockGraphQL.EXPECT().
 		Do(graphql.GetPRForBranch, variables, gomock.Any()).
 		DoAndReturn(func(query string, variables map[string]interface{}, response interface{}) error {
@@ -763,28 +1060,13 @@ func (suite *PRServiceTestSuite) TestDetectCurrentPR_returns_pr_when_one_found()
 	suite.Equal(2, prNumber)
 }
 
-func (suite *PRServiceTestSuite) TestDetectCurrentPR_returns_pr_error_when_more_than_one_found() {
+func (suite *PRServiceTestSuite) expectPRsForBranch(prs string) {
 	variables := map[string]interface{}{
 		"BranchName": "branchy",
 		"Owner":      "mario",
 		"RepoName":   "kart",
 	}
-	prDetails := `{
-		"data": {
-			"repository": {
-				"pullRequests": {
-					"nodes": [
-						{
-							"number": 2
-						},
-						{
-							"number": 4
-						}
-					]
-				}
-			}
-		}
-	}`
+	prDetails := fmt.Sprintf(`{"data": {"repository": {"pullRequests": {"nodes": %s}}}}`, prs)
 	suite.mockGitClient.EXPECT().
 		CurrentBranch(gomock.Any()).
 		Return("branchy", nil)
@@ -797,11 +1079,100 @@ func (suite *PRServiceTestSuite) TestDetectCurrentPR_returns_pr_error_when_more_
 			suite.NoError(err)
 			return nil
 		})
+}
+
+func (suite *PRServiceTestSuite) TestDetectCurrentPR_returns_pr_error_when_more_than_one_found() {
+	suite.expectPRsForBranch(`[
+		{"number": 2, "title": "Old try", "state": "CLOSED", "headRepositoryOwner": {"login": "mario"}},
+		{"number": 4, "title": "Merged try", "state": "MERGED", "headRepositoryOwner": {"login": "mario"}}
+	]`)
+	suite.mockGitClient.EXPECT().ReadBranchConfig(gomock.Any(), "branchy").Return(cligit.BranchConfig{}, nil)
+
 	prNumber, err := suite.prService.DetectCurrentPR(&git.Repo{
 		Owner: "mario",
 		Name:  "kart",
 	})
 	suite.ErrorContains(err, "too many pull request found for branchy")
+	var multiple *MultiplePRsError
+	suite.ErrorAs(err, &multiple)
+	suite.Equal([]git.PRSummary{
+		{Number: 2, Title: "Old try", State: "CLOSED", HeadOwner: "mario"},
+		{Number: 4, Title: "Merged try", State: "MERGED", HeadOwner: "mario"},
+	}, multiple.Candidates)
+	suite.Equal(0, prNumber)
+}
+
+func (suite *PRServiceTestSuite) TestDetectCurrentPR_prefers_only_open_pr() {
+	suite.expectPRsForBranch(`[
+		{"number": 2, "state": "CLOSED", "headRepositoryOwner": {"login": "mario"}},
+		{"number": 4, "state": "OPEN", "headRepositoryOwner": {"login": "mario"}}
+	]`)
+	suite.mockGitClient.EXPECT().ReadBranchConfig(gomock.Any(), "branchy").Return(cligit.BranchConfig{}, errors.New("no config"))
+
+	prNumber, err := suite.prService.DetectCurrentPR(&git.Repo{
+		Owner: "mario",
+		Name:  "kart",
+	})
+	suite.NoError(err)
+	suite.Equal(4, prNumber)
+}
+
+func (suite *PRServiceTestSuite) TestDetectCurrentPR_prefers_open_pr_from_pushed_remote() {
+	suite.expectPRsForBranch(`[
+		{"number": 2, "state": "OPEN", "headRepositoryOwner": {"login": "luigi"}},
+		{"number": 4, "state": "OPEN", "headRepositoryOwner": {"login": "Toad"}}
+	]`)
+	suite.mockGitClient.EXPECT().ReadBranchConfig(gomock.Any(), "branchy").Return(cligit.BranchConfig{RemoteName: "fork"}, nil)
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(cligit.RemoteSet{
+		cligit.NewRemote("origin", "https://github.com/mario/kart.git"),
+		cligit.NewRemote("fork", "https://github.com/toad/kart.git"),
+	}, nil)
+
+	prNumber, err := suite.prService.DetectCurrentPR(&git.Repo{
+		Owner: "mario",
+		Name:  "kart",
+	})
+	suite.NoError(err)
+	suite.Equal(4, prNumber)
+}
+
+func (suite *PRServiceTestSuite) TestDetectCurrentPR_lists_open_prs_when_remote_does_not_match() {
+	suite.expectPRsForBranch(`[
+		{"number": 2, "state": "OPEN", "headRepositoryOwner": {"login": "luigi"}},
+		{"number": 3, "state": "CLOSED", "headRepositoryOwner": {"login": "peach"}},
+		{"number": 4, "state": "OPEN", "headRepositoryOwner": {"login": "toad"}}
+	]`)
+	pushURL, _ := url.Parse("ssh://git@github.com/yoshi/kart.git")
+	suite.mockGitClient.EXPECT().ReadBranchConfig(gomock.Any(), "branchy").Return(cligit.BranchConfig{PushRemoteURL: pushURL}, nil)
+
+	_, err := suite.prService.DetectCurrentPR(&git.Repo{
+		Owner: "mario",
+		Name:  "kart",
+	})
+	var multiple *MultiplePRsError
+	suite.ErrorAs(err, &multiple)
+	suite.Equal("yoshi", multiple.Owner)
+	suite.Equal([]git.PRSummary{
+		{Number: 2, State: "OPEN", HeadOwner: "luigi"},
+		{Number: 4, State: "OPEN", HeadOwner: "toad"},
+	}, multiple.Candidates)
+}
+
+func (suite *PRServiceTestSuite) TestDetectCurrentPR_asks_about_only_open_pr_from_another_fork() {
+	suite.expectPRsForBranch(`[
+		{"number": 2, "state": "OPEN", "headRepositoryOwner": {"login": "wario"}}
+	]`)
+	pushURL, _ := url.Parse("https://github.com/yoshi/kart.git")
+	suite.mockGitClient.EXPECT().ReadBranchConfig(gomock.Any(), "branchy").Return(cligit.BranchConfig{PushRemoteURL: pushURL}, nil)
+
+	prNumber, err := suite.prService.DetectCurrentPR(&git.Repo{
+		Owner: "mario",
+		Name:  "kart",
+	})
+	var multiple *MultiplePRsError
+	suite.ErrorAs(err, &multiple)
+	suite.Equal("yoshi", multiple.Owner)
+	suite.Equal([]git.PRSummary{{Number: 2, State: "OPEN", HeadOwner: "wario"}}, multiple.Candidates)
 	suite.Equal(0, prNumber)
 }
 
@@ -977,6 +1348,176 @@ func (suite *PRServiceTestSuite) TestGetCommentCountForOwnedPRs_returns_error()
 	suite.Nil(counts)
 }
 
+func (suite *PRServiceTestSuite) expectPRHead(head string) {
+	variables := map[string]interface{}{
+		"PullRequestId": githubql.Int(123),
+		"Owner":         githubql.String("luigi"),
+		"RepoName":      githubql.String("castle"),
+	}
+	suite.mockGraphQL.EXPECT().
+		Do(graphql.PRHeadQuery, variables, gomock.Any()).
+		DoAndReturn(func(query string, variables map[string]interface{}, response interface{}) error {
+			gr := GraphQLResponse{Data: response}
+			return json.Unmarshal([]byte(fmt.Sprintf(`{"data": {"repository": {"pullRequest": %s}}}`, head)), &gr)
+		})
+}
+
+func (suite *PRServiceTestSuite) TestCheckout_fetches_and_switches_branch() {
+	suite.mockGitClient.EXPECT().TrackedChangeCount(gomock.Any()).Return(0, nil)
+	suite.expectPRHead(`{"baseRefName": "main", "headRefName": "pipes"}`)
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(cligit.RemoteSet{
+		cligit.NewRemote("upstream", "https://github.com/luigi/castle.git"),
+	}, nil)
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("main", nil)
+	suite.mockGitClient.EXPECT().HasLocalBranch(gomock.Any(), "pipes").Return(false)
+	suite.mockGitClient.EXPECT().Fetch(gomock.Any(), "upstream", "+refs/pull/123/head:pipes").Return(nil)
+	suite.mockGitClient.EXPECT().CheckoutBranch(gomock.Any(), "pipes").Return(nil)
+	suite.mockGitClient.EXPECT().SetBranchConfig(gomock.Any(), "pipes", "remote", "upstream").Return(nil)
+	suite.mockGitClient.EXPECT().SetBranchConfig(gomock.Any(), "pipes", "merge", "refs/heads/pipes").Return(nil)
+
+	branch, err := suite.prService.Checkout(suite.repo, false)
+	suite.NoError(err)
+	suite.Equal("pipes", branch)
+}
+
+func (suite *PRServiceTestSuite) TestCheckout_fork_without_remote() {
+	suite.repo.Host = "github.example.com"
+	suite.mockGitClient.EXPECT().TrackedChangeCount(gomock.Any()).Return(0, nil)
+	suite.expectPRHead(`{"baseRefName": "main", "headRefName": "main", "isCrossRepository": true, "headRepositoryOwner": {"login": "toad"}}`)
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(cligit.RemoteSet{
+		cligit.NewRemote("origin", "https://github.example.com/toad/castle.git"),
+	}, nil)
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("", errors.New("detached head"))
+	suite.mockGitClient.EXPECT().HasLocalBranch(gomock.Any(), "toad/main").Return(false)
+	suite.mockGitClient.EXPECT().Fetch(gomock.Any(), "https://github.example.com/luigi/castle.git", "+refs/pull/123/head:toad/main").Return(nil)
+	suite.mockGitClient.EXPECT().CheckoutBranch(gomock.Any(), "toad/main").Return(nil)
+
+	branch, err := suite.prService.Checkout(suite.repo, false)
+	suite.NoError(err)
+	suite.Equal("toad/main", branch)
+}
+
+func (suite *PRServiceTestSuite) TestCheckout_pulls_current_branch() {
+	suite.expectPRHead(`{"baseRefName": "main", "headRefName": "pipes"}`)
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(cligit.RemoteSet{
+		cligit.NewRemote("origin", "ssh://git@github.com/luigi/castle.git"),
+	}, nil)
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("pipes", nil)
+	suite.mockGitClient.EXPECT().Pull(gomock.Any(), "origin", "refs/pull/123/head").Return(nil)
+
+	branch, err := suite.prService.Checkout(suite.repo, true)
+	suite.NoError(err)
+	suite.Equal("pipes", branch)
+}
+
+func (suite *PRServiceTestSuite) TestCheckout_refuses_uncommitted_changes() {
+	suite.mockGitClient.EXPECT().TrackedChangeCount(gomock.Any()).Return(2, nil)
+
+	branch, err := suite.prService.Checkout(suite.repo, false)
+	suite.ErrorIs(err, ErrUncommittedChanges)
+	suite.Equal("", branch)
+}
+
+func (suite *PRServiceTestSuite) TestCheckout_has_error_fetching() {
+	suite.mockGitClient.EXPECT().TrackedChangeCount(gomock.Any()).Return(0, nil)
+	suite.expectPRHead(`{"baseRefName": "main", "headRefName": "pipes"}`)
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(nil, nil)
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("main", nil)
+	suite.mockGitClient.EXPECT().HasLocalBranch(gomock.Any(), "pipes").Return(false)
+	suite.mockGitClient.EXPECT().Fetch(gomock.Any(), "https://github.com/luigi/castle.git", "+refs/pull/123/head:pipes").Return(errors.New("could not resolve host"))
+
+	_, err := suite.prService.Checkout(suite.repo, false)
+	suite.EqualError(err, "failed to fetch pipes could not resolve host")
+}
+
+func (suite *PRServiceTestSuite) TestCheckout_fast_forwards_existing_branch() {
+	suite.mockGitClient.EXPECT().TrackedChangeCount(gomock.Any()).Return(0, nil)
+	suite.expectPRHead(`{"baseRefName": "main", "headRefName": "pipes"}`)
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(cligit.RemoteSet{
+		cligit.NewRemote("origin", "https://github.com/luigi/castle.git"),
+	}, nil)
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("main", nil)
+	suite.mockGitClient.EXPECT().HasLocalBranch(gomock.Any(), "pipes").Return(true)
+	gomock.InOrder(
+		suite.mockGitClient.EXPECT().Fetch(gomock.Any(), "origin", "refs/pull/123/head").Return(nil),
+		suite.mockGitClient.EXPECT().Fetch(gomock.Any(), "origin", "refs/pull/123/head:pipes").Return(nil),
+		suite.mockGitClient.EXPECT().CheckoutBranch(gomock.Any(), "pipes").Return(nil),
+	)
+
+	branch, err := suite.prService.Checkout(suite.repo, false)
+	suite.NoError(err)
+	suite.Equal("pipes", branch)
+}
+
+func (suite *PRServiceTestSuite) TestCheckout_existing_branch_has_diverged() {
+	suite.mockGitClient.EXPECT().TrackedChangeCount(gomock.Any()).Return(0, nil)
+	suite.expectPRHead(`{"baseRefName": "main", "headRefName": "pipes"}`)
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(nil, nil)
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("main", nil)
+	suite.mockGitClient.EXPECT().HasLocalBranch(gomock.Any(), "pipes").Return(true)
+	suite.mockGitClient.EXPECT().Fetch(gomock.Any(), "https://github.com/luigi/castle.git", "refs/pull/123/head").Return(nil)
+	suite.mockGitClient.EXPECT().Fetch(gomock.Any(), "https://github.com/luigi/castle.git", "refs/pull/123/head:pipes").Return(errors.New("rejected (non-fast-forward)"))
+
+	_, err := suite.prService.Checkout(suite.repo, false)
+	suite.EqualError(err, "failed to update pipes, it has commits that are not in the pull request rejected (non-fast-forward)")
+}
+
+func (suite *PRServiceTestSuite) TestCheckout_fork_branch_named_like_unrelated_local_branch_stays_put() {
+	suite.mockGitClient.EXPECT().TrackedChangeCount(gomock.Any()).Return(0, nil)
+	suite.expectPRHead(`{"baseRefName": "main", "headRefName": "feature", "isCrossRepository": true, "headRepositoryOwner": {"login": "toad"}}`)
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(cligit.RemoteSet{
+		cligit.NewRemote("origin", "https://github.com/luigi/castle.git"),
+	}, nil)
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("main", nil)
+	suite.mockGitClient.EXPECT().HasLocalBranch(gomock.Any(), "feature").Return(true)
+	suite.mockGitClient.EXPECT().Fetch(gomock.Any(), "origin", "refs/pull/123/head").Return(nil)
+	suite.mockGitClient.EXPECT().Fetch(gomock.Any(), "origin", "refs/pull/123/head:feature").Return(errors.New("rejected (non-fast-forward)"))
+	suite.mockGitClient.EXPECT().CheckoutBranch(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := suite.prService.Checkout(suite.repo, false)
+	suite.EqualError(err, "failed to update feature, it has commits that are not in the pull request rejected (non-fast-forward)")
+}
+
+func (suite *PRServiceTestSuite) TestCheckout_existing_branch_has_error_fetching() {
+	suite.mockGitClient.EXPECT().TrackedChangeCount(gomock.Any()).Return(0, nil)
+	suite.expectPRHead(`{"baseRefName": "main", "headRefName": "pipes"}`)
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(nil, nil)
+	suite.mockGitClient.EXPECT().CurrentBranch(gomock.Any()).Return("main", nil)
+	suite.mockGitClient.EXPECT().HasLocalBranch(gomock.Any(), "pipes").Return(true)
+	suite.mockGitClient.EXPECT().Fetch(gomock.Any(), "https://github.com/luigi/castle.git", "refs/pull/123/head").Return(errors.New("could not resolve host"))
+
+	_, err := suite.prService.Checkout(suite.repo, false)
+	suite.EqualError(err, "failed to fetch pipes could not resolve host")
+}
+
+func (suite *PRServiceTestSuite) TestLocalPath() {
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(cligit.RemoteSet{
+		cligit.NewRemote("origin", "https://github.com/mario/kart.git"),
+		cligit.NewRemote("upstream", "https://github.com/luigi/castle.git"),
+	}, nil)
+	suite.mockGitClient.EXPECT().ToplevelDir(gomock.Any()).Return("/home/mario/kart", nil)
+
+	path, err := suite.prService.LocalPath(&git.Repo{Owner: "luigi", Name: "castle"}, "internal/git/api.go")
+	suite.NoError(err)
+	suite.Equal(filepath.Join("/home/mario/kart", "internal", "git", "api.go"), path)
+}
+
+func (suite *PRServiceTestSuite) TestLocalPath_other_repository() {
+	suite.mockGitClient.EXPECT().Remotes(gomock.Any()).Return(cligit.RemoteSet{
+		cligit.NewRemote("origin", "https://github.com/mario/kart.git"),
+	}, nil)
+
+	_, err := suite.prService.LocalPath(&git.Repo{Owner: "luigi", Name: "mansion"}, "internal/git/api.go")
+	suite.EqualError(err, "luigi/mansion is not checked out here")
+}
+
+func (suite *PRServiceTestSuite) TestLocalHead_has_error() {
+	suite.mockGitClient.EXPECT().LastCommit(gomock.Any()).Return(nil, errors.New("not a git repository"))
+
+	_, err := suite.prService.LocalHead()
+	suite.EqualError(err, "failed to read the local commit not a git repository")
+}
+
 func TestPRServiceSuite(t *testing.T) {
 	suite.Run(t, new(PRServiceTestSuite))
 }
@@ -0,0 +1,230 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	githubql "github.com/cli/shurcooL-graphql"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github/graphql"
+)
+
+// GetMetadata fetches the title, description, draft state, labels, requested reviewers and assignees of the PR
+func (gh *PRClient) GetMetadata(repo *git.Repo) (*git.PRMetadata, error) {
+	prDetails, err := gh.getMetadata(repo)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &git.PRMetadata{
+		Id:      prDetails.Id,
+		Title:   prDetails.Title,
+		Body:    prDetails.Body,
+		IsDraft: prDetails.IsDraft,
+	}
+	for _, label := range prDetails.Labels.Nodes {
+		metadata.Labels = append(metadata.Labels, label.Name)
+	}
+	for _, request := range prDetails.ReviewRequests.Nodes {
+		metadata.Reviewers = append(metadata.Reviewers, reviewerName(request.RequestedReviewer))
+	}
+	for _, assignee := range prDetails.Assignees.Nodes {
+		metadata.Assignees = append(metadata.Assignees, assignee.Login)
+	}
+	return metadata, nil
+}
+
+func (gh *PRClient) getMetadata(repo *git.Repo) (*git.PullRequest, error) {
+	variables := map[string]interface{}{
+		"PullRequestId": githubql.Int(repo.PRNumber),
+		"Owner":         githubql.String(repo.Owner),
+		"RepoName":      githubql.String(repo.Name),
+	}
+	var response git.GitHubData
+	err := gh.graphQLClient.Do(graphql.PRMetadataQuery, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pr metadata %w", err)
+	}
+	return &response.Repository.PullRequest, nil
+}
+
+// reviewerName is the login of a user or org/team for a team
+func reviewerName(reviewer git.Reviewer) string {
+	if reviewer.Slug != "" {
+		return fmt.Sprintf("%s/%s", reviewer.Organization.Login, reviewer.Slug)
+	}
+	return reviewer.Login
+}
+
+func (gh *PRClient) EditTitle(prId string, title string) error {
+	variables := map[string]interface{}{
+		"id":    prId,
+		"title": title,
+	}
+	return gh.graphQLClient.Do(graphql.UpdatePRTitleMutation, variables, nil)
+}
+
+// AddLabels adds labels by name, they must already exist in the repo
+func (gh *PRClient) AddLabels(repo *git.Repo, prId string, names []string) error {
+	return gh.labels(graphql.AddLabelsMutation, repo, prId, names)
+}
+
+func (gh *PRClient) RemoveLabels(repo *git.Repo, prId string, names []string) error {
+	return gh.labels(graphql.RemoveLabelsMutation, repo, prId, names)
+}
+
+func (gh *PRClient) labels(mutation string, repo *git.Repo, prId string, names []string) error {
+	var ids []string
+	for _, name := range names {
+		variables := map[string]interface{}{
+			"Owner":    githubql.String(repo.Owner),
+			"RepoName": githubql.String(repo.Name),
+			"Name":     githubql.String(name),
+		}
+		var response git.GitHubData
+		err := gh.graphQLClient.Do(graphql.LabelQuery, variables, &response)
+		if err != nil {
+			return fmt.Errorf("failed to find label %s %w", name, err)
+		}
+		if response.Repository.Label.Id == "" {
+			return fmt.Errorf("label %s not found", name)
+		}
+		ids = append(ids, response.Repository.Label.Id)
+	}
+	variables := map[string]interface{}{
+		"id":       prId,
+		"labelIds": ids,
+	}
+	return gh.graphQLClient.Do(mutation, variables, nil)
+}
+
+// RequestReviewers asks users, or teams given as org/team, for a review, keeping anyone already asked
+func (gh *PRClient) RequestReviewers(prId string, reviewers []string) error {
+	userIds, teamIds, err := gh.reviewerIds(reviewers)
+	if err != nil {
+		return err
+	}
+	return gh.requestReviews(prId, userIds, teamIds, true)
+}
+
+// RemoveReviewers withdraws review requests from users, or teams given as org/team, without asking anyone else again
+func (gh *PRClient) RemoveReviewers(repo *git.Repo, reviewers []string) error {
+	users, teams := []string{}, []string{}
+	for _, reviewer := range reviewers {
+		if _, slug, isTeam := strings.Cut(reviewer, "/"); isTeam {
+			teams = append(teams, slug)
+		} else {
+			users = append(users, reviewer)
+		}
+	}
+	body, err := json.Marshal(map[string][]string{
+		"reviewers":      users,
+		"team_reviewers": teams,
+	})
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", repo.Owner, repo.Name, repo.PRNumber)
+	httpResponse, err := gh.restClient.Request(http.MethodDelete, path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to remove reviewers %w", err)
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to remove reviewers %s", httpResponse.Status)
+	}
+	return nil
+}
+
+func (gh *PRClient) requestReviews(prId string, userIds []string, teamIds []string, union bool) error {
+	variables := map[string]interface{}{
+		"id":      prId,
+		"userIds": userIds,
+		"teamIds": teamIds,
+		"union":   union,
+	}
+	return gh.graphQLClient.Do(graphql.RequestReviewsMutation, variables, nil)
+}
+
+func (gh *PRClient) reviewerIds(reviewers []string) ([]string, []string, error) {
+	userIds, teamIds := []string{}, []string{}
+	for _, reviewer := range reviewers {
+		org, slug, isTeam := strings.Cut(reviewer, "/")
+		if !isTeam {
+			id, err := gh.userId(reviewer)
+			if err != nil {
+				return nil, nil, err
+			}
+			userIds = append(userIds, id)
+			continue
+		}
+		variables := map[string]interface{}{
+			"Org":  githubql.String(org),
+			"Slug": githubql.String(slug),
+		}
+		var response git.GitHubData
+		err := gh.graphQLClient.Do(graphql.TeamQuery, variables, &response)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find team %s %w", reviewer, err)
+		}
+		if response.Organization.Team.Id == "" {
+			return nil, nil, fmt.Errorf("team %s not found", reviewer)
+		}
+		teamIds = append(teamIds, response.Organization.Team.Id)
+	}
+	return userIds, teamIds, nil
+}
+
+func (gh *PRClient) userId(login string) (string, error) {
+	variables := map[string]interface{}{
+		"Login": githubql.String(strings.TrimPrefix(login, "@")),
+	}
+	var response git.GitHubData
+	err := gh.graphQLClient.Do(graphql.UserQuery, variables, &response)
+	if err != nil {
+		return "", fmt.Errorf("failed to find user %s %w", login, err)
+	}
+	if response.User.Id == "" {
+		return "", fmt.Errorf("user %s not found", login)
+	}
+	return response.User.Id, nil
+}
+
+func (gh *PRClient) AddAssignees(prId string, logins []string) error {
+	return gh.assignees(graphql.AddAssigneesMutation, prId, logins)
+}
+
+func (gh *PRClient) RemoveAssignees(prId string, logins []string) error {
+	return gh.assignees(graphql.RemoveAssigneesMutation, prId, logins)
+}
+
+func (gh *PRClient) assignees(mutation string, prId string, logins []string) error {
+	var ids []string
+	for _, login := range logins {
+		id, err := gh.userId(login)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	variables := map[string]interface{}{
+		"id":          prId,
+		"assigneeIds": ids,
+	}
+	return gh.graphQLClient.Do(mutation, variables, nil)
+}
+
+// SetDraft converts the PR to a draft, or marks it ready for review when draft is false
+func (gh *PRClient) SetDraft(prId string, draft bool) error {
+	mutation := graphql.MarkReadyForReviewMutation
+	if draft {
+		mutation = graphql.ConvertToDraftMutation
+	}
+	variables := map[string]interface{}{
+		"id": prId,
+	}
+	return gh.graphQLClient.Do(mutation, variables, nil)
+}
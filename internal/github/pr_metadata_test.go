@@ -0,0 +1,154 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	githubql "github.com/cli/shurcooL-graphql"
+	"github.com/golang/mock/gomock"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github/graphql"
+)
+
+func (suite *PRServiceTestSuite) respondWith(query string, variables interface{}, data string) *gomock.Call {
+	return suite.mockGraphQL.EXPECT().
+		Do(query, variables, gomock.Any()).
+		DoAndReturn(func(query string, variables map[string]interface{}, response interface{}) error {
+			gr := GraphQLResponse{Data: response}
+			return json.Unmarshal([]byte(data), &gr)
+		})
+}
+
+var metadataResponse = `{"data": {"repository": {"pullRequest": {
+	"id": "PR_kwDOA",
+	"title": "Faster pipes",
+	"body": "Makes pipes faster",
+	"isDraft": true,
+	"labels": {"nodes": [{"id": "LA_1", "name": "bug"}]},
+	"assignees": {"nodes": [{"id": "U_1", "login": "mario"}]},
+	"reviewRequests": {"nodes": [
+		{"requestedReviewer": {"id": "U_2", "login": "peach"}},
+		{"requestedReviewer": {"id": "T_1", "slug": "plumbers", "organization": {"login": "mushroom"}}}
+	]}
+}}}}`
+
+func (suite *PRServiceTestSuite) metadataVariables() map[string]interface{} {
+	return map[string]interface{}{
+		"PullRequestId": githubql.Int(123),
+		"Owner":         githubql.String("luigi"),
+		"RepoName":      githubql.String("castle"),
+	}
+}
+
+func (suite *PRServiceTestSuite) TestGetMetadata() {
+	suite.respondWith(graphql.PRMetadataQuery, suite.metadataVariables(), metadataResponse)
+
+	metadata, err := suite.prService.GetMetadata(suite.repo)
+	suite.NoError(err)
+	suite.Equal(&git.PRMetadata{
+		Id:        "PR_kwDOA",
+		Title:     "Faster pipes",
+		Body:      "Makes pipes faster",
+		IsDraft:   true,
+		Labels:    []string{"bug"},
+		Reviewers: []string{"peach", "mushroom/plumbers"},
+		Assignees: []string{"mario"},
+	}, metadata)
+}
+
+func (suite *PRServiceTestSuite) TestGetMetadata_has_error() {
+	suite.mockGraphQL.EXPECT().Do(graphql.PRMetadataQuery, gomock.Any(), gomock.Any()).Return(errors.New("bad gateway"))
+
+	_, err := suite.prService.GetMetadata(suite.repo)
+	suite.EqualError(err, "failed to fetch pr metadata bad gateway")
+}
+
+func (suite *PRServiceTestSuite) TestEditTitle() {
+	suite.mockGraphQL.EXPECT().Do(graphql.UpdatePRTitleMutation, map[string]interface{}{"id": "PR_kwDOA", "title": "Even faster pipes"}, nil).Return(nil)
+
+	err := suite.prService.EditTitle("PR_kwDOA", "Even faster pipes")
+	suite.NoError(err)
+}
+
+func (suite *PRServiceTestSuite) TestAddLabels() {
+	suite.respondWith(graphql.LabelQuery, map[string]interface{}{
+		"Owner":    githubql.String("luigi"),
+		"RepoName": githubql.String("castle"),
+		"Name":     githubql.String("good first issue"),
+	}, `{"data": {"repository": {"label": {"id": "LA_2", "name": "good first issue"}}}}`)
+	suite.mockGraphQL.EXPECT().Do(graphql.AddLabelsMutation, map[string]interface{}{"id": "PR_kwDOA", "labelIds": []string{"LA_2"}}, nil).Return(nil)
+
+	err := suite.prService.AddLabels(suite.repo, "PR_kwDOA", []string{"good first issue"})
+	suite.NoError(err)
+}
+
+func (suite *PRServiceTestSuite) TestRemoveLabels_unknown_label() {
+	suite.respondWith(graphql.LabelQuery, gomock.Any(), `{"data": {"repository": {"label": null}}}`)
+
+	err := suite.prService.RemoveLabels(suite.repo, "PR_kwDOA", []string{"urgent"})
+	suite.EqualError(err, "label urgent not found")
+}
+
+func (suite *PRServiceTestSuite) TestRequestReviewers_users_and_teams() {
+	suite.respondWith(graphql.UserQuery, map[string]interface{}{"Login": githubql.String("peach")}, `{"data": {"user": {"id": "U_2", "login": "peach"}}}`)
+	suite.respondWith(graphql.TeamQuery, map[string]interface{}{"Org": githubql.String("mushroom"), "Slug": githubql.String("plumbers")}, `{"data": {"organization": {"team": {"id": "T_1", "slug": "plumbers"}}}}`)
+	suite.mockGraphQL.EXPECT().Do(graphql.RequestReviewsMutation, map[string]interface{}{
+		"id":      "PR_kwDOA",
+		"userIds": []string{"U_2"},
+		"teamIds": []string{"T_1"},
+		"union":   true,
+	}, nil).Return(nil)
+
+	err := suite.prService.RequestReviewers("PR_kwDOA", []string{"@peach", "mushroom/plumbers"})
+	suite.NoError(err)
+}
+
+func (suite *PRServiceTestSuite) TestRequestReviewers_unknown_team() {
+	suite.respondWith(graphql.TeamQuery, gomock.Any(), `{"data": {"organization": {"team": null}}}`)
+
+	err := suite.prService.RequestReviewers("PR_kwDOA", []string{"mushroom/bakers"})
+	suite.EqualError(err, "team mushroom/bakers not found")
+}
+
+func (suite *PRServiceTestSuite) TestRemoveReviewers_deletes_only_those_given() {
+	suite.mockREST.EXPECT().
+		Request(http.MethodDelete, "repos/luigi/castle/pulls/123/requested_reviewers", gomock.Any()).
+		DoAndReturn(func(method string, path string, body io.Reader) (*http.Response, error) {
+			sent, _ := io.ReadAll(body)
+			suite.JSONEq(`{"reviewers": ["peach"], "team_reviewers": ["plumbers"]}`, string(sent))
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+		})
+
+	err := suite.prService.RemoveReviewers(&git.Repo{Owner: "luigi", Name: "castle", PRNumber: 123}, []string{"peach", "mushroom/plumbers"})
+	suite.NoError(err)
+}
+
+func (suite *PRServiceTestSuite) TestRemoveReviewers_has_error() {
+	suite.mockREST.EXPECT().Request(http.MethodDelete, gomock.Any(), gomock.Any()).Return(&http.Response{
+		StatusCode: http.StatusUnprocessableEntity,
+		Status:     "422 Unprocessable Entity",
+		Body:       io.NopCloser(strings.NewReader("")),
+	}, nil)
+
+	err := suite.prService.RemoveReviewers(&git.Repo{Owner: "luigi", Name: "castle", PRNumber: 123}, []string{"peach"})
+	suite.EqualError(err, "failed to remove reviewers 422 Unprocessable Entity")
+}
+
+func (suite *PRServiceTestSuite) TestRemoveAssignees() {
+	suite.respondWith(graphql.UserQuery, map[string]interface{}{"Login": githubql.String("mario")}, `{"data": {"user": {"id": "U_1", "login": "mario"}}}`)
+	suite.mockGraphQL.EXPECT().Do(graphql.RemoveAssigneesMutation, map[string]interface{}{"id": "PR_kwDOA", "assigneeIds": []string{"U_1"}}, nil).Return(nil)
+
+	err := suite.prService.RemoveAssignees("PR_kwDOA", []string{"mario"})
+	suite.NoError(err)
+}
+
+func (suite *PRServiceTestSuite) TestSetDraft() {
+	suite.mockGraphQL.EXPECT().Do(graphql.ConvertToDraftMutation, map[string]interface{}{"id": "PR_kwDOA"}, nil).Return(nil)
+	suite.mockGraphQL.EXPECT().Do(graphql.MarkReadyForReviewMutation, map[string]interface{}{"id": "PR_kwDOA"}, nil).Return(nil)
+
+	suite.NoError(suite.prService.SetDraft("PR_kwDOA", true))
+	suite.NoError(suite.prService.SetDraft("PR_kwDOA", false))
+}
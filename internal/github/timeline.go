@@ -0,0 +1,191 @@
+package github
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	githubql "github.com/cli/shurcooL-graphql"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github/graphql"
+)
+
+var reviewEvents = map[string]string{
+	"APPROVED":          "approved these changes",
+	"CHANGES_REQUESTED": "requested changes",
+	"DISMISSED":         "had their review dismissed",
+}
+
+// GetPRTimeline gets the comments, reviews and events of a PR in the order they happened
+func (gh *PRClient) GetPRTimeline(repo *git.Repo, verbose bool) (*git.PR, error) {
+	variables := map[string]interface{}{
+		"PullRequestId": githubql.Int(repo.PRNumber),
+		"Owner":         githubql.String(repo.Owner),
+		"RepoName":      githubql.String(repo.Name),
+	}
+	var response git.GitHubData
+	err := gh.graphQLClient.Do(graphql.PRTimelineQuery(verbose), variables, &response)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pr timeline %w", err)
+	}
+
+	prDetails := response.Repository.PullRequest
+	items, err := gh.remainingTimelineItems(repo, prDetails.TimelineItems)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := gh.createComments(&prDetails, verbose)
+	timeline = append(timeline, reviewsWithoutBody(prDetails.Reviews.Nodes)...)
+	timeline = append(timeline, createEvents(items, prDetails.CreatedAt)...)
+	slices.SortStableFunc(timeline, func(i, j git.Comment) int {
+		return time.Time.Compare(i.CreatedAt, j.CreatedAt)
+	})
+
+	return &git.PR{
+		Comments: timeline,
+		State:    gh.createState(verbose, &prDetails),
+		Title:    prDetails.Title,
+		Id:       prDetails.Id,
+		Viewer:   response.Viewer.Login,
+		HeadOid:  prDetails.HeadRefOid,
+		URL:      prDetails.URL,
+	}, nil
+}
+
+// remainingTimelineItems adds the events after the first page, fetching 100 at a time
+func (gh *PRClient) remainingTimelineItems(repo *git.Repo, page git.TimelineItems) ([]git.TimelineItem, error) {
+	items := page.Nodes
+	for page.PageInfo.HasNextPage {
+		variables := map[string]interface{}{
+			"PullRequestId": githubql.Int(repo.PRNumber),
+			"Owner":         githubql.String(repo.Owner),
+			"RepoName":      githubql.String(repo.Name),
+			"Cursor":        githubql.String(page.PageInfo.EndCursor),
+		}
+		var response git.GitHubData
+		err := gh.graphQLClient.Do(graphql.PRTimelineItemsQuery, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch pr timeline %w", err)
+		}
+		page = response.Repository.PullRequest.TimelineItems
+		items = append(items, page.Nodes...)
+	}
+	return items, nil
+}
+
+// reviewsWithoutBody turns approvals and requests for changes that have no comment into events
+func reviewsWithoutBody(reviews []git.Comment) []git.Comment {
+	var events []git.Comment
+	for _, review := range reviews {
+		description, ok := reviewEvents[review.State]
+		if review.Body != "" || !ok {
+			continue
+		}
+		events = append(events, event(review.Author.Login, review.CreatedAt, description))
+	}
+	return events
+}
+
+// createEvents describes each event, commits pushed together by the same person are read as one push.
+// Commit dates say when a commit was made rather than pushed, a rebased commit can be far older than the PR, so a
+// push is never dated before the PR was opened or before the event ahead of it in the timeline.
+func createEvents(items []git.TimelineItem, opened time.Time) []git.Comment {
+	var events []git.Comment
+	var commits []git.TimelineCommit
+	earliest := opened
+	pushed := func() {
+		if len(commits) > 0 {
+			events = append(events, pushEvent(commits, earliest))
+			commits = nil
+		}
+	}
+
+	for _, item := range items {
+		if item.Typename == "PullRequestCommit" {
+			if len(commits) > 0 && commitAuthor(commits[0]) != commitAuthor(item.Commit) {
+				pushed()
+			}
+			commits = append(commits, item.Commit)
+			continue
+		}
+		pushed()
+
+		var description string
+		switch item.Typename {
+		case "HeadRefForcePushedEvent":
+			description = fmt.Sprintf("force pushed from %s to %s", item.BeforeCommit.AbbreviatedOid, item.AfterCommit.AbbreviatedOid)
+		case "LabeledEvent":
+			description = fmt.Sprintf("added the label %s", item.Label.Name)
+		case "UnlabeledEvent":
+			description = fmt.Sprintf("removed the label %s", item.Label.Name)
+		case "ReviewRequestedEvent":
+			reviewer := item.RequestedReviewer.Login
+			if reviewer == "" {
+				reviewer = item.RequestedReviewer.Name
+			}
+			description = fmt.Sprintf("requested a review from %s", reviewer)
+		case "ReadyForReviewEvent":
+			description = "marked this pull request as ready for review"
+		case "ConvertToDraftEvent":
+			description = "marked this pull request as draft"
+		case "ClosedEvent":
+			description = "closed this pull request"
+		case "ReopenedEvent":
+			description = "reopened this pull request"
+		case "MergedEvent":
+			description = fmt.Sprintf("merged commit %s into %s", item.Commit.AbbreviatedOid, item.MergeRefName)
+		default:
+			continue
+		}
+		itemEvent := event(item.Actor.Login, item.CreatedAt, description)
+		itemEvent.URL = item.Commit.URL
+		events = append(events, itemEvent)
+		if item.CreatedAt.After(earliest) {
+			earliest = item.CreatedAt
+		}
+	}
+	pushed()
+	return events
+}
+
+// pushEvent is dated by its newest commit, as the push came after it, but no earlier than earliest
+func pushEvent(commits []git.TimelineCommit, earliest time.Time) git.Comment {
+	lines := make([]string, len(commits))
+	pushedAt := earliest
+	for i, commit := range commits {
+		lines[i] = fmt.Sprintf("%s %s", commit.AbbreviatedOid, commit.MessageHeadline)
+		if commit.CommittedDate.After(pushedAt) {
+			pushedAt = commit.CommittedDate
+		}
+	}
+	description := "pushed 1 commit"
+	if len(commits) > 1 {
+		description = fmt.Sprintf("pushed %d commits", len(commits))
+	}
+	push := event(commitAuthor(commits[0]), pushedAt, description+"\n"+strings.Join(lines, "\n"))
+	push.URL = commits[len(commits)-1].URL
+	return push
+}
+
+func commitAuthor(commit git.TimelineCommit) string {
+	if commit.Author.User.Login != "" {
+		return commit.Author.User.Login
+	}
+	return commit.Author.Name
+}
+
+func event(actor string, createdAt time.Time, description string) git.Comment {
+	return git.Comment{
+		Author:    git.Author{Login: actor},
+		Body:      description,
+		CreatedAt: createdAt,
+		File: git.File{
+			FullPath: MainThread,
+			FileName: MainThread,
+		},
+		Kind: git.EventKind,
+	}
+}
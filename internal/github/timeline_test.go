@@ -0,0 +1,182 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	stdtime "time"
+
+	githubql "github.com/cli/shurcooL-graphql"
+	"github.com/golang/mock/gomock"
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/hbk619/gh-peruse/internal/github/graphql"
+)
+
+func (suite *PRServiceTestSuite) TestGetPRTimeline_interleaves_comments_and_events() {
+	prDetails := `{
+  "data": {
+    "viewer": {"login": "Mario"},
+    "repository": {
+      "pullRequest": {
+        "id": "PR_123",
+        "title": "Test pr",
+        "body": "Please review",
+        "author": {"login": "Mario"},
+        "createdAt": "2025-02-20T10:00:00Z",
+        "reviews": {
+          "nodes": [
+            {"id": "R_1", "author": {"login": "Peach"}, "state": "APPROVED", "body": "", "createdAt": "2025-02-20T14:00:00Z"},
+            {"id": "R_2", "author": {"login": "Luigi"}, "state": "COMMENTED", "body": "", "createdAt": "2025-02-20T15:00:00Z"}
+          ]
+        },
+        "reviewThreads": {"nodes": []},
+        "comments": {
+          "nodes": [
+            {"id": "IC_1", "body": "Can you add a label?", "author": {"login": "Peach"}, "createdAt": "2025-02-20T11:00:00Z"}
+          ]
+        },
+        "timelineItems": {
+          "nodes": [
+            {"__typename": "PullRequestCommit", "commit": {"abbreviatedOid": "abc1234", "messageHeadline": "Add castle", "committedDate": "2025-02-20T09:00:00Z", "author": {"name": "Mario Mario", "user": {"login": "Mario"}}}},
+            {"__typename": "PullRequestCommit", "commit": {"abbreviatedOid": "def5678", "messageHeadline": "Fix moat", "committedDate": "2025-02-20T09:30:00Z", "author": {"name": "Mario Mario", "user": {"login": "Mario"}}}},
+            {"__typename": "LabeledEvent", "createdAt": "2025-02-20T12:00:00Z", "actor": {"login": "Mario"}, "label": {"name": "bug"}},
+            {"__typename": "ReviewRequestedEvent", "createdAt": "2025-02-20T12:30:00Z", "actor": {"login": "Mario"}, "requestedReviewer": {"name": "plumbers"}},
+            {"__typename": "HeadRefForcePushedEvent", "createdAt": "2025-02-20T13:00:00Z", "actor": {"login": "Mario"}, "beforeCommit": {"abbreviatedOid": "def5678"}, "afterCommit": {"abbreviatedOid": "aaa1111"}},
+            {"__typename": "MergedEvent", "createdAt": "2025-02-20T16:00:00Z", "actor": {"login": "Peach"}, "mergeRefName": "main", "commit": {"abbreviatedOid": "bbb2222"}}
+          ]
+        }
+      }
+    }
+  }
+}`
+	mainThread := git.File{FullPath: MainThread, FileName: MainThread}
+	at := func(value string) stdtime.Time {
+		return timeMustParse(stdtime.RFC3339, value)
+	}
+	expected := &git.PR{
+		Comments: []git.Comment{
+			{Author: git.Author{Login: "Mario"}, Body: "Please review", CreatedAt: at("2025-02-20T10:00:00Z"), File: mainThread, Id: "PR_123", Kind: git.PullRequestKind},
+			{Author: git.Author{Login: "Mario"}, Body: "pushed 2 commits\nabc1234 Add castle\ndef5678 Fix moat", CreatedAt: at("2025-02-20T10:00:00Z"), File: mainThread, Kind: git.EventKind},
+			{Author: git.Author{Login: "Peach"}, Body: "Can you add a label?", CreatedAt: at("2025-02-20T11:00:00Z"), File: mainThread, Id: "IC_1", Kind: git.IssueCommentKind},
+			{Author: git.Author{Login: "Mario"}, Body: "added the label bug", CreatedAt: at("2025-02-20T12:00:00Z"), File: mainThread, Kind: git.EventKind},
+			{Author: git.Author{Login: "Mario"}, Body: "requested a review from plumbers", CreatedAt: at("2025-02-20T12:30:00Z"), File: mainThread, Kind: git.EventKind},
+			{Author: git.Author{Login: "Mario"}, Body: "force pushed from def5678 to aaa1111", CreatedAt: at("2025-02-20T13:00:00Z"), File: mainThread, Kind: git.EventKind},
+			{Author: git.Author{Login: "Peach"}, Body: "approved these changes", CreatedAt: at("2025-02-20T14:00:00Z"), File: mainThread, Kind: git.EventKind},
+			{Author: git.Author{Login: "Peach"}, Body: "merged commit bbb2222 into main", CreatedAt: at("2025-02-20T16:00:00Z"), File: mainThread, Kind: git.EventKind},
+		},
+		State:  git.State{},
+		Title:  "Test pr",
+		Id:     "PR_123",
+		Viewer: "Mario",
+	}
+	variables := map[string]interface{}{
+		"PullRequestId": githubql.Int(suite.repo.PRNumber),
+		"Owner":         githubql.String(suite.repo.Owner),
+		"RepoName":      githubql.String(suite.repo.Name),
+	}
+	suite.mockGraphQL.EXPECT().
+		Do(graphql.PRTimelineQuery(false), variables, gomock.Any()).
+		DoAndReturn(func(query string, variables map[string]interface{}, response interface{}) error {
+			gr := GraphQLResponse{Data: response}
+			err := json.Unmarshal([]byte(prDetails), &gr)
+			suite.NoError(err)
+			return nil
+		})
+
+	details, err := suite.prService.GetPRTimeline(suite.repo, false)
+	suite.NoError(err)
+	suite.Equal(expected, details)
+}
+
+func (suite *PRServiceTestSuite) TestGetPRTimeline_fetches_every_page_of_events() {
+	variables := map[string]interface{}{
+		"PullRequestId": githubql.Int(suite.repo.PRNumber),
+		"Owner":         githubql.String(suite.repo.Owner),
+		"RepoName":      githubql.String(suite.repo.Name),
+	}
+	nextPage := map[string]interface{}{
+		"PullRequestId": githubql.Int(suite.repo.PRNumber),
+		"Owner":         githubql.String(suite.repo.Owner),
+		"RepoName":      githubql.String(suite.repo.Name),
+		"Cursor":        githubql.String("Y3Vyc29y"),
+	}
+	gomock.InOrder(
+		suite.respondWith(graphql.PRTimelineQuery(false), variables, `{"data": {"repository": {"pullRequest": {
+			"id": "PR_123",
+			"author": {"login": "Mario"},
+			"createdAt": "2025-02-20T10:00:00Z",
+			"timelineItems": {
+				"pageInfo": {"hasNextPage": true, "endCursor": "Y3Vyc29y"},
+				"nodes": [{"__typename": "LabeledEvent", "createdAt": "2025-02-20T12:00:00Z", "actor": {"login": "Mario"}, "label": {"name": "bug"}}]
+			}
+		}}}}`),
+		suite.respondWith(graphql.PRTimelineItemsQuery, nextPage, `{"data": {"repository": {"pullRequest": {
+			"timelineItems": {
+				"pageInfo": {"hasNextPage": false},
+				"nodes": [{"__typename": "ClosedEvent", "createdAt": "2025-02-20T13:00:00Z", "actor": {"login": "Peach"}}]
+			}
+		}}}}`),
+	)
+
+	details, err := suite.prService.GetPRTimeline(suite.repo, false)
+	suite.NoError(err)
+	suite.Len(details.Comments, 2)
+	suite.Equal("added the label bug", details.Comments[0].Body)
+	suite.Equal("closed this pull request", details.Comments[1].Body)
+}
+
+func (suite *PRServiceTestSuite) TestGetPRTimeline_error_fetching_next_page() {
+	gomock.InOrder(
+		suite.respondWith(graphql.PRTimelineQuery(false), gomock.Any(), `{"data": {"repository": {"pullRequest": {
+			"timelineItems": {"pageInfo": {"hasNextPage": true, "endCursor": "Y3Vyc29y"}, "nodes": []}
+		}}}}`),
+		suite.mockGraphQL.EXPECT().Do(graphql.PRTimelineItemsQuery, gomock.Any(), gomock.Any()).Return(errors.New("bad")),
+	)
+
+	_, err := suite.prService.GetPRTimeline(suite.repo, false)
+	suite.EqualError(err, "failed to fetch pr timeline bad")
+}
+
+func (suite *PRServiceTestSuite) TestGetPRTimeline_error() {
+	suite.mockGraphQL.EXPECT().Do(graphql.PRTimelineQuery(true), gomock.Any(), gomock.Any()).Return(errors.New("bad"))
+
+	_, err := suite.prService.GetPRTimeline(suite.repo, true)
+	suite.EqualError(err, "failed to fetch pr timeline bad")
+}
+
+func (suite *PRServiceTestSuite) TestCreateEvents_splits_pushes_by_author() {
+	events := createEvents([]git.TimelineItem{
+		{Typename: "PullRequestCommit", Commit: git.TimelineCommit{AbbreviatedOid: "abc1234", MessageHeadline: "Add castle", Author: git.CommitAuthor{User: git.Author{Login: "Mario"}}, URL: "https://github.com/luigi/castle/commit/abc1234"}},
+		{Typename: "PullRequestCommit", Commit: git.TimelineCommit{AbbreviatedOid: "def5678", MessageHeadline: "Fix moat", Author: git.CommitAuthor{Name: "Luigi"}}},
+		{Typename: "SomethingNew"},
+	}, stdtime.Time{})
+	suite.Len(events, 2)
+	suite.Equal("pushed 1 commit\nabc1234 Add castle", events[0].Body)
+	suite.Equal("https://github.com/luigi/castle/commit/abc1234", events[0].URL)
+	suite.Equal("Luigi", events[1].Author.Login)
+}
+
+func (suite *PRServiceTestSuite) TestCreateEvents_dates_old_commits_by_when_they_were_pushed() {
+	at := func(value string) stdtime.Time {
+		return timeMustParse(stdtime.RFC3339, value)
+	}
+	events := createEvents([]git.TimelineItem{
+		{Typename: "PullRequestCommit", Commit: git.TimelineCommit{AbbreviatedOid: "abc1234", CommittedDate: at("2025-02-21T09:00:00Z"), Author: git.CommitAuthor{Name: "Mario"}}},
+		{Typename: "PullRequestCommit", Commit: git.TimelineCommit{AbbreviatedOid: "def5678", CommittedDate: at("2025-02-21T09:30:00Z"), Author: git.CommitAuthor{Name: "Mario"}}},
+		{Typename: "LabeledEvent", CreatedAt: at("2025-02-22T12:00:00Z"), Actor: git.Author{Login: "Peach"}, Label: git.Label{Name: "bug"}},
+		{Typename: "PullRequestCommit", Commit: git.TimelineCommit{AbbreviatedOid: "aaa1111", CommittedDate: at("2024-06-01T08:00:00Z"), Author: git.CommitAuthor{Name: "Mario"}}},
+	}, at("2025-02-20T10:00:00Z"))
+
+	suite.Len(events, 3)
+	suite.Equal(at("2025-02-21T09:30:00Z"), events[0].CreatedAt)
+	suite.Equal(at("2025-02-22T12:00:00Z"), events[2].CreatedAt)
+
+	events = createEvents([]git.TimelineItem{
+		{Typename: "PullRequestCommit", Commit: git.TimelineCommit{AbbreviatedOid: "aaa1111", CommittedDate: at("2024-06-01T08:00:00Z"), Author: git.CommitAuthor{Name: "Mario"}}},
+	}, at("2025-02-20T10:00:00Z"))
+	suite.Equal(at("2025-02-20T10:00:00Z"), events[0].CreatedAt)
+}
+
+func (suite *PRServiceTestSuite) TestDelete_event() {
+	err := suite.prService.Delete(&git.Comment{Kind: git.EventKind})
+	suite.EqualError(err, "cannot delete an event")
+}
@@ -2,9 +2,16 @@ package history
 
 import (
 	"encoding/json"
-	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"fmt"
 	"os"
 	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/git"
 )
 
 type (
@@ -13,16 +20,21 @@ type (
 		Save(h History) error
 	}
 	Service struct {
-		configPath string
-		fs         filesystem.FS
+		configPath  string
+		fs          filesystem.FS
+		currentRepo func() (repository.Repository, error)
 	}
 
 	PR struct {
 		CommentCount int
+		// LastSeen is when the newest comment was written the last time the PR was opened
+		LastSeen *time.Time        `json:",omitempty"`
+		Drafts   map[string]string `json:",omitempty"`
 	}
 
 	History struct {
-		Prs map[int]PR
+		// Prs are keyed by Key as PRs in different repositories can have the same number
+		Prs map[string]PR
 	}
 )
 
@@ -33,8 +45,9 @@ func NewHistoryService(basePath string, fs filesystem.FS) (*Service, error) {
 		return nil, err
 	}
 	return &Service{
-		configPath: path.Join(configPath, "gh-peruse-history.json"),
-		fs:         fs,
+		configPath:  path.Join(configPath, "gh-peruse-history.json"),
+		fs:          fs,
+		currentRepo: repository.Current,
 	}, nil
 }
 
@@ -43,7 +56,7 @@ func (service *Service) Load() (History, error) {
 	if err != nil {
 		if os.IsNotExist(err) {
 			return History{
-				Prs: make(map[int]PR),
+				Prs: make(map[string]PR),
 			}, nil
 		}
 		return History{}, err
@@ -54,9 +67,36 @@ func (service *Service) Load() (History, error) {
 	if err != nil {
 		return History{}, err
 	}
+	history.migrate(service.currentRepo)
 	return history, nil
 }
 
+// migrate moves PRs saved by number alone to their Key. Before PRs from other repositories could be read every PR
+// came from the repository in the current directory, when that is unknown the old entries are dropped instead
+func (history *History) migrate(currentRepo func() (repository.Repository, error)) {
+	var repo *git.Repo
+	looked := false
+	for key, pr := range history.Prs {
+		number, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		delete(history.Prs, key)
+		if !looked {
+			looked = true
+			if current, err := currentRepo(); err == nil {
+				repo = &git.Repo{Host: current.Host, Owner: current.Owner, Name: current.Name}
+			}
+		}
+		if repo == nil {
+			continue
+		}
+		if _, ok := history.Prs[Key(repo, number)]; !ok {
+			history.Prs[Key(repo, number)] = pr
+		}
+	}
+}
+
 func (service *Service) Save(history History) error {
 	marshalled, err := json.Marshal(history)
 	if err != nil {
@@ -65,3 +105,38 @@ func (service *Service) Save(history History) error {
 
 	return service.fs.SaveFile(service.configPath, marshalled)
 }
+
+// Key identifies the PR with the number in the repo, e.g. github.com/hbk619/gh-peruse#12. Issues and discussions
+// are numbered along with PRs so their drafts are kept under the same keys.
+func Key(repo *git.Repo, number int) string {
+	host := repo.Host
+	if host == "" {
+		host = "github.com"
+	}
+	return strings.ToLower(fmt.Sprintf("%s/%s/%s#%d", host, repo.Owner, repo.Name, number))
+}
+
+// Draft returns the unsent comment kept for a conversation on a PR, if any
+func (history History) Draft(pr string, key string) string {
+	return history.Prs[pr].Drafts[key]
+}
+
+// SetDraft keeps an unsent comment for a conversation on a PR, an empty body removes it
+func (history *History) SetDraft(pr string, key string, body string) {
+	if history.Prs == nil {
+		history.Prs = make(map[string]PR)
+	}
+	prHistory := history.Prs[pr]
+	if body == "" {
+		delete(prHistory.Drafts, key)
+		if len(prHistory.Drafts) == 0 {
+			prHistory.Drafts = nil
+		}
+	} else {
+		if prHistory.Drafts == nil {
+			prHistory.Drafts = make(map[string]string)
+		}
+		prHistory.Drafts[key] = body
+	}
+	history.Prs[pr] = prHistory
+}
@@ -7,8 +7,10 @@ import (
 	"path"
 	"testing"
 
+	"github.com/cli/go-gh/v2/pkg/repository"
 	"github.com/golang/mock/gomock"
 	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
+	"github.com/hbk619/gh-peruse/internal/git"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -34,27 +36,57 @@ func (suite *HistoryServiceTestSuite) TestLoad_returns_default_when_no_history()
 	history, err := suite.historyService.Load()
 	suite.NoError(err)
 	suite.Equal(History{
-		Prs: make(map[int]PR),
+		Prs: make(map[string]PR),
 	}, history)
 }
 
 func (suite *HistoryServiceTestSuite) TestLoad_returns_history_when_exists() {
 	expectedHistory := History{
-		Prs: map[int]PR{
-			2: {
+		Prs: map[string]PR{
+			"github.com/luigi/mansion#2": {
 				CommentCount: 4,
 			},
-			3: {
+			"github.com/luigi/mansion#3": {
 				CommentCount: 8,
 			},
 		},
 	}
-	suite.mockFilesystem.EXPECT().ReadFile("config/path").Return([]byte(`{"Prs":{"2":{"CommentCount": 4},"3":{"CommentCount":8}}}`), nil)
+	suite.mockFilesystem.EXPECT().ReadFile("config/path").Return([]byte(`{"Prs":{"github.com/luigi/mansion#2":{"CommentCount": 4},"github.com/luigi/mansion#3":{"CommentCount":8}}}`), nil)
 	history, err := suite.historyService.Load()
 	suite.NoError(err)
 	suite.Equal(expectedHistory, history)
 }
 
+func (suite *HistoryServiceTestSuite) TestLoad_moves_prs_saved_by_number_to_the_current_repository() {
+	suite.historyService.currentRepo = func() (repository.Repository, error) {
+		return repository.Repository{Host: "github.com", Owner: "luigi", Name: "mansion"}, nil
+	}
+	suite.mockFilesystem.EXPECT().ReadFile("config/path").Return([]byte(`{"Prs":{"2":{"CommentCount":4},"3":{"CommentCount":1},"github.com/luigi/mansion#3":{"CommentCount":8},"github.com/mario/kart#2":{"CommentCount":5}}}`), nil)
+	history, err := suite.historyService.Load()
+	suite.NoError(err)
+	suite.Equal(History{
+		Prs: map[string]PR{
+			"github.com/luigi/mansion#2": {CommentCount: 4},
+			"github.com/luigi/mansion#3": {CommentCount: 8},
+			"github.com/mario/kart#2":    {CommentCount: 5},
+		},
+	}, history)
+}
+
+func (suite *HistoryServiceTestSuite) TestLoad_drops_prs_saved_by_number_when_current_repository_unknown() {
+	suite.historyService.currentRepo = func() (repository.Repository, error) {
+		return repository.Repository{}, errors.New("not a git repository")
+	}
+	suite.mockFilesystem.EXPECT().ReadFile("config/path").Return([]byte(`{"Prs":{"2":{"CommentCount":4},"github.com/luigi/mansion#3":{"CommentCount":8}}}`), nil)
+	history, err := suite.historyService.Load()
+	suite.NoError(err)
+	suite.Equal(History{
+		Prs: map[string]PR{
+			"github.com/luigi/mansion#3": {CommentCount: 8},
+		},
+	}, history)
+}
+
 func (suite *HistoryServiceTestSuite) TestLoad_returns_default_and_err_when_reading_fails() {
 	streamClosed := fs.ErrClosed
 	suite.mockFilesystem.EXPECT().ReadFile("config/path").Return(nil, streamClosed)
@@ -64,7 +96,7 @@ func (suite *HistoryServiceTestSuite) TestLoad_returns_default_and_err_when_read
 }
 
 func (suite *HistoryServiceTestSuite) TestLoad_returns_default_and_err_when_json_invalid() {
-	suite.mockFilesystem.EXPECT().ReadFile("config/path").Return([]byte(`{Prs":{"2":{"CommentCount": 4},"3":{"CommentCount":8}}}`), nil)
+	suite.mockFilesystem.EXPECT().ReadFile("config/path").Return([]byte(`{Prs":{"2":{"CommentCount": 4},"github.com/luigi/mansion#3":{"CommentCount":8}}}`), nil)
 	history, err := suite.historyService.Load()
 	suite.ErrorContains(err, "invalid character")
 	suite.Equal(History{}, history)
@@ -72,47 +104,75 @@ func (suite *HistoryServiceTestSuite) TestLoad_returns_default_and_err_when_json
 
 func (suite *HistoryServiceTestSuite) TestSave_saves_history() {
 	history := History{
-		Prs: map[int]PR{
-			2: {
+		Prs: map[string]PR{
+			"github.com/luigi/mansion#2": {
 				CommentCount: 4,
 			},
-			3: {
+			"github.com/luigi/mansion#3": {
 				CommentCount: 8,
 			},
 		},
 	}
-	suite.mockFilesystem.EXPECT().SaveFile("config/path", []byte(`{"Prs":{"2":{"CommentCount":4},"3":{"CommentCount":8}}}`))
+	suite.mockFilesystem.EXPECT().SaveFile("config/path", []byte(`{"Prs":{"github.com/luigi/mansion#2":{"CommentCount":4},"github.com/luigi/mansion#3":{"CommentCount":8}}}`))
 	err := suite.historyService.Save(history)
 	suite.NoError(err)
 }
 
 func (suite *HistoryServiceTestSuite) TestSave_returns_error_if_save_fails() {
 	history := History{
-		Prs: map[int]PR{
-			2: {
+		Prs: map[string]PR{
+			"github.com/luigi/mansion#2": {
 				CommentCount: 4,
 			},
-			3: {
+			"github.com/luigi/mansion#3": {
 				CommentCount: 8,
 			},
 		},
 	}
 	expectedError := errors.New("uh oh")
-	suite.mockFilesystem.EXPECT().SaveFile("config/path", []byte(`{"Prs":{"2":{"CommentCount":4},"3":{"CommentCount":8}}}`)).
+	suite.mockFilesystem.EXPECT().SaveFile("config/path", []byte(`{"Prs":{"github.com/luigi/mansion#2":{"CommentCount":4},"github.com/luigi/mansion#3":{"CommentCount":8}}}`)).
 		Return(expectedError)
 	err := suite.historyService.Save(history)
 	suite.ErrorIs(err, expectedError)
 }
 
-func (suite *HistoryServiceTestSuite) TestNewHistoryService() {
-	expectedService := &Service{
-		configPath: path.Join("base/path", ".config", "gh-peruse-history.json"),
-		fs:         suite.mockFilesystem,
+func (suite *HistoryServiceTestSuite) TestSave_saves_drafts() {
+	history := History{
+		Prs: map[string]PR{
+			"github.com/luigi/mansion#2": {
+				CommentCount: 4,
+				Drafts:       map[string]string{"thread": "Not sure"},
+			},
+		},
 	}
+	suite.mockFilesystem.EXPECT().SaveFile("config/path", []byte(`{"Prs":{"github.com/luigi/mansion#2":{"CommentCount":4,"Drafts":{"thread":"Not sure"}}}}`))
+	err := suite.historyService.Save(history)
+	suite.NoError(err)
+}
+
+func (suite *HistoryServiceTestSuite) TestSetDraft_adds_and_removes_drafts() {
+	history := History{}
+	history.SetDraft("github.com/luigi/mansion#2", "thread", "Not sure")
+	suite.Equal("Not sure", history.Draft("github.com/luigi/mansion#2", "thread"))
+	suite.Equal("", history.Draft("github.com/luigi/mansion#3", "thread"))
+	suite.Equal("", history.Draft("github.com/mario/kart#2", "thread"))
+
+	history.SetDraft("github.com/luigi/mansion#2", "thread", "")
+	suite.Equal(History{Prs: map[string]PR{"github.com/luigi/mansion#2": {}}}, history)
+}
+
+func (suite *HistoryServiceTestSuite) TestKey_includes_host_and_repository() {
+	suite.Equal("github.com/luigi/mansion#2", Key(&git.Repo{Owner: "Luigi", Name: "mansion"}, 2))
+	suite.Equal("github.example.com/luigi/mansion#2", Key(&git.Repo{Host: "github.example.com", Owner: "luigi", Name: "mansion"}, 2))
+}
+
+func (suite *HistoryServiceTestSuite) TestNewHistoryService() {
 	suite.mockFilesystem.EXPECT().MkdirAll(path.Join("base/path", ".config"), os.ModeDir).Return(nil)
 	service, err := NewHistoryService("base/path", suite.mockFilesystem)
 	suite.NoError(err)
-	suite.Equal(service, expectedService)
+	suite.Equal(path.Join("base/path", ".config", "gh-peruse-history.json"), service.configPath)
+	suite.Equal(suite.mockFilesystem, service.fs)
+	suite.NotNil(service.currentRepo)
 }
 
 func (suite *HistoryServiceTestSuite) TestNewHistoryService_returns_err_if_config_dir_cannot_be_made() {
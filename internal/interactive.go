@@ -1,10 +1,13 @@
 package internal
 
-import "fmt"
+import "github.com/hbk619/gh-peruse/internal/filesystem"
 
 type Interactive struct {
 	Index    int
 	MaxIndex int
+	Output   filesystem.Output
+	// OnEnd is called when moving past either end of the results
+	OnEnd func()
 }
 
 func (i *Interactive) Next(print func()) {
@@ -12,7 +15,7 @@ func (i *Interactive) Next(print func()) {
 		i.Index++
 		print()
 	} else {
-		fmt.Println("Nothing here")
+		i.end()
 	}
 }
 
@@ -21,10 +24,17 @@ func (i *Interactive) Previous(print func()) {
 		i.Index--
 		print()
 	} else {
-		fmt.Println("Nothing here")
+		i.end()
 	}
 }
 
 func (i *Interactive) Repeat(print func()) {
 	print()
 }
+
+func (i *Interactive) end() {
+	if i.OnEnd != nil {
+		i.OnEnd()
+	}
+	_ = i.Output.Println("Nothing here")
+}
@@ -4,18 +4,21 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
 	"github.com/stretchr/testify/suite"
 )
 
 type InteractiveTestSuite struct {
 	suite.Suite
 	ctrl        *gomock.Controller
+	mockOutput  *mock_filesystem.MockOutput
 	interactive *Interactive
 }
 
 func (suite *InteractiveTestSuite) BeforeTest(string, string) {
 	suite.ctrl = gomock.NewController(suite.T())
-	suite.interactive = &Interactive{}
+	suite.mockOutput = mock_filesystem.NewMockOutput(suite.ctrl)
+	suite.interactive = &Interactive{Output: suite.mockOutput}
 }
 
 func (suite *InteractiveTestSuite) TestNext_moves_on_when_less_than_max_index() {
@@ -41,6 +44,7 @@ func (suite *InteractiveTestSuite) TestNext_does_not_move_on_when_equal_to_max_i
 
 	suite.interactive.MaxIndex = 2
 	suite.interactive.Index = 2
+	suite.mockOutput.EXPECT().Println("Nothing here")
 
 	suite.interactive.Next(print)
 
@@ -71,6 +75,7 @@ func (suite *InteractiveTestSuite) TestPrevious_does_not_move_on_when_on_first_i
 
 	suite.interactive.MaxIndex = 2
 	suite.interactive.Index = 0
+	suite.mockOutput.EXPECT().Println("Nothing here")
 
 	suite.interactive.Previous(print)
 
@@ -78,6 +83,25 @@ func (suite *InteractiveTestSuite) TestPrevious_does_not_move_on_when_on_first_i
 	suite.Equal(0, suite.interactive.Index)
 }
 
+func (suite *InteractiveTestSuite) TestNext_and_previous_call_on_end_at_the_ends() {
+	ends := 0
+	suite.interactive.OnEnd = func() {
+		ends++
+	}
+	suite.interactive.MaxIndex = 1
+	suite.interactive.Index = 1
+	suite.mockOutput.EXPECT().Println("Nothing here").Times(2)
+
+	suite.interactive.Next(func() {})
+	suite.Equal(1, ends)
+
+	suite.interactive.Previous(func() {})
+	suite.Equal(1, ends)
+
+	suite.interactive.Previous(func() {})
+	suite.Equal(2, ends)
+}
+
 func (suite *InteractiveTestSuite) TestRepeat_calls_print() {
 	called := false
 	print := func() {
@@ -0,0 +1,184 @@
+package internal
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"golang.org/x/term"
+)
+
+// singleKeys are the commands that act as soon as the key is pressed, arrow keys navigate. A letter waits for
+// enter like any other command when a longer command starts with it
+var singleKeys = map[string]string{
+	"n":      "n",
+	"p":      "p",
+	"r":      "r",
+	"q":      "q",
+	"\x1b[C": "n",
+	"\x1b[B": "n",
+	"\x1b[D": "p",
+	"\x1b[A": "p",
+	"\x03":   "q",
+	"\x04":   "q",
+}
+
+// KeyPrompter reads from a raw terminal so commands act on a single key press and typed lines can be edited,
+// recalled with the up and down arrows and have @mentions completed with tab
+type KeyPrompter struct {
+	input    io.Reader
+	echo     io.Writer
+	output   filesystem.Output
+	terminal *term.Terminal
+	pending  []byte
+	raw      func() (func(), error)
+	complete func(text string) (string, []string)
+	commands []string
+}
+
+func NewKeyPrompt(input *os.File, echo io.Writer, output filesystem.Output) *KeyPrompter {
+	fd := int(input.Fd())
+	return newKeyPrompter(input, echo, output, func() (func(), error) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return nil, err
+		}
+		return func() {
+			_ = term.Restore(fd, state)
+		}, nil
+	})
+}
+
+func newKeyPrompter(input io.Reader, echo io.Writer, output filesystem.Output, raw func() (func(), error)) *KeyPrompter {
+	prompter := &KeyPrompter{
+		input:  input,
+		echo:   echo,
+		output: output,
+		raw:    raw,
+	}
+	prompter.terminal = term.NewTerminal(prompter, "")
+	prompter.terminal.AutoCompleteCallback = prompter.autoComplete
+	return prompter
+}
+
+// IsTerminal reports whether single key input can be used with the file
+func IsTerminal(file *os.File) bool {
+	return term.IsTerminal(int(file.Fd()))
+}
+
+func (prompter *KeyPrompter) Command(label string) string {
+	_ = prompter.output.Print(label + ": ")
+	restore, err := prompter.raw()
+	if err != nil {
+		return prompter.readCommand()
+	}
+	defer restore()
+
+	key := make([]byte, 16)
+	n, err := prompter.Read(key)
+	if err != nil {
+		return "q"
+	}
+	pressed := string(key[:n])
+	if command, ok := singleKeys[pressed]; ok && ActsOnPress(pressed, prompter.commands) {
+		_, _ = prompter.echo.Write([]byte(command + "\r\n"))
+		return command
+	}
+	prompter.pending = append(prompter.pending, key[:n]...)
+	return prompter.readCommand()
+}
+
+func (prompter *KeyPrompter) String(label string) string {
+	_ = prompter.output.Print(label + ": ")
+	restore, err := prompter.raw()
+	if err == nil {
+		defer restore()
+	}
+	line, _ := prompter.readLine()
+	return line
+}
+
+func (prompter *KeyPrompter) Lines(label string, terminator string) string {
+	var lines []string
+	_ = prompter.output.Print(label + ":\n")
+	restore, err := prompter.raw()
+	if err == nil {
+		defer restore()
+	}
+	for {
+		line, err := prompter.terminal.ReadLine()
+		if strings.TrimSpace(line) == terminator {
+			break
+		}
+		if line != "" || err == nil {
+			lines = append(lines, line)
+		}
+		if err != nil {
+			break
+		}
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// SetCommands tells the prompt every command that can be typed
+func (prompter *KeyPrompter) SetCommands(commands []string) {
+	prompter.commands = commands
+}
+
+func (prompter *KeyPrompter) SetCompletion(complete func(text string) (string, []string)) {
+	prompter.complete = complete
+}
+
+// Read gives the terminal any keys read while waiting for a single key press before reading more input
+func (prompter *KeyPrompter) Read(p []byte) (int, error) {
+	if len(prompter.pending) > 0 {
+		n := copy(p, prompter.pending)
+		prompter.pending = prompter.pending[n:]
+		return n, nil
+	}
+	return prompter.input.Read(p)
+}
+
+func (prompter *KeyPrompter) Write(p []byte) (int, error) {
+	return prompter.echo.Write(p)
+}
+
+// ActsOnPress reports whether the key runs its command as soon as it is pressed in single key mode, which it does
+// unless a longer one of the commands starts with it
+func ActsOnPress(key string, commands []string) bool {
+	if _, ok := singleKeys[key]; !ok {
+		return false
+	}
+	for _, command := range commands {
+		if len(command) > len(key) && strings.HasPrefix(command, key) {
+			return false
+		}
+	}
+	return true
+}
+
+// readCommand reads the rest of a command, returning q once the input has ended so the command loop stops
+func (prompter *KeyPrompter) readCommand() string {
+	line, err := prompter.readLine()
+	if err != nil && line == "" {
+		return "q"
+	}
+	return line
+}
+
+func (prompter *KeyPrompter) readLine() (string, error) {
+	line, err := prompter.terminal.ReadLine()
+	return strings.TrimSpace(line), err
+}
+
+func (prompter *KeyPrompter) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' || prompter.complete == nil {
+		return "", 0, false
+	}
+	completed, candidates := prompter.complete(line[:pos])
+	if len(candidates) > 0 {
+		_, _ = prompter.terminal.Write([]byte(strings.Join(candidates, " ") + "\n"))
+	}
+	return completed + line[pos:], len(completed), true
+}
@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/golang/mock/gomock"
+	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type KeyPromptTestSuite struct {
+	suite.Suite
+	mockOutput *mock_filesystem.MockOutput
+	ctrl       *gomock.Controller
+	echo       *bytes.Buffer
+	rawCalls   int
+}
+
+func (suite *KeyPromptTestSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOutput = mock_filesystem.NewMockOutput(suite.ctrl)
+	suite.echo = &bytes.Buffer{}
+	suite.rawCalls = 0
+}
+
+func (suite *KeyPromptTestSuite) prompter(input string) *KeyPrompter {
+	return newKeyPrompter(strings.NewReader(input), suite.echo, suite.mockOutput, func() (func(), error) {
+		suite.rawCalls++
+		return func() {}, nil
+	})
+}
+
+func (suite *KeyPromptTestSuite) TestCommand_single_key() {
+	suite.mockOutput.EXPECT().Print("choose: ")
+	result := suite.prompter("n").Command("choose")
+
+	suite.Equal("n", result)
+	suite.Equal(1, suite.rawCalls)
+}
+
+func (suite *KeyPromptTestSuite) TestCommand_arrow_keys_navigate() {
+	suite.mockOutput.EXPECT().Print("choose: ").Times(2)
+	suite.Equal("p", suite.prompter("\x1b[D").Command("choose"))
+	suite.Equal("n", suite.prompter("\x1b[C").Command("choose"))
+}
+
+func (suite *KeyPromptTestSuite) TestCommand_longer_commands_need_enter() {
+	suite.mockOutput.EXPECT().Print("choose: ")
+	result := suite.prompter("res\r").Command("choose")
+
+	suite.Equal("res", result)
+}
+
+func (suite *KeyPromptTestSuite) TestCommand_quits_when_input_ends_mid_command() {
+	suite.mockOutput.EXPECT().Print("choose: ")
+	prompter := suite.typedPrompter("r")
+	prompter.SetCommands([]string{"r", "res"})
+
+	suite.Equal("q", prompter.Command("choose"))
+}
+
+func (suite *KeyPromptTestSuite) TestActsOnPress() {
+	suite.True(ActsOnPress("r", []string{"n", "r", "done"}))
+	suite.False(ActsOnPress("r", []string{"n", "r", "res"}))
+	suite.False(ActsOnPress("c", []string{"c", "cm"}))
+}
+
+func (suite *KeyPromptTestSuite) typedPrompter(input string) *KeyPrompter {
+	return newKeyPrompter(iotest.OneByteReader(strings.NewReader(input)), suite.echo, suite.mockOutput, func() (func(), error) {
+		return func() {}, nil
+	})
+}
+
+func (suite *KeyPromptTestSuite) TestCommand_key_starting_longer_command_waits_for_enter() {
+	suite.mockOutput.EXPECT().Print("choose: ").Times(3)
+	commands := []string{"n", "r", "res", "react", "q", "qr"}
+	prompter := suite.typedPrompter("res\rr\rn")
+	prompter.SetCommands(commands)
+
+	suite.Equal("res", prompter.Command("choose"))
+	suite.Equal("r", prompter.Command("choose"))
+	suite.Equal("n", prompter.Command("choose"))
+}
+
+func (suite *KeyPromptTestSuite) TestCommand_typed_one_key_at_a_time() {
+	suite.mockOutput.EXPECT().Print("choose: ").Times(2)
+	prompter := suite.typedPrompter("nt\rn\r")
+	prompter.SetCommands([]string{"n", "nt", "p", "pt"})
+
+	suite.Equal("nt", prompter.Command("choose"))
+	suite.Equal("n", prompter.Command("choose"))
+}
+
+func (suite *KeyPromptTestSuite) TestCommand_end_of_input_quits() {
+	suite.mockOutput.EXPECT().Print("choose: ")
+	suite.Equal("q", suite.prompter("").Command("choose"))
+}
+
+func (suite *KeyPromptTestSuite) TestString_supports_line_editing() {
+	suite.mockOutput.EXPECT().Print("comment: ")
+	result := suite.prompter("LGTN\x7fM\x1b[D\x1b[D\x1b[D!\r").String("comment")
+
+	suite.Equal("L!GTM", result)
+}
+
+func (suite *KeyPromptTestSuite) TestString_recalls_history() {
+	suite.mockOutput.EXPECT().Print("comment: ").Times(2)
+	prompter := suite.prompter("first\r\x1b[A\r")
+
+	suite.Equal("first", prompter.String("comment"))
+	suite.Equal("first", prompter.String("comment"))
+}
+
+func (suite *KeyPromptTestSuite) TestString_completes_on_tab() {
+	suite.mockOutput.EXPECT().Print("comment: ")
+	prompter := suite.prompter("thanks @lu\tfor this\r")
+	prompter.SetCompletion(NewMentions([]string{"Luigi"}).Complete)
+
+	suite.Equal("thanks @Luigi for this", prompter.String("comment"))
+}
+
+func (suite *KeyPromptTestSuite) TestLines_reads_until_terminator() {
+	suite.mockOutput.EXPECT().Print("comment:\n")
+	result := suite.prompter("first\r\r  second\r.\r").Lines("comment", ".")
+
+	suite.Equal("first\n\n  second", result)
+}
+
+func TestKeyPromptSuite(t *testing.T) {
+	suite.Run(t, new(KeyPromptTestSuite))
+}
@@ -0,0 +1,240 @@
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	htmlComment   = regexp.MustCompile(`(?s)<!--.*?-->`)
+	details       = regexp.MustCompile(`(?is)<details[^>]*>\s*(?:<summary[^>]*>(.*?)</summary>)?.*?</details>`)
+	htmlImage     = regexp.MustCompile(`(?i)<img[^>]*?alt="([^"]*)"[^>]*>`)
+	htmlBreak     = regexp.MustCompile(`(?i)<br\s*/?>`)
+	htmlTag       = regexp.MustCompile(`(?i)</?(?:a|b|i|em|strong|sub|sup|p|div|span|img|picture|source|details|summary|code|pre|kbd|ins|del|h[1-6]|ul|ol|li|hr|table|thead|tbody|tr|th|td|blockquote)(?:\s[^<>]*)?/?>`)
+	fence         = regexp.MustCompile("^\\s*(```+|~~~+)\\s*([^`\\s]*)")
+	heading       = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)\s*#*\s*$`)
+	rule          = regexp.MustCompile(`^\s{0,3}([-*_])(\s*[-*_]){2,}\s*$`)
+	quote         = regexp.MustCompile(`^\s{0,3}>\s?`)
+	task          = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+`)
+	bullet        = regexp.MustCompile(`^\s*[-*+]\s+`)
+	tableDivider  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	image         = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]*)[^)]*\)`)
+	link          = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]*)[^)]*\)`)
+	autoLink      = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+	strong        = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	emphasis      = regexp.MustCompile(`\*([^*\s](?:[^*]*[^*\s])?)\*`)
+	underscore    = regexp.MustCompile(`(^|[^\w])_([^_\s](?:[^_]*[^_\s])?)_([^\w]|$)`)
+	strikethrough = regexp.MustCompile(`~~(.+?)~~`)
+	escaped       = regexp.MustCompile(`\\([\\` + "`" + `*_{}\[\]()#+\-.!|>~])`)
+	blankLines    = regexp.MustCompile(`\n{3,}`)
+	maskedCode    = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// Renderer turns Markdown into text that reads well with a screen reader, markup is dropped and
+// structure like code blocks, quotes and tables is announced instead
+type Renderer struct {
+	ReadURLs bool
+}
+
+func NewRenderer() *Renderer {
+	return &Renderer{}
+}
+
+func (renderer *Renderer) Render(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text, code := maskCode(strings.Split(text, "\n"))
+	text = htmlComment.ReplaceAllString(text, "")
+	text = details.ReplaceAllStringFunc(text, func(match string) string {
+		summary := strings.TrimSpace(htmlTag.ReplaceAllString(details.FindStringSubmatch(match)[1], ""))
+		if summary == "" {
+			return "collapsed section"
+		}
+		return "collapsed section, " + summary
+	})
+	text = maskedCode.ReplaceAllStringFunc(text, func(match string) string {
+		index, _ := strconv.Atoi(maskedCode.FindStringSubmatch(match)[1])
+		return code[index]
+	})
+
+	lines := renderer.blocks(strings.Split(text, "\n"))
+	rendered := blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(rendered)
+}
+
+func (renderer *Renderer) blocks(lines []string) []string {
+	var rendered []string
+	quoted := false
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if quote.MatchString(line) != quoted && (strings.TrimSpace(line) != "" || quoted) {
+			quoted = !quoted
+			if quoted {
+				rendered = append(rendered, "quote")
+			} else {
+				rendered = append(rendered, "end quote")
+			}
+		}
+		if quoted {
+			line = quote.ReplaceAllString(line, "")
+		}
+
+		if match := fence.FindStringSubmatch(line); match != nil {
+			end := closingFence(lines, i+1, match[1])
+			if match[2] != "" {
+				rendered = append(rendered, "code block, "+match[2])
+			} else {
+				rendered = append(rendered, "code block")
+			}
+			for _, code := range lines[i+1 : end] {
+				if quoted {
+					code = quote.ReplaceAllString(code, "")
+				}
+				rendered = append(rendered, code)
+			}
+			rendered = append(rendered, "end code block")
+			i = end
+			continue
+		}
+
+		if i+1 < len(lines) && strings.Contains(line, "|") && tableDivider.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-") {
+			end := i + 2
+			for end < len(lines) && strings.Contains(lines[end], "|") && strings.TrimSpace(lines[end]) != "" {
+				end++
+			}
+			rendered = append(rendered, renderer.table(lines[i], lines[i+2:end])...)
+			i = end - 1
+			continue
+		}
+
+		rendered = append(rendered, renderer.line(line))
+	}
+	if quoted {
+		rendered = append(rendered, "end quote")
+	}
+	return rendered
+}
+
+// maskCode swaps each fenced code block for a placeholder so HTML comments and collapsed sections are only
+// removed outside code, it returns the masked text and the code blocks in the order of their placeholders
+func maskCode(lines []string) (string, []string) {
+	var masked, code []string
+	for i := 0; i < len(lines); i++ {
+		match := fence.FindStringSubmatch(quote.ReplaceAllString(lines[i], ""))
+		if match == nil {
+			masked = append(masked, lines[i])
+			continue
+		}
+		end := min(closingFence(lines, i+1, match[1]), len(lines)-1)
+		masked = append(masked, fmt.Sprintf("\x00%d\x00", len(code)))
+		code = append(code, strings.Join(lines[i:end+1], "\n"))
+		i = end
+	}
+	return strings.Join(masked, "\n"), code
+}
+
+// closingFence finds the line closing a code block, as in CommonMark it is a run of the opening fence character at
+// least as long as the opening fence with nothing after it. Unclosed blocks run to the end.
+func closingFence(lines []string, start int, opening string) int {
+	for end := start; end < len(lines); end++ {
+		line := quote.ReplaceAllString(lines[end], "")
+		trimmed := strings.TrimLeft(line, " ")
+		if len(line)-len(trimmed) > 3 {
+			continue
+		}
+		rest := strings.TrimLeft(trimmed, opening[:1])
+		if len(trimmed)-len(rest) >= len(opening) && strings.TrimSpace(rest) == "" {
+			return end
+		}
+	}
+	return len(lines)
+}
+
+func (renderer *Renderer) line(line string) string {
+	if rule.MatchString(line) {
+		return ""
+	}
+	if match := heading.FindStringSubmatch(line); match != nil {
+		return fmt.Sprintf("heading level %d, %s", len(match[1]), renderer.Inline(match[2]))
+	}
+	if match := task.FindStringSubmatch(line); match != nil {
+		state := "unchecked, "
+		if match[1] != " " {
+			state = "checked, "
+		}
+		return state + renderer.Inline(line[len(match[0]):])
+	}
+	line = bullet.ReplaceAllString(line, "")
+	return renderer.Inline(line)
+}
+
+// table reads each row as a list of heading and value pairs
+func (renderer *Renderer) table(header string, rows []string) []string {
+	headings := cells(header)
+	rendered := []string{fmt.Sprintf("table, %d columns, %d rows", len(headings), len(rows))}
+	for number, row := range rows {
+		var values []string
+		for column, value := range cells(row) {
+			value = renderer.Inline(value)
+			if column < len(headings) && headings[column] != "" {
+				value = renderer.Inline(headings[column]) + " " + value
+			}
+			values = append(values, strings.TrimSpace(value))
+		}
+		rendered = append(rendered, fmt.Sprintf("row %d, %s", number+1, strings.Join(values, ", ")))
+	}
+	return append(rendered, "end table")
+}
+
+func cells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+	parts := strings.Split(strings.ReplaceAll(row, `\|`, "\x00"), "|")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(strings.ReplaceAll(part, "\x00", "|"))
+	}
+	return parts
+}
+
+// Inline drops emphasis, code and link markup from a single line, code spans are read as written
+func (renderer *Renderer) Inline(text string) string {
+	spans := strings.Split(text, "`")
+	if len(spans)%2 == 0 {
+		// an unmatched backtick is read as text
+		spans[len(spans)-2] += "`" + spans[len(spans)-1]
+		spans = spans[:len(spans)-1]
+	}
+	for i := 0; i < len(spans); i += 2 {
+		spans[i] = renderer.text(spans[i])
+	}
+	return strings.TrimRight(strings.Join(spans, ""), " ")
+}
+
+func (renderer *Renderer) text(text string) string {
+	text = htmlImage.ReplaceAllString(text, "image $1")
+	text = htmlBreak.ReplaceAllString(text, " ")
+	text = htmlTag.ReplaceAllString(text, "")
+	text = image.ReplaceAllStringFunc(text, func(match string) string {
+		alt := image.FindStringSubmatch(match)[1]
+		if alt == "" {
+			return "image"
+		}
+		return "image " + alt
+	})
+	text = link.ReplaceAllStringFunc(text, func(match string) string {
+		groups := link.FindStringSubmatch(match)
+		if renderer.ReadURLs && groups[2] != "" && groups[2] != groups[1] {
+			return fmt.Sprintf("%s (%s)", groups[1], groups[2])
+		}
+		return groups[1]
+	})
+	text = autoLink.ReplaceAllString(text, "$1")
+	text = strong.ReplaceAllString(text, "$1$2")
+	text = emphasis.ReplaceAllString(text, "$1")
+	text = underscore.ReplaceAllString(text, "$1$2$3")
+	text = strikethrough.ReplaceAllString(text, "$1")
+	return escaped.ReplaceAllString(text, "$1")
+}
@@ -0,0 +1,106 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SpeechTestSuite struct {
+	suite.Suite
+	renderer *Renderer
+}
+
+func (suite *SpeechTestSuite) BeforeTest(suiteName, testName string) {
+	suite.renderer = NewRenderer()
+}
+
+func (suite *SpeechTestSuite) TestRender_plain_text_is_unchanged() {
+	suite.Equal("Looks good to me", suite.renderer.Render("Looks good to me"))
+}
+
+func (suite *SpeechTestSuite) TestRender_announces_code_blocks() {
+	body := "Try this\n```go\nfmt.Println(\"**hi**\")\n```\nthen run it"
+	expected := "Try this\ncode block, go\nfmt.Println(\"**hi**\")\nend code block\nthen run it"
+	suite.Equal(expected, suite.renderer.Render(body))
+}
+
+func (suite *SpeechTestSuite) TestRender_code_block_without_language() {
+	suite.Equal("code block\nmake test\nend code block", suite.renderer.Render("~~~\nmake test\n~~~"))
+}
+
+func (suite *SpeechTestSuite) TestRender_unclosed_code_block() {
+	suite.Equal("code block, sh\nmake test\nend code block", suite.renderer.Render("```sh\nmake test"))
+}
+
+func (suite *SpeechTestSuite) TestRender_code_block_closes_on_bare_fence_at_least_as_long() {
+	body := "```md\n```go\n``` still code\n``\n````\nafter"
+	suite.Equal("code block, md\n```go\n``` still code\n``\nend code block\nafter", suite.renderer.Render(body))
+}
+
+func (suite *SpeechTestSuite) TestRender_keeps_html_inside_code_blocks() {
+	body := "```html\n<!-- keep -->\n<details><summary>Log</summary>shown</details>\n```\n<!-- hidden -->done"
+	expected := "code block, html\n<!-- keep -->\n<details><summary>Log</summary>shown</details>\nend code block\ndone"
+	suite.Equal(expected, suite.renderer.Render(body))
+}
+
+func (suite *SpeechTestSuite) TestRender_collapses_details_containing_code() {
+	body := "<details><summary>Log</summary>\n\n```\n<!-- not a comment -->\n```\n</details>\nDone"
+	suite.Equal("collapsed section, Log\nDone", suite.renderer.Render(body))
+}
+
+func (suite *SpeechTestSuite) TestRender_drops_inline_markup() {
+	body := "This is **really** _nice_, use `a_b*c` and ~~not~~ *this* my_var_name"
+	suite.Equal("This is really nice, use a_b*c and not this my_var_name", suite.renderer.Render(body))
+}
+
+func (suite *SpeechTestSuite) TestRender_links() {
+	body := "See [the docs](https://example.com/docs) and <https://example.com>"
+	suite.Equal("See the docs and https://example.com", suite.renderer.Render(body))
+
+	suite.renderer.ReadURLs = true
+	suite.Equal("See the docs (https://example.com/docs) and https://example.com", suite.renderer.Render(body))
+}
+
+func (suite *SpeechTestSuite) TestRender_images() {
+	body := "![screenshot of the page](https://example.com/a.png) ![](b.png) <img width=\"200\" alt=\"logo\" src=\"c.png\">"
+	suite.Equal("image screenshot of the page image image logo", suite.renderer.Render(body))
+}
+
+func (suite *SpeechTestSuite) TestRender_linearizes_tables() {
+	body := "Results\n| Name | Status |\n| --- | :---: |\n| lint | **passed** |\n| test | failed |\n\nDone"
+	expected := "Results\ntable, 2 columns, 2 rows\nrow 1, Name lint, Status passed\nrow 2, Name test, Status failed\nend table\n\nDone"
+	suite.Equal(expected, suite.renderer.Render(body))
+}
+
+func (suite *SpeechTestSuite) TestRender_strips_html_comments_and_collapses_details() {
+	body := "<!-- Please describe your change -->\nFixes the bug\n<!--\nchecklist\n-->\n<details>\n<summary>Dependabot commands and options</summary>\n\nYou can trigger a rebase\n</details>"
+	suite.Equal("Fixes the bug\n\ncollapsed section, Dependabot commands and options", suite.renderer.Render(body))
+}
+
+func (suite *SpeechTestSuite) TestRender_strips_html_tags() {
+	suite.Equal("Coverage is 80% List<String>", suite.renderer.Render("<sub>Coverage is <b>80%</b></sub> List<String>"))
+}
+
+func (suite *SpeechTestSuite) TestRender_headings_lists_and_rules() {
+	body := "## Summary ##\n- one\n* two\n- [ ] todo\n- [x] done\n---\n1. first"
+	expected := "heading level 2, Summary\none\ntwo\nunchecked, todo\nchecked, done\n\n1. first"
+	suite.Equal(expected, suite.renderer.Render(body))
+}
+
+func (suite *SpeechTestSuite) TestRender_quotes() {
+	body := "> you said\n> **this**\n\nI agree"
+	suite.Equal("quote\nyou said\nthis\nend quote\n\nI agree", suite.renderer.Render(body))
+}
+
+func (suite *SpeechTestSuite) TestRender_quote_at_end() {
+	suite.Equal("I agree with\nquote\nthis\nend quote", suite.renderer.Render("I agree with\n> this"))
+}
+
+func (suite *SpeechTestSuite) TestRender_escaped_characters() {
+	suite.Equal("1 * 2 and #3", suite.renderer.Render(`1 \* 2 and \#3`))
+}
+
+func TestSpeechTestSuite(t *testing.T) {
+	suite.Run(t, new(SpeechTestSuite))
+}
@@ -0,0 +1,193 @@
+package internal
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hbk619/gh-peruse/internal/git"
+)
+
+var partialMention = regexp.MustCompile(`(^|\s)@([A-Za-z0-9-]*)\t?`)
+
+type Mentions struct {
+	logins []string
+}
+
+func NewMentions(logins []string) *Mentions {
+	sorted := slices.Clone(logins)
+	slices.Sort(sorted)
+	return &Mentions{
+		logins: slices.Compact(sorted),
+	}
+}
+
+// Participants lists everyone who wrote one of the comments apart from the viewer, for completing mentions
+func Participants(comments []git.Comment, viewer string) []string {
+	var logins []string
+	for _, comment := range comments {
+		login := comment.Author.Login
+		if login != "" && login != viewer && !slices.Contains(logins, login) {
+			logins = append(logins, login)
+		}
+	}
+	slices.Sort(logins)
+	return logins
+}
+
+// Matches returns the logins starting with the partially typed login
+func (mentions *Mentions) Matches(partial string) []string {
+	var matches []string
+	for _, login := range mentions.logins {
+		if strings.HasPrefix(strings.ToLower(login), strings.ToLower(partial)) {
+			matches = append(matches, login)
+		}
+	}
+	return matches
+}
+
+// Complete finishes the mention being typed at the end of text, it returns the candidates when there is more than one
+func (mentions *Mentions) Complete(text string) (string, []string) {
+	start := strings.LastIndexAny(text, " \t\n") + 1
+	word := text[start:]
+	if !strings.HasPrefix(word, "@") {
+		return text, nil
+	}
+	matches := mentions.Matches(word[1:])
+	switch len(matches) {
+	case 0:
+		return text, nil
+	case 1:
+		return text[:start] + "@" + matches[0] + " ", nil
+	default:
+		return text[:start] + "@" + commonPrefix(matches, word[1:]), matches
+	}
+}
+
+// Expand completes every partially typed mention in text, mentions matching more than one login are left as typed
+// and returned with their candidates. Code spans and fenced code blocks are left alone.
+func (mentions *Mentions) Expand(text string) (string, map[string][]string) {
+	ambiguous := make(map[string][]string)
+	code := codeRanges(text)
+	var expanded strings.Builder
+	last := 0
+	for _, match := range partialMention.FindAllStringSubmatchIndex(text, -1) {
+		// match[3] is where the @ is, after any whitespace before it
+		if slices.ContainsFunc(code, func(span [2]int) bool {
+			return match[3] >= span[0] && match[3] < span[1]
+		}) {
+			continue
+		}
+		expanded.WriteString(text[last:match[0]])
+		expanded.WriteString(mentions.expand(text[match[0]:match[1]], text[match[2]:match[3]], text[match[4]:match[5]], ambiguous))
+		last = match[1]
+	}
+	expanded.WriteString(text[last:])
+	return expanded.String(), ambiguous
+}
+
+func (mentions *Mentions) expand(match string, prefix string, partial string, ambiguous map[string][]string) string {
+	suffix := ""
+	if strings.HasSuffix(match, "\t") {
+		suffix = " "
+	}
+	completed := prefix + "@" + partial + suffix
+	if partial == "" || slices.ContainsFunc(mentions.logins, func(login string) bool {
+		return strings.EqualFold(login, partial)
+	}) {
+		return completed
+	}
+	matches := mentions.Matches(partial)
+	switch len(matches) {
+	case 0:
+		return completed
+	case 1:
+		return prefix + "@" + matches[0] + suffix
+	default:
+		ambiguous[partial] = matches
+		return completed
+	}
+}
+
+// codeRanges finds the start and end of each fenced code block and code span in text
+func codeRanges(text string) [][2]int {
+	var ranges [][2]int
+	gapStart, fenceStart := 0, -1
+	var fence string
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		indented := len(line)-len(trimmed) > 3
+		switch {
+		case fenceStart < 0 && !indented && fenceOpening(trimmed) != "":
+			fence = fenceOpening(trimmed)
+			fenceStart = offset
+			ranges = append(ranges, codeSpans(text, gapStart, offset)...)
+		case fenceStart >= 0 && !indented && strings.HasPrefix(trimmed, fence) &&
+			strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1])) == "":
+			ranges = append(ranges, [2]int{fenceStart, offset + len(line)})
+			fenceStart = -1
+			gapStart = offset + len(line)
+		}
+		offset += len(line)
+	}
+	if fenceStart >= 0 {
+		return append(ranges, [2]int{fenceStart, len(text)})
+	}
+	return append(ranges, codeSpans(text, gapStart, len(text))...)
+}
+
+// fenceOpening returns the backticks or tildes opening a fenced code block, or nothing if the line does not open one
+func fenceOpening(line string) string {
+	for _, marker := range []string{"```", "~~~"} {
+		if strings.HasPrefix(line, marker) {
+			return line[:len(line)-len(strings.TrimLeft(line, marker[:1]))]
+		}
+	}
+	return ""
+}
+
+// codeSpans finds code spans between start and end, a span closes on a run of as many backticks as opened it
+func codeSpans(text string, start int, end int) [][2]int {
+	var spans [][2]int
+	for i := start; i < end; {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+		opening := len(text[i:end]) - len(strings.TrimLeft(text[i:end], "`"))
+		closing := -1
+		for j := i + opening; j < end; {
+			if text[j] != '`' {
+				j++
+				continue
+			}
+			run := len(text[j:end]) - len(strings.TrimLeft(text[j:end], "`"))
+			if run == opening {
+				closing = j + run
+				break
+			}
+			j += run
+		}
+		if closing < 0 {
+			i += opening
+			continue
+		}
+		spans = append(spans, [2]int{i, closing})
+		i = closing
+	}
+	return spans
+}
+
+func commonPrefix(logins []string, typed string) string {
+	prefix := logins[0]
+	for _, login := range logins[1:] {
+		for !strings.HasPrefix(strings.ToLower(login), strings.ToLower(prefix)) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if len(prefix) < len(typed) {
+		return typed
+	}
+	return prefix
+}
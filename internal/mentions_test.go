@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/hbk619/gh-peruse/internal/git"
+	"github.com/stretchr/testify/suite"
+)
+
+type MentionsTestSuite struct {
+	suite.Suite
+	mentions *Mentions
+}
+
+func (suite *MentionsTestSuite) BeforeTest(string, string) {
+	suite.mentions = NewMentions([]string{"mario", "Luigi", "mariah", "mario"})
+}
+
+func (suite *MentionsTestSuite) TestMatches_ignores_case() {
+	suite.Equal([]string{"Luigi"}, suite.mentions.Matches("lu"))
+	suite.Equal([]string{"mariah", "mario"}, suite.mentions.Matches("MAR"))
+}
+
+func (suite *MentionsTestSuite) TestComplete_single_match() {
+	text, candidates := suite.mentions.Complete("thanks @lu")
+	suite.Equal("thanks @Luigi ", text)
+	suite.Nil(candidates)
+}
+
+func (suite *MentionsTestSuite) TestComplete_several_matches_completes_common_prefix() {
+	text, candidates := suite.mentions.Complete("thanks @m")
+	suite.Equal("thanks @mari", text)
+	suite.Equal([]string{"mariah", "mario"}, candidates)
+}
+
+func (suite *MentionsTestSuite) TestComplete_not_a_mention() {
+	text, candidates := suite.mentions.Complete("thanks lu")
+	suite.Equal("thanks lu", text)
+	suite.Nil(candidates)
+}
+
+func (suite *MentionsTestSuite) TestExpand_completes_partial_mentions() {
+	text, ambiguous := suite.mentions.Expand("@lu\tand @mario agree, ask @mar or @bowser, email me@lu.com")
+	suite.Equal("@Luigi and @mario agree, ask @mar or @bowser, email me@lu.com", text)
+	suite.Equal(map[string][]string{"mar": {"mariah", "mario"}}, ambiguous)
+}
+
+func (suite *MentionsTestSuite) TestExpand_leaves_code_alone() {
+	text, ambiguous := suite.mentions.Expand("ask @lu about `@lu` and `` @lu` ``\n```java\n@lu\n```js\n@lu\n```` \nthen @lu")
+	suite.Equal("ask @Luigi about `@lu` and `` @lu` ``\n```java\n@lu\n```js\n@lu\n```` \nthen @Luigi", text)
+	suite.Empty(ambiguous)
+}
+
+func (suite *MentionsTestSuite) TestExpand_leaves_unclosed_fence_alone() {
+	text, _ := suite.mentions.Expand("@lu\n~~~\n@lu\n```\n@lu")
+	suite.Equal("@Luigi\n~~~\n@lu\n```\n@lu", text)
+}
+
+func (suite *MentionsTestSuite) TestParticipants_excludes_viewer() {
+	comments := []git.Comment{
+		{Author: git.Author{Login: "Yoshi"}},
+		{Author: git.Author{Login: "Luigi"}},
+		{Author: git.Author{Login: "Bowser"}},
+		{Author: git.Author{Login: "Yoshi"}},
+		{},
+	}
+
+	suite.Equal([]string{"Bowser", "Yoshi"}, Participants(comments, "Luigi"))
+}
+
+func TestMentionsSuite(t *testing.T) {
+	suite.Run(t, new(MentionsTestSuite))
+}
@@ -33,6 +33,34 @@ func (m *MockPrompt) EXPECT() *MockPromptMockRecorder {
 	return m.recorder
 }
 
+// Command mocks base method.
+func (m *MockPrompt) Command(label string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Command", label)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Command indicates an expected call of Command.
+func (mr *MockPromptMockRecorder) Command(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Command", reflect.TypeOf((*MockPrompt)(nil).Command), label)
+}
+
+// Lines mocks base method.
+func (m *MockPrompt) Lines(label, terminator string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lines", label, terminator)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Lines indicates an expected call of Lines.
+func (mr *MockPromptMockRecorder) Lines(label, terminator interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lines", reflect.TypeOf((*MockPrompt)(nil).Lines), label, terminator)
+}
+
 // String mocks base method.
 func (m *MockPrompt) String(label string) string {
 	m.ctrl.T.Helper()
@@ -46,3 +74,38 @@ func (mr *MockPromptMockRecorder) String(label interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockPrompt)(nil).String), label)
 }
+
+// MockCompleter is a mock of Completer interface.
+type MockCompleter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompleterMockRecorder
+}
+
+// MockCompleterMockRecorder is the mock recorder for MockCompleter.
+type MockCompleterMockRecorder struct {
+	mock *MockCompleter
+}
+
+// NewMockCompleter creates a new mock instance.
+func NewMockCompleter(ctrl *gomock.Controller) *MockCompleter {
+	mock := &MockCompleter{ctrl: ctrl}
+	mock.recorder = &MockCompleterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompleter) EXPECT() *MockCompleterMockRecorder {
+	return m.recorder
+}
+
+// SetCompletion mocks base method.
+func (m *MockCompleter) SetCompletion(complete func(string) (string, []string)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetCompletion", complete)
+}
+
+// SetCompletion indicates an expected call of SetCompletion.
+func (mr *MockCompleterMockRecorder) SetCompletion(complete interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCompletion", reflect.TypeOf((*MockCompleter)(nil).SetCompletion), complete)
+}
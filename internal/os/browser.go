@@ -0,0 +1,29 @@
+package os
+
+import (
+	"fmt"
+	stdos "os"
+
+	"github.com/cli/go-gh/v2/pkg/browser"
+)
+
+type (
+	Browser struct {
+	}
+	WebBrowser interface {
+		Open(url string) error
+	}
+)
+
+func NewBrowser() *Browser {
+	return &Browser{}
+}
+
+// Open opens the url with $GH_BROWSER, the browser set in gh's config, $BROWSER or the system's default browser
+func (b *Browser) Open(url string) error {
+	err := browser.New("", stdos.Stdout, stdos.Stderr).Browse(url)
+	if err != nil {
+		return fmt.Errorf("error opening browser %w", err)
+	}
+	return nil
+}
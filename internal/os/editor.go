@@ -0,0 +1,105 @@
+package os
+
+import (
+	"fmt"
+	stdos "os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hbk619/gh-peruse/internal/requests"
+)
+
+type (
+	Editor struct {
+	}
+	TextEditor interface {
+		Edit(contents string) (string, error)
+		Open(filePath string, line int) error
+	}
+)
+
+func NewEditor() *Editor {
+	return &Editor{}
+}
+
+func (editor *Editor) Edit(contents string) (string, error) {
+	file, err := stdos.CreateTemp("", "gh-peruse-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file %w", err)
+	}
+	defer stdos.Remove(file.Name())
+
+	_, err = file.WriteString(contents)
+	closeErr := file.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to write temporary file %w", err)
+	}
+	if closeErr != nil {
+		return "", fmt.Errorf("failed to write temporary file %w", closeErr)
+	}
+
+	command := requests.NewCommandRunner()
+	err = OpenInEditor(file.Name(), command)
+	if err != nil {
+		return "", fmt.Errorf("error running editor %w", err)
+	}
+
+	edited, err := stdos.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read temporary file %w", err)
+	}
+	return string(edited), nil
+}
+
+// Open opens the file in the editor with the cursor on line for editors known to take a line
+func (editor *Editor) Open(filePath string, line int) error {
+	err := OpenAtLine(filePath, line, requests.NewCommandRunner())
+	if err != nil {
+		return fmt.Errorf("error running editor %w", err)
+	}
+	return nil
+}
+
+var OpenInEditor = func(filePath string, command requests.CommandLine) error {
+	executable, args := EditorCommand()
+	return command.RunInteractive(executable, append(args, filePath))
+}
+
+// EditorCommand splits $VISUAL or $EDITOR into an executable and its arguments, e.g. "code --wait"
+func EditorCommand() (string, []string) {
+	editor := stdos.Getenv("VISUAL")
+	if editor == "" {
+		editor = stdos.Getenv("EDITOR")
+	}
+	parts := strings.Fields(editor)
+	if len(parts) == 0 {
+		if runtime.GOOS == "windows" {
+			return "notepad", []string{}
+		}
+		return "nano", []string{}
+	}
+	return parts[0], parts[1:]
+}
+
+var OpenAtLine = func(filePath string, line int, command requests.CommandLine) error {
+	executable, args := EditorCommand()
+	return command.RunInteractive(executable, append(args, LineArgs(executable, filePath, line)...))
+}
+
+// LineArgs gives the arguments that open the file at line, vim, emacs and nano take +line before the file and
+// VS Code takes --goto file:line, other editors just get the file
+func LineArgs(executable string, filePath string, line int) []string {
+	if line < 1 {
+		return []string{filePath}
+	}
+	name := strings.TrimSuffix(strings.ToLower(filepath.Base(executable)), ".exe")
+	switch name {
+	case "vi", "vim", "nvim", "gvim", "emacs", "emacsclient", "nano":
+		return []string{fmt.Sprintf("+%d", line), filePath}
+	case "code", "code-insiders", "codium":
+		return []string{"--goto", fmt.Sprintf("%s:%d", filePath, line)}
+	default:
+		return []string{filePath}
+	}
+}
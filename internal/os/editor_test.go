@@ -0,0 +1,106 @@
+package os
+
+import (
+	"errors"
+	stdos "os"
+	"testing"
+
+	"github.com/hbk619/gh-peruse/internal/requests"
+	"github.com/stretchr/testify/suite"
+)
+
+type EditorSuite struct {
+	suite.Suite
+	originalOpenInEditor func(filePath string, command requests.CommandLine) error
+	originalOpenAtLine   func(filePath string, line int, command requests.CommandLine) error
+	editor               *Editor
+}
+
+func (suite *EditorSuite) BeforeTest(string, string) {
+	suite.originalOpenInEditor = OpenInEditor
+	suite.originalOpenAtLine = OpenAtLine
+	suite.editor = NewEditor()
+}
+
+func (suite *EditorSuite) AfterTest(string, string) {
+	OpenInEditor = suite.originalOpenInEditor
+	OpenAtLine = suite.originalOpenAtLine
+}
+
+func (suite *EditorSuite) TestEdit_returns_edited_contents() {
+	initialContents := ""
+	OpenInEditor = func(filePath string, command requests.CommandLine) error {
+		contents, err := stdos.ReadFile(filePath)
+		suite.NoError(err)
+		initialContents = string(contents)
+		return stdos.WriteFile(filePath, []byte("edited"), 0644)
+	}
+	result, err := suite.editor.Edit("> quoted")
+	suite.NoError(err)
+	suite.Equal("> quoted", initialContents)
+	suite.Equal("edited", result)
+}
+
+func (suite *EditorSuite) TestEdit_removes_temporary_file() {
+	tempFile := ""
+	OpenInEditor = func(filePath string, command requests.CommandLine) error {
+		tempFile = filePath
+		return nil
+	}
+	_, err := suite.editor.Edit("")
+	suite.NoError(err)
+	_, err = stdos.Stat(tempFile)
+	suite.True(stdos.IsNotExist(err))
+}
+
+func (suite *EditorSuite) TestEdit_returns_error() {
+	expectedErr := errors.New("oops")
+	OpenInEditor = func(filePath string, command requests.CommandLine) error {
+		return expectedErr
+	}
+	_, actualErr := suite.editor.Edit("test")
+	suite.ErrorIs(actualErr, expectedErr)
+}
+
+func (suite *EditorSuite) TestEditorCommand_prefers_visual() {
+	suite.T().Setenv("VISUAL", "code --wait")
+	suite.T().Setenv("EDITOR", "vim")
+	executable, args := EditorCommand()
+	suite.Equal("code", executable)
+	suite.Equal([]string{"--wait"}, args)
+}
+
+func (suite *EditorSuite) TestEditorCommand_falls_back_to_editor() {
+	suite.T().Setenv("VISUAL", "")
+	suite.T().Setenv("EDITOR", "vim")
+	executable, args := EditorCommand()
+	suite.Equal("vim", executable)
+	suite.Equal([]string{}, args)
+}
+
+func (suite *EditorSuite) TestLineArgs() {
+	suite.Equal([]string{"+12", "main.go"}, LineArgs("vim", "main.go", 12))
+	suite.Equal([]string{"+12", "main.go"}, LineArgs("/usr/bin/emacs", "main.go", 12))
+	suite.Equal([]string{"+12", "main.go"}, LineArgs("nano", "main.go", 12))
+	suite.Equal([]string{"--goto", "main.go:12"}, LineArgs("Code.exe", "main.go", 12))
+}
+
+func (suite *EditorSuite) TestLineArgs_unknown_editor_or_no_line() {
+	suite.Equal([]string{"main.go"}, LineArgs("notepad", "main.go", 12))
+	suite.Equal([]string{"main.go"}, LineArgs("vim", "main.go", 0))
+}
+
+func (suite *EditorSuite) TestOpen_returns_error() {
+	expectedErr := errors.New("oops")
+	OpenAtLine = func(filePath string, line int, command requests.CommandLine) error {
+		suite.Equal("main.go", filePath)
+		suite.Equal(12, line)
+		return expectedErr
+	}
+	err := suite.editor.Open("main.go", 12)
+	suite.ErrorIs(err, expectedErr)
+}
+
+func TestEditorSuite(t *testing.T) {
+	suite.Run(t, new(EditorSuite))
+}
@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/os/browser.go
+
+// Package mock_os is a generated GoMock package.
+package mock_os
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWebBrowser is a mock of WebBrowser interface.
+type MockWebBrowser struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebBrowserMockRecorder
+}
+
+// MockWebBrowserMockRecorder is the mock recorder for MockWebBrowser.
+type MockWebBrowserMockRecorder struct {
+	mock *MockWebBrowser
+}
+
+// NewMockWebBrowser creates a new mock instance.
+func NewMockWebBrowser(ctrl *gomock.Controller) *MockWebBrowser {
+	mock := &MockWebBrowser{ctrl: ctrl}
+	mock.recorder = &MockWebBrowserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebBrowser) EXPECT() *MockWebBrowserMockRecorder {
+	return m.recorder
+}
+
+// Open mocks base method.
+func (m *MockWebBrowser) Open(url string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockWebBrowserMockRecorder) Open(url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockWebBrowser)(nil).Open), url)
+}
@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/os/editor.go
+
+// Package mock_os is a generated GoMock package.
+package mock_os
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTextEditor is a mock of TextEditor interface.
+type MockTextEditor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTextEditorMockRecorder
+}
+
+// MockTextEditorMockRecorder is the mock recorder for MockTextEditor.
+type MockTextEditorMockRecorder struct {
+	mock *MockTextEditor
+}
+
+// NewMockTextEditor creates a new mock instance.
+func NewMockTextEditor(ctrl *gomock.Controller) *MockTextEditor {
+	mock := &MockTextEditor{ctrl: ctrl}
+	mock.recorder = &MockTextEditorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTextEditor) EXPECT() *MockTextEditorMockRecorder {
+	return m.recorder
+}
+
+// Edit mocks base method.
+func (m *MockTextEditor) Edit(contents string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Edit", contents)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Edit indicates an expected call of Edit.
+func (mr *MockTextEditorMockRecorder) Edit(contents interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Edit", reflect.TypeOf((*MockTextEditor)(nil).Edit), contents)
+}
+
+// Open mocks base method.
+func (m *MockTextEditor) Open(filePath string, line int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", filePath, line)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockTextEditorMockRecorder) Open(filePath, line interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockTextEditor)(nil).Open), filePath, line)
+}
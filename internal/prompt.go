@@ -10,10 +10,32 @@ import (
 
 type Prompt interface {
 	String(label string) string
+	Lines(label string, terminator string) string
+	Command(label string) string
+}
+
+// Completer is implemented by prompts that can complete what is being typed when tab is pressed
+type Completer interface {
+	SetCompletion(complete func(text string) (string, []string))
+}
+
+// Commander is implemented by prompts that act on a single key press, they are told every command so a key that
+// starts a longer command waits for enter
+type Commander interface {
+	SetCommands(commands []string)
+}
+
+// ReadCommand reads one of the commands, the prompt is told them first in case it acts on a single key press
+func ReadCommand(prompt Prompt, label string, commands []string) string {
+	if commander, ok := prompt.(Commander); ok {
+		commander.SetCommands(commands)
+	}
+	return prompt.Command(label)
 }
 
 type Prompter struct {
 	input  io.Reader
+	reader *bufio.Reader
 	output filesystem.Output
 }
 
@@ -24,15 +46,53 @@ func NewPrompt(input io.Reader, output filesystem.Output) *Prompter {
 	}
 }
 
+// String reads a line, when the input has ended it returns whatever was left which may be nothing
 func (prompt *Prompter) String(label string) string {
-	var s string
-	r := bufio.NewReader(prompt.input)
+	s, _ := prompt.readLine(label)
+	return s
+}
+
+// Command reads a navigation command, with whole lines there is no difference to String except that q is returned
+// once the input has ended so the command loop stops
+func (prompt *Prompter) Command(label string) string {
+	s, err := prompt.readLine(label)
+	if err != nil && s == "" {
+		return "q"
+	}
+	return s
+}
+
+func (prompt *Prompter) readLine(label string) (string, error) {
+	_ = prompt.output.Print(label + ": ")
+	s, err := prompt.lineReader().ReadString('\n')
+	return strings.TrimSpace(s), err
+}
+
+// Lines reads lines until one matches the terminator or the input ends
+func (prompt *Prompter) Lines(label string, terminator string) string {
+	var lines []string
+	r := prompt.lineReader()
+	_ = prompt.output.Print(label + ":\n")
 	for {
-		_ = prompt.output.Print(label + ": ")
-		s, _ = r.ReadString('\n')
-		if s != "" {
+		s, err := r.ReadString('\n')
+		line := strings.TrimRight(s, "\r\n")
+		if strings.TrimSpace(line) == terminator {
 			break
 		}
+		if err != nil {
+			if line != "" {
+				lines = append(lines, line)
+			}
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+func (prompt *Prompter) lineReader() *bufio.Reader {
+	if prompt.reader == nil {
+		prompt.reader = bufio.NewReader(prompt.input)
 	}
-	return strings.TrimSpace(s)
+	return prompt.reader
 }
@@ -40,6 +40,46 @@ func (suite *PromptTestSuite) TestString_reads_until_new_line() {
 	suite.Equal("got it!", result)
 }
 
+func (suite *PromptTestSuite) TestLines_reads_until_terminator() {
+	suite.mockOutput.EXPECT().Print("enter things:\n")
+	suite.prompt.input = strings.NewReader("first\n\n  indented\n.\nnot read\n")
+	result := suite.prompt.Lines("enter things", ".")
+
+	suite.Equal("first\n\n  indented", result)
+}
+
+func (suite *PromptTestSuite) TestLines_reads_until_end_of_input() {
+	suite.mockOutput.EXPECT().Print("enter things:\n")
+	suite.prompt.input = strings.NewReader("first\nsecond")
+	result := suite.prompt.Lines("enter things", ".")
+
+	suite.Equal("first\nsecond", result)
+}
+
+func (suite *PromptTestSuite) TestString_keeps_unread_lines_for_next_prompt() {
+	suite.mockOutput.EXPECT().Print("enter things: ").Times(2)
+	suite.prompt.input = strings.NewReader("first\nsecond\n")
+
+	suite.Equal("first", suite.prompt.String("enter things"))
+	suite.Equal("second", suite.prompt.String("enter things"))
+}
+
+func (suite *PromptTestSuite) TestString_returns_what_is_left_at_end_of_input() {
+	suite.mockOutput.EXPECT().Print("enter things: ").Times(2)
+	suite.prompt.input = strings.NewReader("last")
+
+	suite.Equal("last", suite.prompt.String("enter things"))
+	suite.Equal("", suite.prompt.String("enter things"))
+}
+
+func (suite *PromptTestSuite) TestCommand_quits_at_end_of_input() {
+	suite.mockOutput.EXPECT().Print("n for next: ").Times(2)
+	suite.prompt.input = strings.NewReader("n")
+
+	suite.Equal("n", suite.prompt.Command("n for next"))
+	suite.Equal("q", suite.prompt.Command("n for next"))
+}
+
 func TestPromptSuite(t *testing.T) {
 	suite.Run(t, new(PromptTestSuite))
 }
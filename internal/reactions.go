@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/git"
+)
+
+// Reactor adds and removes reactions on any kind of comment
+type Reactor interface {
+	React(comment *git.Comment, content string) error
+	RemoveReaction(comment *git.Comment, content string) error
+}
+
+// ToggleReaction adds the reaction, or removes it if already added, asking which reaction when none is given
+func ToggleReaction(client Reactor, comment *git.Comment, input string, prompt Prompt, output filesystem.Output) error {
+	if input == "" {
+		input = prompt.String("Type a reaction: +1, -1, laugh, hooray, confused, heart, rocket or eyes")
+	}
+	content, err := git.ReactionContent(input)
+	if err != nil {
+		_ = output.Println(err.Error())
+		return err
+	}
+
+	message := "Added %s"
+	if comment.HasReacted(content) {
+		message = "Removed %s"
+		err = client.RemoveReaction(comment, content)
+	} else {
+		err = client.React(comment, content)
+	}
+	if err != nil {
+		_ = output.Println(fmt.Sprintf("Warning failed to react: %s", err.Error()))
+		return err
+	}
+	comment.ToggleReaction(content)
+	_ = output.Println(fmt.Sprintf(message, git.SpokenReaction(content)))
+	return nil
+}
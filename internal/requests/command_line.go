@@ -3,6 +3,7 @@ package requests
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 )
@@ -11,6 +12,8 @@ type (
 	CommandLine interface {
 		Run(executable string, args []string) (string, error)
 		RunWithInput(executable string, args []string, input string) (string, error)
+		RunInteractive(executable string, args []string) error
+		Start(executable string, args []string) (func(), error)
 	}
 
 	CommandRunner struct{}
@@ -65,3 +68,42 @@ func (runner *CommandRunner) RunWithInput(executable string, args []string, inpu
 
 	return strings.TrimSpace(out.String()), nil
 }
+
+func (runner *CommandRunner) RunInteractive(executable string, args []string) error {
+	executablePath, err := exec.LookPath(executable)
+	if err != nil {
+		return fmt.Errorf("failed to find executable %w", err)
+	}
+	cmd := exec.Command(executablePath, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// Start runs the command without waiting for it to finish, the returned func stops it if it is still running
+func (runner *CommandRunner) Start(executable string, args []string) (func(), error) {
+	executablePath, err := exec.LookPath(executable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find executable %w", err)
+	}
+	cmd := exec.Command(executablePath, args...)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(done)
+	}()
+	return func() {
+		select {
+		case <-done:
+		default:
+			_ = cmd.Process.Kill()
+			<-done
+		}
+	}, nil
+}
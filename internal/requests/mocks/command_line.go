@@ -48,6 +48,20 @@ func (mr *MockCommandLineMockRecorder) Run(executable, args interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockCommandLine)(nil).Run), executable, args)
 }
 
+// RunInteractive mocks base method.
+func (m *MockCommandLine) RunInteractive(executable string, args []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunInteractive", executable, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunInteractive indicates an expected call of RunInteractive.
+func (mr *MockCommandLineMockRecorder) RunInteractive(executable, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInteractive", reflect.TypeOf((*MockCommandLine)(nil).RunInteractive), executable, args)
+}
+
 // RunWithInput mocks base method.
 func (m *MockCommandLine) RunWithInput(executable string, args []string, input string) (string, error) {
 	m.ctrl.T.Helper()
@@ -62,3 +76,18 @@ func (mr *MockCommandLineMockRecorder) RunWithInput(executable, args, input inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunWithInput", reflect.TypeOf((*MockCommandLine)(nil).RunWithInput), executable, args, input)
 }
+
+// Start mocks base method.
+func (m *MockCommandLine) Start(executable string, args []string) (func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", executable, args)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockCommandLineMockRecorder) Start(executable, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockCommandLine)(nil).Start), executable, args)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/requests/rest.go
+
+// Package mock_requests is a generated GoMock package.
+package mock_requests
+
+import (
+	io "io"
+	http "net/http"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRESTClient is a mock of RESTClient interface.
+type MockRESTClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockRESTClientMockRecorder
+}
+
+// MockRESTClientMockRecorder is the mock recorder for MockRESTClient.
+type MockRESTClientMockRecorder struct {
+	mock *MockRESTClient
+}
+
+// NewMockRESTClient creates a new mock instance.
+func NewMockRESTClient(ctrl *gomock.Controller) *MockRESTClient {
+	mock := &MockRESTClient{ctrl: ctrl}
+	mock.recorder = &MockRESTClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRESTClient) EXPECT() *MockRESTClientMockRecorder {
+	return m.recorder
+}
+
+// Request mocks base method.
+func (m *MockRESTClient) Request(method, path string, body io.Reader) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Request", method, path, body)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Request indicates an expected call of Request.
+func (mr *MockRESTClientMockRecorder) Request(method, path, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockRESTClient)(nil).Request), method, path, body)
+}
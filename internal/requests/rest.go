@@ -0,0 +1,12 @@
+package requests
+
+import (
+	"io"
+	"net/http"
+)
+
+type (
+	RESTClient interface {
+		Request(method string, path string, body io.Reader) (*http.Response, error)
+	}
+)
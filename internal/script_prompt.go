@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+)
+
+// ScriptPrompter answers prompts from a list of commands separated by ; or new lines, e.g. "n;n;done;c LGTM;q".
+// Once the commands run out every further command is q.
+type ScriptPrompter struct {
+	commands []string
+	output   filesystem.Output
+}
+
+func NewScriptPrompt(script string, output filesystem.Output) *ScriptPrompter {
+	var commands []string
+	for _, line := range strings.Split(script, "\n") {
+		for _, command := range strings.Split(line, ";") {
+			command = strings.TrimSpace(command)
+			if command != "" {
+				commands = append(commands, command)
+			}
+		}
+	}
+	return &ScriptPrompter{
+		commands: commands,
+		output:   output,
+	}
+}
+
+func (prompt *ScriptPrompter) Command(label string) string {
+	if len(prompt.commands) == 0 {
+		return "q"
+	}
+	return prompt.next(label)
+}
+
+func (prompt *ScriptPrompter) String(label string) string {
+	return prompt.next(label)
+}
+
+func (prompt *ScriptPrompter) Lines(label string, terminator string) string {
+	return prompt.next(label)
+}
+
+func (prompt *ScriptPrompter) next(label string) string {
+	if len(prompt.commands) == 0 {
+		return ""
+	}
+	command := prompt.commands[0]
+	prompt.commands = prompt.commands[1:]
+	_ = prompt.output.Println(label + ": " + command)
+	return command
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScriptPromptTestSuite struct {
+	suite.Suite
+	mockOutput *mock_filesystem.MockOutput
+	ctrl       *gomock.Controller
+}
+
+func (suite *ScriptPromptTestSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOutput = mock_filesystem.NewMockOutput(suite.ctrl)
+	suite.mockOutput.EXPECT().Println(gomock.Any()).AnyTimes()
+}
+
+func (suite *ScriptPromptTestSuite) TestCommand_splits_on_semicolons_and_new_lines() {
+	prompt := NewScriptPrompt("n; n;done\nc LGTM\n\n", suite.mockOutput)
+
+	suite.Equal("n", prompt.Command("choose"))
+	suite.Equal("n", prompt.Command("choose"))
+	suite.Equal("done", prompt.Command("choose"))
+	suite.Equal("c LGTM", prompt.Command("choose"))
+}
+
+func (suite *ScriptPromptTestSuite) TestCommand_quits_when_commands_run_out() {
+	prompt := NewScriptPrompt("n", suite.mockOutput)
+
+	suite.Equal("n", prompt.Command("choose"))
+	suite.Equal("q", prompt.Command("choose"))
+	suite.Equal("", prompt.String("comment"))
+}
+
+func (suite *ScriptPromptTestSuite) TestString_echoes_answer() {
+	mockOutput := mock_filesystem.NewMockOutput(suite.ctrl)
+	mockOutput.EXPECT().Println("Type a reaction: heart")
+	prompt := NewScriptPrompt("heart", mockOutput)
+
+	suite.Equal("heart", prompt.String("Type a reaction"))
+}
+
+func TestScriptPromptSuite(t *testing.T) {
+	suite.Run(t, new(ScriptPromptTestSuite))
+}
@@ -0,0 +1,144 @@
+package sound
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hbk619/gh-peruse/internal/requests"
+)
+
+type Cue string
+
+const (
+	Resolved Cue = "resolved"
+	Outdated Cue = "outdated"
+	Unread   Cue = "unread"
+	End      Cue = "end"
+	Failure  Cue = "error"
+)
+
+type (
+	Player interface {
+		Play(cue Cue) error
+	}
+
+	// Earcons plays a short sound for each cue with the player command, the sounds are written to dir the first
+	// time they are needed and can be replaced with any wav file of the same name
+	Earcons struct {
+		command requests.CommandLine
+		player  []string
+		dir     string
+	}
+
+	Silent struct{}
+
+	tone struct {
+		frequency float64
+		ms        int
+	}
+)
+
+const sampleRate = 22050
+
+// tones are rising for resolved, falling for outdated, a quick double blip for unread, a low thud at the ends of
+// the list and a low buzz for errors, a frequency of 0 is a pause
+var tones = map[Cue][]tone{
+	Resolved: {{660, 70}, {880, 90}},
+	Outdated: {{587, 70}, {392, 90}},
+	Unread:   {{1175, 50}, {0, 40}, {1175, 50}},
+	End:      {{196, 120}},
+	Failure:  {{147, 90}, {0, 30}, {147, 90}},
+}
+
+// NewEarcons uses player to play sounds if given, e.g. "mpv --no-terminal", otherwise the platform's player
+func NewEarcons(player string, dir string) *Earcons {
+	return &Earcons{
+		command: requests.NewCommandRunner(),
+		player:  strings.Fields(player),
+		dir:     dir,
+	}
+}
+
+func NewSilent() *Silent {
+	return &Silent{}
+}
+
+func (earcons *Earcons) Play(cue Cue) error {
+	file, err := earcons.file(cue)
+	if err != nil {
+		return fmt.Errorf("failed to write sound %w", err)
+	}
+
+	executable, args := PlayCommand(file)
+	if len(earcons.player) > 0 {
+		executable = earcons.player[0]
+		args = append(slices.Clone(earcons.player[1:]), file)
+	}
+	_, err = earcons.command.Start(executable, args)
+	return err
+}
+
+func (earcons *Earcons) file(cue Cue) (string, error) {
+	file := filepath.Join(earcons.dir, string(cue)+".wav")
+	if _, err := os.Stat(file); err == nil {
+		return file, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	notes, ok := tones[cue]
+	if !ok {
+		return "", fmt.Errorf("unknown cue %s", cue)
+	}
+	if err := os.MkdirAll(earcons.dir, 0o755); err != nil {
+		return "", err
+	}
+	return file, os.WriteFile(file, wav(notes), 0o644)
+}
+
+func (silent *Silent) Play(Cue) error {
+	return nil
+}
+
+// wav builds a 16 bit mono PCM file, each note fades in and out so it does not click
+func wav(notes []tone) []byte {
+	var samples []int16
+	for _, note := range notes {
+		count := sampleRate * note.ms / 1000
+		fade := min(count/4, sampleRate/200)
+		for i := 0; i < count; i++ {
+			if note.frequency == 0 {
+				samples = append(samples, 0)
+				continue
+			}
+			volume := 0.4
+			if i < fade {
+				volume *= float64(i) / float64(fade)
+			} else if count-i < fade {
+				volume *= float64(count-i) / float64(fade)
+			}
+			value := math.Sin(2 * math.Pi * note.frequency * float64(i) / sampleRate)
+			samples = append(samples, int16(value*volume*math.MaxInt16))
+		}
+	}
+
+	var buffer bytes.Buffer
+	dataSize := uint32(len(samples) * 2)
+	buffer.WriteString("RIFF")
+	_ = binary.Write(&buffer, binary.LittleEndian, 36+dataSize)
+	buffer.WriteString("WAVEfmt ")
+	for _, field := range []any{uint32(16), uint16(1), uint16(1), uint32(sampleRate), uint32(sampleRate * 2), uint16(2), uint16(16)} {
+		_ = binary.Write(&buffer, binary.LittleEndian, field)
+	}
+	buffer.WriteString("data")
+	_ = binary.Write(&buffer, binary.LittleEndian, dataSize)
+	_ = binary.Write(&buffer, binary.LittleEndian, samples)
+	return buffer.Bytes()
+}
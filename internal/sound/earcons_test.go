@@ -0,0 +1,69 @@
+package sound
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_requests "github.com/hbk619/gh-peruse/internal/requests/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type EarconsSuite struct {
+	suite.Suite
+	ctrl            *gomock.Controller
+	mockCommandLine *mock_requests.MockCommandLine
+	dir             string
+}
+
+func (suite *EarconsSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockCommandLine = mock_requests.NewMockCommandLine(suite.ctrl)
+	suite.dir = filepath.Join(suite.T().TempDir(), "sounds")
+}
+
+func (suite *EarconsSuite) earcons(player string) *Earcons {
+	earcons := NewEarcons(player, suite.dir)
+	earcons.command = suite.mockCommandLine
+	return earcons
+}
+
+func (suite *EarconsSuite) TestPlay_writes_sound_and_plays_it() {
+	file := filepath.Join(suite.dir, "resolved.wav")
+	executable, args := PlayCommand(file)
+	suite.mockCommandLine.EXPECT().Start(executable, args).Return(func() {}, nil)
+
+	err := suite.earcons("").Play(Resolved)
+	suite.NoError(err)
+
+	contents, err := os.ReadFile(file)
+	suite.NoError(err)
+	suite.Equal("RIFF", string(contents[:4]))
+	suite.Equal("WAVE", string(contents[8:12]))
+	suite.Equal(uint32(len(contents)-8), binary.LittleEndian.Uint32(contents[4:8]))
+	suite.Equal(uint32(len(contents)-44), binary.LittleEndian.Uint32(contents[40:44]))
+}
+
+func (suite *EarconsSuite) TestPlay_uses_player_and_existing_sound() {
+	suite.NoError(os.MkdirAll(suite.dir, 0o755))
+	file := filepath.Join(suite.dir, "end.wav")
+	suite.NoError(os.WriteFile(file, []byte("my sound"), 0o644))
+	suite.mockCommandLine.EXPECT().Start("mpv", []string{"--no-terminal", file}).Return(func() {}, nil)
+
+	err := suite.earcons("mpv --no-terminal").Play(End)
+	suite.NoError(err)
+
+	contents, _ := os.ReadFile(file)
+	suite.Equal("my sound", string(contents))
+}
+
+func (suite *EarconsSuite) TestPlay_unknown_cue() {
+	err := suite.earcons("").Play(Cue("party"))
+	suite.ErrorContains(err, "failed to write sound unknown cue party")
+}
+
+func TestEarconsSuite(t *testing.T) {
+	suite.Run(t, new(EarconsSuite))
+}
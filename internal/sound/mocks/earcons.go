@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/sound/earcons.go
+
+// Package mock_sound is a generated GoMock package.
+package mock_sound
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	sound "github.com/hbk619/gh-peruse/internal/sound"
+)
+
+// MockPlayer is a mock of Player interface.
+type MockPlayer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlayerMockRecorder
+}
+
+// MockPlayerMockRecorder is the mock recorder for MockPlayer.
+type MockPlayerMockRecorder struct {
+	mock *MockPlayer
+}
+
+// NewMockPlayer creates a new mock instance.
+func NewMockPlayer(ctrl *gomock.Controller) *MockPlayer {
+	mock := &MockPlayer{ctrl: ctrl}
+	mock.recorder = &MockPlayerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlayer) EXPECT() *MockPlayerMockRecorder {
+	return m.recorder
+}
+
+// Play mocks base method.
+func (m *MockPlayer) Play(cue sound.Cue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Play", cue)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Play indicates an expected call of Play.
+func (mr *MockPlayerMockRecorder) Play(cue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Play", reflect.TypeOf((*MockPlayer)(nil).Play), cue)
+}
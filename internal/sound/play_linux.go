@@ -0,0 +1,8 @@
+//go:build linux
+// +build linux
+
+package sound
+
+var PlayCommand = func(file string) (string, []string) {
+	return "paplay", []string{file}
+}
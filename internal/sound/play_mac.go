@@ -0,0 +1,8 @@
+//go:build darwin
+// +build darwin
+
+package sound
+
+var PlayCommand = func(file string) (string, []string) {
+	return "afplay", []string{file}
+}
@@ -0,0 +1,14 @@
+//go:build windows
+// +build windows
+
+package sound
+
+import (
+	"fmt"
+	"strings"
+)
+
+var PlayCommand = func(file string) (string, []string) {
+	script := fmt.Sprintf("(New-Object Media.SoundPlayer '%s').PlaySync()", strings.ReplaceAll(file, "'", "''"))
+	return "powershell", []string{"-NoProfile", "-Command", script}
+}
@@ -0,0 +1,21 @@
+//go:build linux
+// +build linux
+
+package speech
+
+import (
+	"github.com/hbk619/gh-peruse/internal/requests"
+)
+
+const speechCommand = "spd-say"
+
+var Speak = func(text string, command requests.CommandLine) (func(), error) {
+	_, err := command.Run(speechCommand, []string{"--", text})
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		_, _ = command.Run(speechCommand, []string{"--cancel"})
+	}, nil
+}
@@ -0,0 +1,35 @@
+//go:build linux
+
+package speech
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_requests "github.com/hbk619/gh-peruse/internal/requests/mocks"
+	"github.com/stretchr/testify/suite"
+)
+
+type SpeakLinuxSuite struct {
+	suite.Suite
+	ctrl            *gomock.Controller
+	mockCommandLine *mock_requests.MockCommandLine
+}
+
+func (suite *SpeakLinuxSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockCommandLine = mock_requests.NewMockCommandLine(suite.ctrl)
+}
+
+func (suite *SpeakLinuxSuite) TestSpeak_says_text_and_cancels() {
+	suite.mockCommandLine.EXPECT().Run("spd-say", []string{"--", "-1 from Mario"}).Return("", nil)
+	stop, err := Speak("-1 from Mario", suite.mockCommandLine)
+	suite.NoError(err)
+
+	suite.mockCommandLine.EXPECT().Run("spd-say", []string{"--cancel"}).Return("", nil)
+	stop()
+}
+
+func TestSpeakLinuxSuite(t *testing.T) {
+	suite.Run(t, new(SpeakLinuxSuite))
+}
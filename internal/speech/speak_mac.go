@@ -0,0 +1,14 @@
+//go:build darwin
+// +build darwin
+
+package speech
+
+import (
+	"github.com/hbk619/gh-peruse/internal/requests"
+)
+
+const speechCommand = "say"
+
+var Speak = func(text string, command requests.CommandLine) (func(), error) {
+	return command.Start(speechCommand, []string{text})
+}
@@ -0,0 +1,18 @@
+//go:build windows
+// +build windows
+
+package speech
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hbk619/gh-peruse/internal/requests"
+)
+
+const speechCommand = "powershell"
+
+var Speak = func(text string, command requests.CommandLine) (func(), error) {
+	script := fmt.Sprintf("Add-Type -AssemblyName System.Speech; (New-Object System.Speech.Synthesis.SpeechSynthesizer).Speak('%s')", strings.ReplaceAll(text, "'", "''"))
+	return command.Start(speechCommand, []string{"-NoProfile", "-Command", script})
+}
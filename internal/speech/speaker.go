@@ -0,0 +1,77 @@
+package speech
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/hbk619/gh-peruse/internal/filesystem"
+	"github.com/hbk619/gh-peruse/internal/requests"
+)
+
+// Speaker reads output aloud as well as printing it. Lines are collected until a prompt is printed and then spoken
+// together, cutting off anything still being read so moving on never waits for the last comment to finish.
+type Speaker struct {
+	output  filesystem.Output
+	command requests.CommandLine
+	lines   []string
+	stop    func()
+	failed  bool
+}
+
+var lookPath = exec.LookPath
+
+func NewSpeaker(output filesystem.Output) *Speaker {
+	return &Speaker{
+		output:  output,
+		command: requests.NewCommandRunner(),
+	}
+}
+
+// Check makes sure the speech command is installed so a missing one is reported before anything is read
+func (speaker *Speaker) Check() error {
+	_, err := lookPath(speechCommand)
+	if err != nil {
+		return fmt.Errorf("failed to find %s to speak %w", speechCommand, err)
+	}
+	return nil
+}
+
+func (speaker *Speaker) Println(text string) error {
+	speaker.lines = append(speaker.lines, text)
+	return speaker.output.Println(text)
+}
+
+func (speaker *Speaker) Print(text string) error {
+	speaker.lines = append(speaker.lines, text)
+	err := speaker.Flush()
+	if err != nil {
+		_ = speaker.output.Println(fmt.Sprintf("Warning failed to speak: %s", err.Error()))
+	}
+	return speaker.output.Print(text)
+}
+
+// Flush speaks anything printed since the last prompt, once speaking has failed nothing more is spoken
+func (speaker *Speaker) Flush() error {
+	if len(speaker.lines) == 0 || speaker.failed {
+		speaker.lines = nil
+		return nil
+	}
+	text := strings.TrimSpace(strings.Join(speaker.lines, "\n"))
+	speaker.lines = nil
+	if speaker.stop != nil {
+		speaker.stop()
+		speaker.stop = nil
+	}
+	if text == "" {
+		return nil
+	}
+
+	stop, err := Speak(text, speaker.command)
+	if err != nil {
+		speaker.failed = true
+		return fmt.Errorf("error speaking %w", err)
+	}
+	speaker.stop = stop
+	return nil
+}
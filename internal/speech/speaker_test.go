@@ -0,0 +1,132 @@
+package speech
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_filesystem "github.com/hbk619/gh-peruse/internal/filesystem/mocks"
+	"github.com/hbk619/gh-peruse/internal/requests"
+	"github.com/stretchr/testify/suite"
+)
+
+type SpeakerSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	mockOutput    *mock_filesystem.MockOutput
+	originalSpeak func(text string, command requests.CommandLine) (func(), error)
+	spoken        []string
+	stopped       int
+	speaker       *Speaker
+}
+
+func (suite *SpeakerSuite) BeforeTest(string, string) {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOutput = mock_filesystem.NewMockOutput(suite.ctrl)
+	suite.originalSpeak = Speak
+	suite.spoken = nil
+	suite.stopped = 0
+	Speak = func(text string, command requests.CommandLine) (func(), error) {
+		suite.spoken = append(suite.spoken, text)
+		return func() {
+			suite.stopped++
+		}, nil
+	}
+	suite.speaker = NewSpeaker(suite.mockOutput)
+}
+
+func (suite *SpeakerSuite) AfterTest(string, string) {
+	Speak = suite.originalSpeak
+}
+
+func (suite *SpeakerSuite) TestPrintln_waits_for_prompt() {
+	suite.mockOutput.EXPECT().Println("Mario")
+	suite.mockOutput.EXPECT().Println("Looks good")
+	suite.mockOutput.EXPECT().Print("n for next: ")
+
+	suite.NoError(suite.speaker.Println("Mario"))
+	suite.NoError(suite.speaker.Println("Looks good"))
+	suite.Empty(suite.spoken)
+
+	suite.NoError(suite.speaker.Print("n for next: "))
+	suite.Equal([]string{"Mario\nLooks good\nn for next:"}, suite.spoken)
+	suite.Equal(0, suite.stopped)
+}
+
+func (suite *SpeakerSuite) TestPrint_interrupts_previous_speech() {
+	suite.mockOutput.EXPECT().Println(gomock.Any()).Times(2)
+	suite.mockOutput.EXPECT().Print("choose: ").Times(2)
+
+	_ = suite.speaker.Println("first")
+	_ = suite.speaker.Print("choose: ")
+	_ = suite.speaker.Println("second")
+	_ = suite.speaker.Print("choose: ")
+
+	suite.Equal([]string{"first\nchoose:", "second\nchoose:"}, suite.spoken)
+	suite.Equal(1, suite.stopped)
+}
+
+func (suite *SpeakerSuite) TestFlush_nothing_to_say() {
+	suite.NoError(suite.speaker.Flush())
+	suite.Empty(suite.spoken)
+}
+
+func (suite *SpeakerSuite) TestFlush_returns_error() {
+	expectedErr := errors.New("spd-say not found")
+	Speak = func(text string, command requests.CommandLine) (func(), error) {
+		return nil, expectedErr
+	}
+	suite.mockOutput.EXPECT().Println("No comments left")
+
+	_ = suite.speaker.Println("No comments left")
+	err := suite.speaker.Flush()
+	suite.ErrorIs(err, expectedErr)
+}
+
+func (suite *SpeakerSuite) TestPrint_warns_once_and_stops_speaking_when_speaking_fails() {
+	calls := 0
+	Speak = func(text string, command requests.CommandLine) (func(), error) {
+		calls++
+		return nil, errors.New("spd-say not found")
+	}
+	gomock.InOrder(
+		suite.mockOutput.EXPECT().Println("Warning failed to speak: error speaking spd-say not found"),
+		suite.mockOutput.EXPECT().Print("choose: ").Times(2),
+	)
+
+	suite.NoError(suite.speaker.Print("choose: "))
+	suite.NoError(suite.speaker.Print("choose: "))
+	suite.Equal(1, calls)
+}
+
+func (suite *SpeakerSuite) TestCheck_returns_error_when_speech_command_is_missing() {
+	originalLookPath := lookPath
+	defer func() {
+		lookPath = originalLookPath
+	}()
+	expectedErr := errors.New("executable file not found in $PATH")
+	lookPath = func(file string) (string, error) {
+		suite.Equal(speechCommand, file)
+		return "", expectedErr
+	}
+
+	err := suite.speaker.Check()
+	suite.ErrorIs(err, expectedErr)
+	suite.ErrorContains(err, "failed to find "+speechCommand+" to speak")
+}
+
+func (suite *SpeakerSuite) TestCheck_finds_speech_command() {
+	originalLookPath := lookPath
+	defer func() {
+		lookPath = originalLookPath
+	}()
+	lookPath = func(file string) (string, error) {
+		return "/usr/bin/" + file, nil
+	}
+
+	suite.NoError(suite.speaker.Check())
+}
+
+func TestSpeakerSuite(t *testing.T) {
+	suite.Run(t, new(SpeakerSuite))
+}
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type Field string
+
+const (
+	FileNameField     Field = "file"
+	PathField         Field = "path"
+	LineField         Field = "line"
+	LineContentsField Field = "code"
+	AuthorField       Field = "author"
+	TimeField         Field = "time"
+	BodyField         Field = "body"
+	ReactionsField    Field = "reactions"
+)
+
+var fields = []Field{FileNameField, PathField, LineField, LineContentsField, AuthorField, TimeField, BodyField, ReactionsField}
+
+// Verbosity controls which parts of a comment are read out and in what order
+type Verbosity struct {
+	Name   string
+	Fields []Field
+	// RepeatPath reads the file details for every comment rather than only when the file changes
+	RepeatPath bool
+	// ReadHidden reads resolved and outdated comments after saying what they are instead of skipping them
+	ReadHidden bool
+	Resolved   string
+	Outdated   string
+}
+
+var (
+	Terse = Verbosity{
+		Name:     "terse",
+		Fields:   []Field{FileNameField, LineField, AuthorField, BodyField},
+		Resolved: "Resolved",
+		Outdated: "Outdated",
+	}
+	Normal = Verbosity{
+		Name:     "normal",
+		Fields:   []Field{FileNameField, PathField, LineField, LineContentsField, AuthorField, BodyField, ReactionsField},
+		Resolved: "This comment is resolved",
+		Outdated: "This comment is outdated",
+	}
+	Verbose = Verbosity{
+		Name:       "verbose",
+		Fields:     []Field{FileNameField, PathField, LineField, LineContentsField, AuthorField, TimeField, BodyField, ReactionsField},
+		RepeatPath: true,
+		ReadHidden: true,
+		Resolved:   "This comment is resolved",
+		Outdated:   "This comment is outdated",
+	}
+	Verbosities = []Verbosity{Terse, Normal, Verbose}
+)
+
+// ParseVerbosity finds a profile by name, or builds one from a comma separated list of fields in the order they
+// should be read, e.g. "author,time,body"
+func ParseVerbosity(value string) (Verbosity, error) {
+	for _, verbosity := range Verbosities {
+		if strings.EqualFold(verbosity.Name, value) {
+			return verbosity, nil
+		}
+	}
+
+	custom := Normal
+	custom.Name = value
+	custom.Fields = nil
+	for _, name := range strings.Split(value, ",") {
+		field := Field(strings.ToLower(strings.TrimSpace(name)))
+		if !slices.Contains(fields, field) {
+			return Verbosity{}, fmt.Errorf("unknown verbosity %s, use terse, normal, verbose or a list of %s", value, fieldNames())
+		}
+		custom.Fields = append(custom.Fields, field)
+	}
+	return custom, nil
+}
+
+func fieldNames() string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = string(field)
+	}
+	return strings.Join(names, ",")
+}
+
+// RelativeTime says how long ago something happened, e.g. "2 hours ago"
+func RelativeTime(then time.Time, now time.Time) string {
+	elapsed := now.Sub(then)
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return ago(int(elapsed/time.Minute), "minute")
+	case elapsed < 24*time.Hour:
+		return ago(int(elapsed/time.Hour), "hour")
+	case elapsed < 30*24*time.Hour:
+		return ago(int(elapsed/(24*time.Hour)), "day")
+	case elapsed < 365*24*time.Hour:
+		return ago(int(elapsed/(30*24*time.Hour)), "month")
+	default:
+		return ago(int(elapsed/(365*24*time.Hour)), "year")
+	}
+}
+
+func ago(count int, unit string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s ago", unit)
+	}
+	return fmt.Sprintf("%d %ss ago", count, unit)
+}
@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type VerbosityTestSuite struct {
+	suite.Suite
+}
+
+func (suite *VerbosityTestSuite) TestParseVerbosity_profiles() {
+	verbosity, err := ParseVerbosity("Terse")
+	suite.NoError(err)
+	suite.Equal(Terse, verbosity)
+
+	verbosity, err = ParseVerbosity("verbose")
+	suite.NoError(err)
+	suite.Equal(Verbose, verbosity)
+}
+
+func (suite *VerbosityTestSuite) TestParseVerbosity_fields() {
+	verbosity, err := ParseVerbosity("author, time,BODY")
+	suite.NoError(err)
+	suite.Equal([]Field{AuthorField, TimeField, BodyField}, verbosity.Fields)
+	suite.Equal("This comment is resolved", verbosity.Resolved)
+	suite.False(verbosity.RepeatPath)
+}
+
+func (suite *VerbosityTestSuite) TestParseVerbosity_unknown() {
+	_, err := ParseVerbosity("author,mood")
+	suite.EqualError(err, "unknown verbosity author,mood, use terse, normal, verbose or a list of file,path,line,code,author,time,body,reactions")
+}
+
+func (suite *VerbosityTestSuite) TestRelativeTime() {
+	now := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	suite.Equal("just now", RelativeTime(now.Add(-30*time.Second), now))
+	suite.Equal("1 minute ago", RelativeTime(now.Add(-time.Minute), now))
+	suite.Equal("2 hours ago", RelativeTime(now.Add(-2*time.Hour-10*time.Minute), now))
+	suite.Equal("3 days ago", RelativeTime(now.Add(-72*time.Hour), now))
+	suite.Equal("2 months ago", RelativeTime(now.AddDate(0, 0, -65), now))
+	suite.Equal("1 year ago", RelativeTime(now.AddDate(-1, -1, 0), now))
+}
+
+func TestVerbosityTestSuite(t *testing.T) {
+	suite.Run(t, new(VerbosityTestSuite))
+}